@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/scaffold"
+)
+
+const usage = `Uso:
+  crud generate resource <Nombre> --fields nombre:tipo[,nombre:tipo...] [--dir .] [--force] [--dry-run]
+
+Tipos admitidos: string, text, int, bool, float, decimal, time, date, json
+
+Ejemplo:
+  crud generate resource Product --fields name:string,price:decimal
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) < 2 || args[0] != "generate" || args[1] != "resource" {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", strings.Join(args, " "))
+	}
+
+	fs := flag.NewFlagSet("generate resource", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	fieldsSpec := fs.String("fields", "", "campos del recurso como nombre:tipo separados por coma")
+	dir := fs.String("dir", ".", "raíz del proyecto (donde está go.mod)")
+	force := fs.Bool("force", false, "sobrescribe archivos existentes")
+	dryRun := fs.Bool("dry-run", false, "muestra los archivos sin escribirlos")
+
+	// Se admite el nombre antes o después de las banderas.
+	rest := args[2:]
+	var name string
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		name, rest = rest[0], rest[1:]
+	}
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+	if name == "" && fs.NArg() > 0 {
+		name = fs.Arg(0)
+	}
+	if name == "" {
+		fs.Usage()
+		return fmt.Errorf("resource name is required")
+	}
+
+	fields, err := scaffold.ParseFields(*fieldsSpec)
+	if err != nil {
+		return err
+	}
+
+	files, err := scaffold.Generate(scaffold.Options{
+		Root:   *dir,
+		Name:   name,
+		Fields: fields,
+		Force:  *force,
+		DryRun: *dryRun,
+	})
+	for _, f := range files {
+		fmt.Println("✅", f)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !*dryRun {
+		fmt.Println("🧱 Recurso generado. Aplica la migración y ejecuta `swag init` para actualizar la documentación.")
+	}
+	return nil
+}
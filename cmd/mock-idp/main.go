@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/jnates/crud_golang/internal/mockidp"
+)
+
+const usage = `Uso:
+  mock-idp [--addr :9000] [--issuer http://localhost:9000] [--client-id crud-golang] [--client-secret ...] [--users users.json]
+
+Proveedor OpenID Connect de prueba: aprueba cualquier inicio de sesión sin pedir credenciales.
+Elige el usuario con login_hint (email o sub) o usa el primero de la lista.
+
+users.json es una lista de {"sub", "email", "email_verified", "name", "groups"}.
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("mock-idp", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	addr := fs.String("addr", ":9000", "dirección de escucha")
+	issuer := fs.String("issuer", "http://localhost:9000", "URL pública del proveedor (OIDC_ISSUER_URL)")
+	clientID := fs.String("client-id", "crud-golang", "client_id aceptado (OIDC_CLIENT_ID)")
+	clientSecret := fs.String("client-secret", "", "client_secret exigido, si se indica (OIDC_CLIENT_SECRET)")
+	usersFile := fs.String("users", "", "JSON con los usuarios; por defecto admin@example.com (grupo admins) y user@example.com")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := mockidp.Config{Issuer: *issuer, ClientID: *clientID, ClientSecret: *clientSecret}
+	if *usersFile != "" {
+		data, err := os.ReadFile(*usersFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &cfg.Users); err != nil {
+			return fmt.Errorf("parsing %s: %w", *usersFile, err)
+		}
+	}
+
+	server, err := mockidp.New(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🪪 Mock IdP escuchando en %s (issuer %s, client_id %s)\n", *addr, *issuer, *clientID)
+	return http.ListenAndServe(*addr, server)
+}
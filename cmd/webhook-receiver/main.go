@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/jnates/crud_golang/pkg/webhook"
+)
+
+const usage = `Uso:
+  webhook-receiver --secret whsec_... [--addr :9100] [--status 200]
+
+Receptor de webhooks para desarrollo: verifica la firma de cada entrega, la imprime y responde
+con --status (un valor fuera de 2xx sirve para probar los reintentos).
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("webhook-receiver", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	addr := fs.String("addr", ":9100", "dirección de escucha")
+	secret := fs.String("secret", "", "secreto del webhook, devuelto al crearlo o rotarlo")
+	status := fs.Int("status", http.StatusOK, "código con el que se responde a las entregas válidas")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *secret == "" {
+		fs.Usage()
+		return fmt.Errorf("--secret is required")
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := webhook.VerifyRequest(r, *secret)
+		if err != nil {
+			fmt.Printf("🚫 Entrega rechazada: %v\n", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, body, "", "  ") != nil {
+			pretty.Reset()
+			pretty.Write(body)
+		}
+		fmt.Printf("📬 %s (entrega %s)\n%s\n", r.Header.Get(webhook.HeaderEvent), r.Header.Get(webhook.HeaderDelivery), pretty.String())
+		w.WriteHeader(*status)
+	})
+
+	fmt.Printf("🪝 Receptor de webhooks escuchando en %s (responde %d)\n", *addr, *status)
+	return http.ListenAndServe(*addr, handler)
+}
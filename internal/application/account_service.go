@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// AccountService gestiona los flujos por email con tokens de un solo uso: restablecer la
+// contraseña olvidada y verificar el email.
+type AccountService struct {
+	users       ports.UserRepository
+	credentials ports.CredentialRepository
+	sessions    ports.SessionRepository
+	tokens      ports.UserTokenRepository
+	hasher      ports.PasswordHasher
+	mailer      ports.Mailer
+	authz       *Authorizer
+	opts        AuthOptions
+	now         func() time.Time
+}
+
+func NewAccountService(
+	users ports.UserRepository,
+	credentials ports.CredentialRepository,
+	sessions ports.SessionRepository,
+	tokens ports.UserTokenRepository,
+	hasher ports.PasswordHasher,
+	mailer ports.Mailer,
+	authz *Authorizer,
+	opts AuthOptions,
+) *AccountService {
+	return &AccountService{
+		users:       users,
+		credentials: credentials,
+		sessions:    sessions,
+		tokens:      tokens,
+		hasher:      hasher,
+		mailer:      mailer,
+		authz:       authz,
+		opts:        opts,
+		now:         time.Now,
+	}
+}
+
+// RequestPasswordReset envía en segundo plano un enlace para restablecer la contraseña. No
+// espera al envío ni informa errores: si el email no corresponde a una cuenta activa o algo
+// falla, sólo queda en el log. Así ni la respuesta ni lo que tarda revelan qué cuentas existen.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) {
+	// El envío sobrevive al fin de la petición y conserva sus valores, como el idioma.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.requestPasswordReset(ctx, email); err != nil {
+			log.Error().Err(err).Msg("🔴 Error al enviar el enlace de restablecimiento")
+		}
+	}()
+}
+
+func (s *AccountService) requestPasswordReset(ctx context.Context, email string) error {
+	creds, err := s.credentials.GetByEmail(strings.TrimSpace(email))
+	if errors.Is(err, model.ErrUserNotFound) {
+		log.Debug().Msg("🔍 Solicitud de restablecimiento para un email desconocido")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := s.users.GetByID(creds.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.Status.AllowsLogin() {
+		log.Debug().Int64("userID", user.ID).Msg("🔍 Solicitud de restablecimiento para una cuenta inactiva")
+		return nil
+	}
+
+	token, err := s.issueToken(user, model.TokenPurposePasswordReset, s.opts.ResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, user, model.EmailTemplatePasswordReset, s.link("/reset-password", token), s.opts.ResetTokenTTL)
+}
+
+// ResetPassword canjea el token y fija la contraseña nueva en una misma transacción. Además
+// desbloquea la cuenta y cierra todas las sesiones abiertas. Como en VerifyEmail, si el usuario
+// cambió de email después de recibirlo, el token no vale.
+func (s *AccountService) ResetPassword(ctx context.Context, token, password string) error {
+	if err := s.opts.Password.Validate(password); err != nil {
+		return err
+	}
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	consumed, err := s.tokens.ResetPassword(hashToken(token), hash, now)
+	if err != nil {
+		return err
+	}
+	_, err = s.sessions.RevokeAll(consumed.UserID, now)
+	return err
+}
+
+// ResolveUserID acepta el ID numérico o público de un usuario, como UserService.ResolveUserID.
+func (s *AccountService) ResolveUserID(ctx context.Context, ref string) (int64, error) {
+	return resolveUserID(ctx, s.authz, s.users, ref)
+}
+
+// RequestEmailVerification envía al usuario un enlace para verificar su email actual.
+func (s *AccountService) RequestEmailVerification(ctx context.Context, userID int64) error {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersUpdate, userID); err != nil {
+		return err
+	}
+
+	user, err := s.users.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	token, err := s.issueToken(user, model.TokenPurposeEmailVerification, s.opts.VerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, user, model.EmailTemplateEmailVerification, s.link("/verify-email", token), s.opts.VerificationTokenTTL)
+}
+
+// VerifyEmail canjea el token de verificación. Si el usuario cambió de email después de
+// recibirlo, el token no vale.
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) error {
+	consumed, err := s.tokens.Consume(hashToken(token), model.TokenPurposeEmailVerification, s.now())
+	if err != nil {
+		return err
+	}
+
+	user, err := s.users.GetByID(consumed.UserID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(user.Email, consumed.Email) {
+		return model.ErrInvalidUserToken
+	}
+	// El actor es el propio usuario: demostró que recibe los emails de la cuenta.
+	return s.users.SetEmailVerified(user.ID, true, strconv.FormatInt(user.ID, 10))
+}
+
+// issueToken anula los tokens pendientes del mismo propósito y emite uno nuevo.
+func (s *AccountService) issueToken(user *model.User, purpose model.TokenPurpose, ttl time.Duration) (string, error) {
+	now := s.now().UTC()
+	if err := s.tokens.InvalidateAll(user.ID, purpose, now); err != nil {
+		return "", err
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.tokens.Create(&model.UserToken{
+		Hash:      hash,
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// send envía la plantilla en el idioma de la petición (ver model.WithLocale).
+func (s *AccountService) send(ctx context.Context, user *model.User, template, link string, ttl time.Duration) error {
+	return s.mailer.Send(ctx, &model.Email{
+		To:       user.Email,
+		Template: template,
+		Locale:   model.LocaleFrom(ctx),
+		Data: map[string]interface{}{
+			"Name":      user.Name,
+			"Email":     user.Email,
+			"Link":      link,
+			"ExpiresIn": ttl,
+		},
+	})
+}
+
+func (s *AccountService) link(path, token string) string {
+	return strings.TrimSuffix(s.opts.PublicURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
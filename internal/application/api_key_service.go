@@ -0,0 +1,242 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// apiKeyPrefixBytes da un prefijo visible de 8 caracteres hexadecimales.
+const apiKeyPrefixBytes = 4
+
+// APIKeyService administra las API keys y autentica las peticiones que las presentan.
+type APIKeyService struct {
+	keys  ports.APIKeyRepository
+	authz *Authorizer
+	now   func() time.Time
+}
+
+func NewAPIKeyService(keys ports.APIKeyRepository, authz *Authorizer) *APIKeyService {
+	return &APIKeyService{keys: keys, authz: authz, now: time.Now}
+}
+
+// Create emite una clave nueva. El valor en claro sólo se devuelve aquí.
+func (s *APIKeyService) Create(ctx context.Context, req *model.APIKeyRequest) (*model.IssuedAPIKey, error) {
+	if err := s.authz.Require(ctx, model.PermKeysWrite); err != nil {
+		return nil, err
+	}
+	if err := validateAPIKeyRequest(req); err != nil {
+		return nil, err
+	}
+	// Nadie puede emitir una clave con permisos que no tiene.
+	for _, perm := range req.Permissions {
+		if err := s.authz.Require(ctx, perm); err != nil {
+			return nil, err
+		}
+	}
+	now := s.now().UTC()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, model.ErrInvalidAPIKeyExpiry
+	}
+
+	prefix, err := newAPIKeyPrefix()
+	if err != nil {
+		return nil, err
+	}
+	secret, hash, err := newAPIKeySecret(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &model.APIKey{
+		Name:        strings.TrimSpace(req.Name),
+		Prefix:      prefix,
+		Permissions: req.Permissions,
+		AllowedIPs:  req.AllowedIPs,
+		CreatedBy:   actorOf(ctx),
+		CreatedAt:   now,
+		ExpiresAt:   req.ExpiresAt,
+		Hash:        hash,
+	}
+	if key.AllowedIPs == nil {
+		key.AllowedIPs = []string{}
+	}
+	if err := s.keys.Create(key); err != nil {
+		return nil, err
+	}
+	return &model.IssuedAPIKey{APIKey: key, Key: secret}, nil
+}
+
+func (s *APIKeyService) Get(ctx context.Context, id int64) (*model.APIKey, error) {
+	if err := s.authz.Require(ctx, model.PermKeysRead); err != nil {
+		return nil, err
+	}
+	return s.keys.Get(id)
+}
+
+func (s *APIKeyService) List(ctx context.Context, offset, limit int) ([]*model.APIKey, error) {
+	if err := s.authz.Require(ctx, model.PermKeysRead); err != nil {
+		return nil, err
+	}
+	return s.keys.List(offset, limit)
+}
+
+// Rotate emite un secreto nuevo para la clave, que conserva su prefijo, permisos y
+// restricciones. Con grace > 0 el secreto anterior sigue valiendo durante ese tiempo.
+func (s *APIKeyService) Rotate(ctx context.Context, id int64, grace time.Duration) (*model.IssuedAPIKey, error) {
+	if err := s.authz.Require(ctx, model.PermKeysWrite); err != nil {
+		return nil, err
+	}
+	current, err := s.keys.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, hash, err := newAPIKeySecret(current.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	var previousExpiresAt *time.Time
+	if grace > 0 {
+		until := now.Add(grace)
+		previousExpiresAt = &until
+	}
+	key, err := s.keys.Rotate(id, hash, previousExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+	return &model.IssuedAPIKey{APIKey: key, Key: secret}, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id int64) error {
+	if err := s.authz.Require(ctx, model.PermKeysWrite); err != nil {
+		return err
+	}
+	if _, err := s.keys.Get(id); err != nil {
+		return err
+	}
+	return s.keys.Revoke(id, s.now().UTC())
+}
+
+// Authenticate valida la clave presentada desde la IP indicada y devuelve el principal con
+// los permisos de la clave. No requiere un principal en el contexto.
+func (s *APIKeyService) Authenticate(ctx context.Context, raw, ip string) (*model.Principal, error) {
+	prefix, ok := model.ParseAPIKey(raw)
+	if !ok {
+		return nil, model.ErrInvalidAPIKey
+	}
+	key, err := s.keys.GetByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, model.ErrAPIKeyNotFound) {
+			return nil, model.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	if !key.IsActive(now) || !matchesAPIKey(key, hashToken(raw), now) {
+		return nil, model.ErrInvalidAPIKey
+	}
+	if !ipAllowed(key.AllowedIPs, ip) {
+		log.Warn().Int64("apiKeyID", key.ID).Str("ip", ip).Msg("🔒 API key usada desde una IP no permitida")
+		return nil, model.ErrAPIKeyIPNotAllowed
+	}
+
+	if err := s.keys.Touch(key.ID, now); err != nil {
+		return nil, err
+	}
+	return &model.Principal{
+		Subject:     "apikey:" + strconv.FormatInt(key.ID, 10),
+		Permissions: key.Permissions,
+		AuthMethod:  model.AuthMethodAPIKey,
+	}, nil
+}
+
+// matchesAPIKey compara el hash con el secreto vigente y, durante el período de gracia de una
+// rotación, con el anterior.
+func matchesAPIKey(key *model.APIKey, hash string, now time.Time) bool {
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(key.Hash)) == 1 {
+		return true
+	}
+	return key.PreviousHash != "" && key.PreviousExpiresAt != nil && now.Before(*key.PreviousExpiresAt) &&
+		subtle.ConstantTimeCompare([]byte(hash), []byte(key.PreviousHash)) == 1
+}
+
+func validateAPIKeyRequest(req *model.APIKeyRequest) error {
+	for _, perm := range req.Permissions {
+		// Una API key no representa a un usuario, así que los permisos ":self" no tendrían efecto.
+		if !perm.IsValid() || strings.HasSuffix(string(perm), ":self") {
+			return fmt.Errorf("%w: %q", model.ErrInvalidPermission, perm)
+		}
+	}
+	for _, allowed := range req.AllowedIPs {
+		if _, err := parseIPRange(allowed); err != nil {
+			return fmt.Errorf("%w: %q", model.ErrInvalidIPRange, allowed)
+		}
+	}
+	return nil
+}
+
+// ipAllowed indica si la IP está en alguna de las IPs o rangos permitidos; una lista vacía
+// no restringe.
+func ipAllowed(allowed []string, ip string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, raw := range allowed {
+		if prefix, err := parseIPRange(raw); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPRange acepta una IP suelta o un rango CIDR.
+func parseIPRange(raw string) (netip.Prefix, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(raw, "/") {
+		prefix, err := netip.ParsePrefix(raw)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func newAPIKeyPrefix() (string, error) {
+	buf := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// newAPIKeySecret arma la clave ck_<prefijo>_<secreto> y su hash.
+func newAPIKeySecret(prefix string) (key, hash string, err error) {
+	secret, _, err := newOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	key = model.APIKeyTokenPrefix + prefix + "_" + secret
+	return key, hashToken(key), nil
+}
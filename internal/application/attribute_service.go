@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+)
+
+type AttributeService struct {
+	repo  ports.AttributeDefinitionRepository
+	authz *Authorizer
+}
+
+func NewAttributeService(repo ports.AttributeDefinitionRepository, authz *Authorizer) *AttributeService {
+	return &AttributeService{repo: repo, authz: authz}
+}
+
+func (s *AttributeService) Get(ctx context.Context, name string) (*model.AttributeDefinition, error) {
+	if err := s.authz.Require(ctx, model.PermAttrsRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByName(name)
+}
+
+func (s *AttributeService) Create(ctx context.Context, def *model.AttributeDefinition) (int64, error) {
+	if err := s.authz.Require(ctx, model.PermAttrsWrite); err != nil {
+		return 0, err
+	}
+	def.Name = strings.TrimSpace(def.Name)
+	if err := def.Check(); err != nil {
+		return 0, err
+	}
+	return s.repo.Create(def)
+}
+
+func (s *AttributeService) Delete(ctx context.Context, name string) error {
+	if err := s.authz.Require(ctx, model.PermAttrsWrite); err != nil {
+		return err
+	}
+	return s.repo.Delete(name)
+}
+
+func (s *AttributeService) List(ctx context.Context) ([]*model.AttributeDefinition, error) {
+	if err := s.authz.Require(ctx, model.PermAttrsRead); err != nil {
+		return nil, err
+	}
+	return s.repo.List()
+}
+
+// validateAttributes comprueba los valores de un usuario contra las definiciones registradas:
+// rechaza atributos desconocidos, exige los obligatorios y valida tipo, enumerados y patrón.
+func validateAttributes(defs []*model.AttributeDefinition, values map[string]interface{}) error {
+	known := make(map[string]*model.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		known[def.Name] = def
+		if _, ok := values[def.Name]; def.Required && !ok {
+			return fmt.Errorf("%w: %s is required", model.ErrInvalidAttribute, def.Name)
+		}
+	}
+
+	for name, value := range values {
+		def, ok := known[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", model.ErrUnknownAttribute, name)
+		}
+		if err := def.Validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAttributeFilters convierte los filtros de atributos recibidos como texto a valores tipados.
+func parseAttributeFilters(defs []*model.AttributeDefinition, raw map[string]string) (map[string]interface{}, error) {
+	known := make(map[string]*model.AttributeDefinition, len(defs))
+	for _, def := range defs {
+		known[def.Name] = def
+	}
+
+	parsed := make(map[string]interface{}, len(raw))
+	for name, value := range raw {
+		def, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", model.ErrUnknownAttribute, name)
+		}
+		v, err := def.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		parsed[name] = v
+	}
+	return parsed, nil
+}
@@ -0,0 +1,324 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+)
+
+// AuthOptions configura la política de contraseñas, el bloqueo por intentos fallidos, la
+// duración de las sesiones y los enlaces que se envían por email.
+type AuthOptions struct {
+	Password             model.PasswordPolicy
+	MaxFailedLogins      int
+	LockoutDuration      time.Duration
+	SessionTTL           time.Duration
+	ResetTokenTTL        time.Duration
+	VerificationTokenTTL time.Duration
+	InvitationTTL        time.Duration
+	// PublicURL es la base de los enlaces de los emails (p. ej. https://app.example.com).
+	PublicURL string
+}
+
+// DefaultAuthOptions bloquea el acceso 15 minutos tras 5 intentos fallidos seguidos, mantiene
+// las sesiones 30 días y da 1 hora para restablecer la contraseña, 48 para verificar el email
+// y 7 días para aceptar una invitación.
+func DefaultAuthOptions() AuthOptions {
+	return AuthOptions{
+		Password:             model.DefaultPasswordPolicy(),
+		MaxFailedLogins:      5,
+		LockoutDuration:      15 * time.Minute,
+		SessionTTL:           30 * 24 * time.Hour,
+		ResetTokenTTL:        time.Hour,
+		VerificationTokenTTL: 48 * time.Hour,
+		InvitationTTL:        7 * 24 * time.Hour,
+		PublicURL:            "http://localhost:8080",
+	}
+}
+
+type AuthService struct {
+	users       ports.UserRepository
+	credentials ports.CredentialRepository
+	sessions    ports.SessionRepository
+	groups      ports.GroupRepository
+	hasher      ports.PasswordHasher
+	tokens      ports.TokenIssuer
+	authz       *Authorizer
+	opts        AuthOptions
+	now         func() time.Time
+
+	// dummyHash se compara cuando el email no existe para que la respuesta tarde lo mismo
+	// y no revele qué cuentas están registradas.
+	dummyHash string
+}
+
+func NewAuthService(
+	users ports.UserRepository,
+	credentials ports.CredentialRepository,
+	sessions ports.SessionRepository,
+	groups ports.GroupRepository,
+	hasher ports.PasswordHasher,
+	tokens ports.TokenIssuer,
+	authz *Authorizer,
+	opts AuthOptions,
+) *AuthService {
+	dummyHash, _ := hasher.Hash("not-a-real-password")
+	return &AuthService{
+		users:       users,
+		credentials: credentials,
+		sessions:    sessions,
+		groups:      groups,
+		hasher:      hasher,
+		tokens:      tokens,
+		authz:       authz,
+		opts:        opts,
+		now:         time.Now,
+		dummyHash:   dummyHash,
+	}
+}
+
+// Login valida email y contraseña, abre una sesión para el dispositivo y emite sus tokens.
+// Los intentos fallidos se cuentan y, al llegar a MaxFailedLogins, el acceso se bloquea
+// durante LockoutDuration.
+func (s *AuthService) Login(ctx context.Context, email, password string, client model.ClientInfo) (*model.TokenPair, error) {
+	creds, err := s.credentials.GetByEmail(strings.TrimSpace(email))
+	if errors.Is(err, model.ErrUserNotFound) {
+		s.hasher.Compare(s.dummyHash, password)
+		return nil, model.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if creds.IsLocked(now) {
+		return nil, model.ErrAccountLocked
+	}
+	if creds.PasswordHash == "" || !s.hasher.Compare(creds.PasswordHash, password) {
+		return nil, s.recordFailedLogin(creds.UserID, now)
+	}
+
+	user, err := s.users.GetByID(creds.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Status.AllowsLogin() {
+		return nil, model.ErrAccountInactive
+	}
+
+	if creds.FailedLogins > 0 || creds.LockedUntil != nil {
+		if err := s.credentials.ResetFailedLogins(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.startSession(user, nil, client, now)
+}
+
+// startSession abre una sesión para el dispositivo y emite sus tokens. externalRoles son los
+// roles que otorgó un proveedor de identidad y quedan asociados a la sesión.
+func (s *AuthService) startSession(user *model.User, externalRoles []string, client model.ClientInfo, now time.Time) (*model.TokenPair, error) {
+	refreshToken, refreshHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	session := &model.Session{
+		UserID:        user.ID,
+		UserAgent:     client.UserAgent,
+		IP:            client.IP,
+		CreatedAt:     now.UTC(),
+		ExpiresAt:     now.Add(s.opts.SessionTTL).UTC(),
+		ExternalRoles: externalRoles,
+	}
+	if err := s.sessions.Create(session, refreshHash); err != nil {
+		return nil, err
+	}
+	return s.issue(user, session, refreshToken)
+}
+
+// Refresh canjea un token de renovación por uno nuevo y un token de acceso. Cada token sirve
+// una sola vez: si se presenta uno ya canjeado, la sesión se revoca por posible robo.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client model.ClientInfo) (*model.TokenPair, error) {
+	newToken, newHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	session, err := s.sessions.Rotate(hashToken(refreshToken), newHash, client, now)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetByID(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Status.AllowsLogin() {
+		if err := s.sessions.Revoke(session.ID, now); err != nil {
+			return nil, err
+		}
+		return nil, model.ErrAccountInactive
+	}
+	return s.issue(user, session, newToken)
+}
+
+// Logout cierra la sesión del token de renovación. Un token desconocido no es un error, así que
+// cerrar sesión es idempotente.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	session, err := s.sessions.GetByToken(hashToken(refreshToken))
+	if errors.Is(err, model.ErrSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.sessions.Revoke(session.ID, s.now())
+}
+
+// ResolveUserID acepta el ID numérico o público de un usuario, como UserService.ResolveUserID.
+func (s *AuthService) ResolveUserID(ctx context.Context, ref string) (int64, error) {
+	return resolveUserID(ctx, s.authz, s.users, ref)
+}
+
+// Sessions lista las sesiones activas de un usuario y marca la del token actual.
+func (s *AuthService) Sessions(ctx context.Context, userID int64) ([]*model.Session, error) {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersRead, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.users.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessions.ListActive(userID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := model.PrincipalFrom(ctx); ok {
+		for _, session := range sessions {
+			session.Current = session.ID == p.SessionID
+		}
+	}
+	return sessions, nil
+}
+
+// RevokeSession cierra una sesión concreta del usuario.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersUpdate, userID); err != nil {
+		return err
+	}
+	session, err := s.sessions.Get(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return model.ErrSessionNotFound
+	}
+	return s.sessions.Revoke(sessionID, s.now())
+}
+
+// RevokeSessions cierra todas las sesiones del usuario y devuelve cuántas estaban activas.
+func (s *AuthService) RevokeSessions(ctx context.Context, userID int64) (int, error) {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersUpdate, userID); err != nil {
+		return 0, err
+	}
+	if _, err := s.users.GetByID(userID); err != nil {
+		return 0, err
+	}
+	return s.sessions.RevokeAll(userID, s.now())
+}
+
+// issue emite el token de acceso de la sesión, con los roles del usuario más los externos de
+// la sesión, y le agrega el token de renovación.
+func (s *AuthService) issue(user *model.User, session *model.Session, refreshToken string) (*model.TokenPair, error) {
+	principal, err := s.principalFor(user)
+	if err != nil {
+		return nil, err
+	}
+	principal.SessionID = session.ID
+	principal.Roles = normalizeRoles(append(principal.Roles, session.ExternalRoles...))
+
+	pair, err := s.tokens.Issue(principal)
+	if err != nil {
+		return nil, err
+	}
+	pair.RefreshToken = refreshToken
+	return pair, nil
+}
+
+// SetPassword cambia la contraseña de un usuario. Quien cambia la suya debe indicar la actual;
+// un administrador puede restablecer la de otros sin ella. Como al restablecerla por email, se
+// cierran las sesiones del usuario, salvo la de quien cambia su propia contraseña.
+func (s *AuthService) SetPassword(ctx context.Context, userID int64, current, password string) error {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersUpdate, userID); err != nil {
+		return err
+	}
+	if err := s.opts.Password.Validate(password); err != nil {
+		return err
+	}
+
+	creds, err := s.credentials.Get(userID)
+	if err != nil {
+		return err
+	}
+	p, _ := model.PrincipalFrom(ctx)
+	self := p.UserID == userID
+	if self && creds.PasswordHash != "" {
+		if !s.hasher.Compare(creds.PasswordHash, current) {
+			return model.ErrInvalidCredentials
+		}
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	now := s.now().UTC()
+	if err := s.credentials.SetPassword(userID, hash, now); err != nil {
+		return err
+	}
+
+	var keep int64
+	if self {
+		keep = p.SessionID
+	}
+	_, err = s.sessions.RevokeOthers(userID, keep, now)
+	return err
+}
+
+func (s *AuthService) recordFailedLogin(userID int64, now time.Time) error {
+	attempts, err := s.credentials.RecordFailedLogin(userID)
+	if err != nil {
+		return err
+	}
+	if s.opts.MaxFailedLogins > 0 && attempts >= s.opts.MaxFailedLogins {
+		if err := s.credentials.Lock(userID, now.Add(s.opts.LockoutDuration)); err != nil {
+			return err
+		}
+		return model.ErrAccountLocked
+	}
+	return model.ErrInvalidCredentials
+}
+
+// principalFor arma el principal de un usuario local: el rol "user" más los roles de sus grupos.
+func (s *AuthService) principalFor(user *model.User) (*model.Principal, error) {
+	groups, err := s.groups.ListByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	roles := []string{model.RoleUser}
+	for _, group := range groups {
+		roles = append(roles, group.Roles...)
+	}
+	return &model.Principal{
+		Subject: strconv.FormatInt(user.ID, 10),
+		UserID:  user.ID,
+		Email:   user.Email,
+		Roles:   normalizeRoles(roles),
+	}, nil
+}
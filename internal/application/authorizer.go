@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// Authorizer aplica la política de permisos sobre el principal que viaja en el contexto.
+type Authorizer struct {
+	policy *model.Policy
+}
+
+func NewAuthorizer(policy *model.Policy) *Authorizer {
+	if policy == nil {
+		policy = model.DefaultPolicy()
+	}
+	return &Authorizer{policy: policy}
+}
+
+// Require exige que el principal tenga el permiso indicado.
+func (a *Authorizer) Require(ctx context.Context, perm model.Permission) error {
+	p, ok := model.PrincipalFrom(ctx)
+	if !ok {
+		return model.ErrUnauthenticated
+	}
+	if !a.allows(p, perm) {
+		return &model.ForbiddenError{Permission: perm}
+	}
+	return nil
+}
+
+// RequireOnUser exige el permiso sobre un usuario concreto: basta con la variante ":self"
+// cuando el principal es ese mismo usuario.
+func (a *Authorizer) RequireOnUser(ctx context.Context, perm model.Permission, userID int64) error {
+	p, ok := model.PrincipalFrom(ctx)
+	if !ok {
+		return model.ErrUnauthenticated
+	}
+	if a.allows(p, perm) {
+		return nil
+	}
+	if p.UserID != 0 && p.UserID == userID && a.allows(p, perm.Self()) {
+		return nil
+	}
+	return &model.ForbiddenError{Permission: perm}
+}
+
+// allows combina los permisos de los roles del principal con los que tiene otorgados directamente.
+func (a *Authorizer) allows(p *model.Principal, perm model.Permission) bool {
+	return a.policy.Allows(p.Roles, perm) || p.Grants(perm)
+}
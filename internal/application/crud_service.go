@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+)
+
+// CRUDService es el caso de uso genérico para entidades sin reglas de negocio propias.
+// Los servicios con reglas (UserService, GroupService) exponen los mismos métodos con su lógica.
+// Los permisos se derivan del recurso: "<recurso>:read" y "<recurso>:write".
+type CRUDService[T any, ID comparable] struct {
+	repo  ports.Repository[T, ID]
+	authz *Authorizer
+	read  model.Permission
+	write model.Permission
+}
+
+func NewCRUDService[T any, ID comparable](repo ports.Repository[T, ID], authz *Authorizer, resource string) *CRUDService[T, ID] {
+	return &CRUDService[T, ID]{
+		repo:  repo,
+		authz: authz,
+		read:  model.Permission(resource + ":read"),
+		write: model.Permission(resource + ":write"),
+	}
+}
+
+func (s *CRUDService[T, ID]) Get(ctx context.Context, id ID) (*T, error) {
+	if err := s.authz.Require(ctx, s.read); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *CRUDService[T, ID]) Create(ctx context.Context, entity *T) (ID, error) {
+	if err := s.authz.Require(ctx, s.write); err != nil {
+		var zero ID
+		return zero, err
+	}
+	return s.repo.Create(entity)
+}
+
+func (s *CRUDService[T, ID]) Update(ctx context.Context, entity *T) error {
+	if err := s.authz.Require(ctx, s.write); err != nil {
+		return err
+	}
+	return s.repo.Update(entity)
+}
+
+func (s *CRUDService[T, ID]) Delete(ctx context.Context, id ID) error {
+	if err := s.authz.Require(ctx, s.write); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *CRUDService[T, ID]) List(ctx context.Context, offset, limit int, filter map[string]interface{}) ([]*T, error) {
+	if err := s.authz.Require(ctx, s.read); err != nil {
+		return nil, err
+	}
+	return s.repo.List(offset, limit, filter)
+}
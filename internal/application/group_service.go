@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+)
+
+type GroupService struct {
+	repo  ports.GroupRepository
+	users ports.UserRepository
+	authz *Authorizer
+}
+
+func NewGroupService(repo ports.GroupRepository, users ports.UserRepository, authz *Authorizer) *GroupService {
+	return &GroupService{repo: repo, users: users, authz: authz}
+}
+
+func (s *GroupService) Get(ctx context.Context, id int64) (*model.Group, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *GroupService) Create(ctx context.Context, group *model.Group) (int64, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsWrite); err != nil {
+		return 0, err
+	}
+	group.Roles = normalizeRoles(group.Roles)
+	return s.repo.Create(group)
+}
+
+// CreateWithMembers crea el grupo con sus miembros iniciales en una misma transacción; cada
+// referencia es un ID numérico o público.
+func (s *GroupService) CreateWithMembers(ctx context.Context, group *model.Group, refs []model.UserRef) (int64, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsWrite); err != nil {
+		return 0, err
+	}
+	userIDs, err := s.resolveMembers(refs)
+	if err != nil {
+		return 0, err
+	}
+	group.Roles = normalizeRoles(group.Roles)
+	return s.repo.CreateWithMembers(group, userIDs)
+}
+
+func (s *GroupService) Update(ctx context.Context, group *model.Group) error {
+	if err := s.authz.Require(ctx, model.PermGroupsWrite); err != nil {
+		return err
+	}
+	group.Roles = normalizeRoles(group.Roles)
+	return s.repo.Update(group)
+}
+
+// Replace actualiza el grupo y deja como miembros exactamente los indicados, en una misma
+// transacción.
+func (s *GroupService) Replace(ctx context.Context, group *model.Group, refs []model.UserRef) error {
+	if err := s.authz.Require(ctx, model.PermGroupsWrite); err != nil {
+		return err
+	}
+	userIDs, err := s.resolveMembers(refs)
+	if err != nil {
+		return err
+	}
+	group.Roles = normalizeRoles(group.Roles)
+	return s.repo.ReplaceWithMembers(group, userIDs)
+}
+
+func (s *GroupService) Delete(ctx context.Context, id int64) error {
+	if err := s.authz.Require(ctx, model.PermGroupsWrite); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *GroupService) List(ctx context.Context, offset, limit int, filter map[string]interface{}) ([]*model.Group, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsRead); err != nil {
+		return nil, err
+	}
+	return s.repo.List(offset, limit, filter)
+}
+
+// Count cuenta los grupos que cumplen los mismos filtros que List.
+func (s *GroupService) Count(ctx context.Context, filter map[string]interface{}) (int, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsRead); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(filter)
+}
+
+// AddMembers agrega usuarios al grupo; cada referencia es un ID numérico o público.
+func (s *GroupService) AddMembers(ctx context.Context, groupID int64, refs []model.UserRef) error {
+	userIDs, err := s.memberIDs(ctx, groupID, refs)
+	if err != nil {
+		return err
+	}
+	return s.repo.AddMembers(groupID, userIDs)
+}
+
+// RemoveMembers quita usuarios del grupo; cada referencia es un ID numérico o público.
+func (s *GroupService) RemoveMembers(ctx context.Context, groupID int64, refs []model.UserRef) error {
+	userIDs, err := s.memberIDs(ctx, groupID, refs)
+	if err != nil {
+		return err
+	}
+	return s.repo.RemoveMembers(groupID, userIDs)
+}
+
+// memberIDs comprueba el permiso y el grupo y resuelve las referencias a usuarios.
+func (s *GroupService) memberIDs(ctx context.Context, groupID int64, refs []model.UserRef) ([]int64, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsWrite); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(groupID); err != nil {
+		return nil, err
+	}
+	return s.resolveMembers(refs)
+}
+
+// resolveMembers resuelve las referencias a usuarios sin repetidos; un ID público que no existe
+// es ErrUserNotFound.
+func (s *GroupService) resolveMembers(refs []model.UserRef) ([]int64, error) {
+	userIDs, err := resolveUserIDs(s.users, refs)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range userIDs {
+		if id == 0 {
+			return nil, fmt.Errorf("%w: %q", model.ErrUserNotFound, refs[i])
+		}
+	}
+	return uniqueIDs(userIDs), nil
+}
+
+func (s *GroupService) Members(ctx context.Context, groupID int64) ([]*model.User, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsRead); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(groupID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(groupID)
+}
+
+// ResolveUserID acepta el ID numérico o público de un usuario, como UserService.ResolveUserID.
+func (s *GroupService) ResolveUserID(ctx context.Context, ref string) (int64, error) {
+	return resolveUserID(ctx, s.authz, s.users, ref)
+}
+
+// GroupsOfUser lista los grupos de un usuario; cada usuario puede consultar los suyos.
+func (s *GroupService) GroupsOfUser(ctx context.Context, userID int64) ([]*model.Group, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsRead); err != nil {
+		if s.authz.RequireOnUser(ctx, model.PermUsersRead, userID) != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.users.GetByID(userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByUser(userID)
+}
+
+// GroupsOfUsers lista los grupos de varios usuarios en una sola consulta. Sin groups:read sólo
+// se pueden pedir los de la propia cuenta.
+func (s *GroupService) GroupsOfUsers(ctx context.Context, userIDs []int64) (map[int64][]*model.Group, error) {
+	if err := s.authz.Require(ctx, model.PermGroupsRead); err != nil {
+		for _, userID := range userIDs {
+			if s.authz.RequireOnUser(ctx, model.PermUsersRead, userID) != nil {
+				return nil, err
+			}
+		}
+	}
+	return s.repo.ListByUsers(userIDs)
+}
+
+// normalizeRoles elimina roles vacíos y duplicados conservando el orden.
+func normalizeRoles(roles []string) []string {
+	seen := make(map[string]bool, len(roles))
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role == "" || seen[role] {
+			continue
+		}
+		seen[role] = true
+		normalized = append(normalized, role)
+	}
+	return normalized
+}
@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+)
+
+// InvitationService invita usuarios por email: crea la cuenta en estado invited y el invitado
+// la activa al aceptar, eligiendo su nombre y contraseña.
+type InvitationService struct {
+	invitations ports.InvitationRepository
+	users       ports.UserRepository
+	attributes  ports.AttributeDefinitionRepository
+	hasher      ports.PasswordHasher
+	mailer      ports.Mailer
+	authz       *Authorizer
+	opts        AuthOptions
+	now         func() time.Time
+}
+
+func NewInvitationService(
+	invitations ports.InvitationRepository,
+	users ports.UserRepository,
+	attributes ports.AttributeDefinitionRepository,
+	hasher ports.PasswordHasher,
+	mailer ports.Mailer,
+	authz *Authorizer,
+	opts AuthOptions,
+) *InvitationService {
+	return &InvitationService{
+		invitations: invitations,
+		users:       users,
+		attributes:  attributes,
+		hasher:      hasher,
+		mailer:      mailer,
+		authz:       authz,
+		opts:        opts,
+		now:         time.Now,
+	}
+}
+
+// Invite crea el usuario invitado y le envía el enlace para aceptar. Falla con ErrEmailTaken
+// si ya existe un usuario con ese email, aunque esté pendiente de otra invitación.
+func (s *InvitationService) Invite(ctx context.Context, req *model.InvitationRequest) (*model.Invitation, error) {
+	if err := s.authz.Require(ctx, model.PermUsersCreate); err != nil {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(req.Email)
+
+	defs, err := s.attributes.List()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAttributes(defs, req.Attributes); err != nil {
+		return nil, err
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	invitation := &model.Invitation{
+		Email:     email,
+		InvitedBy: actorOf(ctx),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.opts.InvitationTTL),
+	}
+	user := &model.User{Name: strings.TrimSpace(req.Name), Email: email, Attributes: req.Attributes}
+	if err := s.invitations.Create(invitation, user, hash); err != nil {
+		return nil, err
+	}
+
+	if err := s.send(ctx, invitation, user.Name, token); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (s *InvitationService) Get(ctx context.Context, id int64) (*model.Invitation, error) {
+	if err := s.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return nil, err
+	}
+	return s.invitations.Get(id)
+}
+
+func (s *InvitationService) List(ctx context.Context, offset, limit int, status model.InvitationStatus) ([]*model.Invitation, error) {
+	if err := s.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return nil, err
+	}
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("%w: %q", model.ErrInvalidInvitationStatus, status)
+	}
+	return s.invitations.List(offset, limit, status)
+}
+
+// Resend vuelve a enviar una invitación pendiente con un token nuevo y el plazo completo; el
+// enlace anterior deja de valer.
+func (s *InvitationService) Resend(ctx context.Context, id int64) (*model.Invitation, error) {
+	if err := s.authz.Require(ctx, model.PermUsersCreate); err != nil {
+		return nil, err
+	}
+	if _, err := s.invitations.Get(id); err != nil {
+		return nil, err
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	invitation, err := s.invitations.Renew(id, hash, now.Add(s.opts.InvitationTTL), now)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetByID(invitation.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.send(ctx, invitation, user.Name, token); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Revoke anula una invitación pendiente y elimina al usuario invitado.
+func (s *InvitationService) Revoke(ctx context.Context, id int64) (*model.Invitation, error) {
+	if err := s.authz.Require(ctx, model.PermUsersCreate); err != nil {
+		return nil, err
+	}
+	if _, err := s.invitations.Get(id); err != nil {
+		return nil, err
+	}
+	return s.invitations.Revoke(id, actorOf(ctx), s.now().UTC())
+}
+
+// Accept canjea la invitación: fija el nombre y la contraseña del invitado, marca su email
+// como verificado (recibió el enlace) y activa la cuenta en una misma transacción. No requiere
+// autenticación.
+func (s *InvitationService) Accept(ctx context.Context, req *model.InvitationAcceptance) (*model.User, error) {
+	if err := s.opts.Password.Validate(req.Password); err != nil {
+		return nil, err
+	}
+	hash, err := s.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	_, user, err := s.invitations.Accept(hashToken(req.Token), strings.TrimSpace(req.Name), hash, s.now().UTC())
+	return user, err
+}
+
+// ExpirePending vence las invitaciones cuyo plazo pasó. La ejecuta periódicamente una tarea
+// en segundo plano con model.SystemPrincipal.
+func (s *InvitationService) ExpirePending(ctx context.Context) error {
+	if err := s.authz.Require(ctx, model.PermUsersCreate); err != nil {
+		return err
+	}
+	_, err := s.invitations.ExpirePending(s.now().UTC())
+	return err
+}
+
+func (s *InvitationService) send(ctx context.Context, invitation *model.Invitation, name, token string) error {
+	link := strings.TrimSuffix(s.opts.PublicURL, "/") + "/accept-invitation?token=" + url.QueryEscape(token)
+	return s.mailer.Send(ctx, &model.Email{
+		To:       invitation.Email,
+		Template: model.EmailTemplateInvitation,
+		Locale:   model.LocaleFrom(ctx),
+		Data: map[string]interface{}{
+			"Name":      name,
+			"Email":     invitation.Email,
+			"Link":      link,
+			"ExpiresIn": s.opts.InvitationTTL,
+		},
+	})
+}
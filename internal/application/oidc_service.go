@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// OIDCOptions configura el inicio de sesión con un proveedor OpenID Connect.
+type OIDCOptions struct {
+	// GroupRoles asigna roles locales a cada grupo del proveedor.
+	GroupRoles map[string][]string
+	// AutoProvision crea el usuario en su primer inicio de sesión si ninguno local tiene su email.
+	AutoProvision bool
+	// StateTTL es el tiempo que tiene el usuario para volver del proveedor.
+	StateTTL time.Duration
+}
+
+// DefaultOIDCOptions crea los usuarios que no existen y da 10 minutos para volver del proveedor.
+func DefaultOIDCOptions() OIDCOptions {
+	return OIDCOptions{AutoProvision: true, StateTTL: 10 * time.Minute}
+}
+
+// OIDCService gestiona el inicio de sesión con un proveedor OpenID Connect (authorization code
+// + PKCE). La identidad externa se vincula con un usuario local por su email verificado y los
+// grupos del proveedor se traducen a roles de la sesión.
+type OIDCService struct {
+	provider    ports.IdentityProvider
+	states      ports.LoginStateStore
+	identities  ports.IdentityRepository
+	users       ports.UserRepository
+	credentials ports.CredentialRepository
+	auth        *AuthService
+	opts        OIDCOptions
+	now         func() time.Time
+}
+
+// NewOIDCService crea el servicio; con provider nil el inicio de sesión externo queda deshabilitado.
+func NewOIDCService(
+	provider ports.IdentityProvider,
+	states ports.LoginStateStore,
+	identities ports.IdentityRepository,
+	users ports.UserRepository,
+	credentials ports.CredentialRepository,
+	auth *AuthService,
+	opts OIDCOptions,
+) *OIDCService {
+	return &OIDCService{
+		provider:    provider,
+		states:      states,
+		identities:  identities,
+		users:       users,
+		credentials: credentials,
+		auth:        auth,
+		opts:        opts,
+		now:         time.Now,
+	}
+}
+
+// Enabled indica si hay un proveedor configurado.
+func (s *OIDCService) Enabled() bool {
+	return s.provider != nil
+}
+
+// Begin guarda el estado del inicio de sesión y devuelve la URL del proveedor junto con el
+// parámetro state, que el cliente debe conservar para comprobarlo a la vuelta.
+func (s *OIDCService) Begin(ctx context.Context) (authURL, state string, err error) {
+	state, _, err = newOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, _, err := newOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, _, err := newOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.states.Save(&model.OIDCLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    s.now().Add(s.opts.StateTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err = s.provider.AuthCodeURL(ctx, state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// Complete canjea el código que devolvió el proveedor, vincula o crea el usuario local y abre
+// una sesión con los roles de sus grupos. Cada state sirve una sola vez.
+func (s *OIDCService) Complete(ctx context.Context, state, code string, client model.ClientInfo) (*model.TokenPair, error) {
+	now := s.now()
+	pending, err := s.states.Take(state, now)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		log.Warn().Err(err).Msg("🔒 El proveedor de identidad rechazó el inicio de sesión")
+		return nil, model.ErrExternalLoginFailed
+	}
+
+	user, err := s.resolveUser(identity, now)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Status.AllowsLogin() {
+		return nil, model.ErrAccountInactive
+	}
+	return s.auth.startSession(user, s.rolesFor(identity.Groups), client, now)
+}
+
+// resolveUser busca el usuario vinculado a la identidad. La primera vez lo vincula con el
+// usuario local que tenga el mismo email, siempre que el proveedor lo haya verificado, o lo
+// crea si AutoProvision está activo.
+func (s *OIDCService) resolveUser(identity *model.ExternalIdentity, now time.Time) (*model.User, error) {
+	linked, err := s.identities.GetBySubject(identity.Issuer, identity.Subject)
+	if err == nil {
+		if err := s.identities.Touch(identity.Issuer, identity.Subject, identity.Email, now.UTC()); err != nil {
+			return nil, err
+		}
+		return s.users.GetByID(linked.UserID)
+	}
+	if !errors.Is(err, model.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, model.ErrExternalEmailUnverified
+	}
+	link := &model.UserIdentity{
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: now.UTC(),
+	}
+
+	creds, err := s.credentials.GetByEmail(identity.Email)
+	switch {
+	case err == nil:
+		link.UserID = creds.UserID
+		if err := s.identities.Link(link); err != nil {
+			return nil, err
+		}
+		return s.users.GetByID(creds.UserID)
+	case !errors.Is(err, model.ErrUserNotFound):
+		return nil, err
+	case !s.opts.AutoProvision:
+		return nil, model.ErrExternalUserNotFound
+	}
+
+	user := &model.User{Name: strings.TrimSpace(identity.Name), Email: identity.Email}
+	if user.Name == "" {
+		user.Name = identity.Email
+	}
+	if err := s.identities.Provision(user, link); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// rolesFor traduce los grupos del proveedor a roles locales según GroupRoles; los grupos sin
+// entrada no otorgan nada.
+func (s *OIDCService) rolesFor(groups []string) []string {
+	var roles []string
+	for _, group := range groups {
+		roles = append(roles, s.opts.GroupRoles[group]...)
+	}
+	return normalizeRoles(roles)
+}
+
+// pkceChallenge calcula el code_challenge S256 de un verificador PKCE (RFC 7636).
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
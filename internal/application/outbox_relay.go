@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// OutboxOptions configura el relay del outbox.
+type OutboxOptions struct {
+	// BatchSize es el máximo de eventos que se reservan por vuelta.
+	BatchSize int
+	// Lease es cuánto dura la reserva de un lote; si el relay cae, otro retoma los eventos al vencer.
+	Lease time.Duration
+	// MaxBackoff limita la espera entre reintentos, que se duplica en cada fallo desde 1s.
+	MaxBackoff time.Duration
+	// Retention es cuánto se conservan los eventos ya publicados.
+	Retention time.Duration
+}
+
+// DefaultOutboxOptions reserva lotes de 100 eventos por 1 minuto, reintenta hasta cada 10 minutos
+// y conserva los publicados 7 días.
+func DefaultOutboxOptions() OutboxOptions {
+	return OutboxOptions{BatchSize: 100, Lease: time.Minute, MaxBackoff: 10 * time.Minute, Retention: 7 * 24 * time.Hour}
+}
+
+// OutboxRelay publica los eventos del outbox con el EventPublisher configurado. La entrega es al
+// menos una vez y en orden por agregado: si un evento falla, los siguientes del mismo agregado
+// esperan a que se publique.
+type OutboxRelay struct {
+	outbox    ports.OutboxRepository
+	publisher ports.EventPublisher
+	opts      OutboxOptions
+	now       func() time.Time
+}
+
+func NewOutboxRelay(outbox ports.OutboxRepository, publisher ports.EventPublisher, opts OutboxOptions) *OutboxRelay {
+	return &OutboxRelay{outbox: outbox, publisher: publisher, opts: opts, now: time.Now}
+}
+
+// Relay publica lotes de eventos pendientes hasta vaciar el outbox o encontrar un error de
+// la base de datos; los fallos del publicador sólo programan un reintento.
+func (r *OutboxRelay) Relay(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := r.now().UTC()
+		events, err := r.outbox.Claim(r.opts.BatchSize, now, now.Add(r.opts.Lease))
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		if err := r.publish(ctx, events); err != nil {
+			return err
+		}
+		if len(events) < r.opts.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, events []*model.Event) error {
+	blocked := make(map[string]bool)
+	for _, event := range events {
+		key := event.AggregateType + ":" + event.AggregateID
+		if blocked[key] {
+			// Un evento anterior del agregado falló: éste espera a la siguiente vuelta.
+			if err := r.outbox.Release(event.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			blocked[key] = true
+			retryAt := r.now().UTC().Add(r.backoff(event.Attempts + 1))
+			log.Warn().Err(err).Int64("eventID", event.ID).Str("type", event.Type).Int("attempts", event.Attempts+1).
+				Time("retryAt", retryAt).Msg("⚠️ Error al publicar evento; se reintentará")
+			if err := r.outbox.MarkFailed(event.ID, err.Error(), retryAt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkPublished(event.ID, r.now().UTC()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backoff duplica la espera en cada intento fallido, desde 1s hasta MaxBackoff.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	wait := time.Second
+	for i := 1; i < attempts && wait < r.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, r.opts.MaxBackoff)
+}
+
+// Purge elimina los eventos publicados hace más de Retention.
+func (r *OutboxRelay) Purge(ctx context.Context) error {
+	purged, err := r.outbox.PurgePublished(r.now().UTC().Add(-r.opts.Retention))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Info().Int("purged", purged).Msg("🧹 Eventos publicados purgados del outbox")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package application
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// opaqueTokenBytes da 256 bits de entropía a los tokens que se entregan al cliente.
+const opaqueTokenBytes = 32
+
+// newOpaqueToken genera un token aleatorio para el cliente y el hash que se guarda en su lugar.
+func newOpaqueToken() (token, hash string, err error) {
+	buf := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+// hashToken devuelve el SHA-256 en hexadecimal de un token opaco.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,307 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// UserFeedOptions configura el stream de cambios de usuarios.
+type UserFeedOptions struct {
+	// Buffer es cuántos eventos puede acumular un suscriptor lento antes de cortarle el stream;
+	// al reconectar con Last-Event-ID recupera lo que se perdió.
+	Buffer int
+	// ReplayBatch es el tamaño de las páginas con que se releen eventos del outbox.
+	ReplayBatch int
+	// Settle es cuánto espera un evento antes de repartirse. Los IDs del outbox se asignan al
+	// insertar, así que una transacción lenta puede confirmar un ID menor que otro ya visible;
+	// debe superar lo que duran las transacciones que escriben en el outbox.
+	Settle time.Duration
+}
+
+// DefaultUserFeedOptions acumula hasta 256 eventos por suscriptor, relee de a 500 y espera 2
+// segundos a que se asiente cada evento.
+func DefaultUserFeedOptions() UserFeedOptions {
+	return UserFeedOptions{Buffer: 256, ReplayBatch: 500, Settle: 2 * time.Second}
+}
+
+// UserFeed reparte los eventos de usuario entre los suscriptores de esta instancia. Cada
+// instancia escucha los avisos del outbox (EventNotifier), así que un cambio hecho en cualquiera
+// llega a todos los streams; los eventos se leen del outbox en orden de ID una vez asentados,
+// de modo que el ID de un evento sirve como Last-Event-ID sin saltarse ninguno.
+type UserFeed struct {
+	users    *UserService
+	outbox   ports.OutboxRepository
+	notifier ports.EventNotifier
+	opts     UserFeedOptions
+
+	mu          sync.Mutex
+	subscribers map[*userSubscriber]struct{}
+	// lastID es el mayor ID repartido, desde donde se relee tras perder avisos.
+	lastID int64
+}
+
+type userSubscriber struct {
+	events chan *model.Event
+	filter map[string]interface{}
+}
+
+func NewUserFeed(users *UserService, outbox ports.OutboxRepository, notifier ports.EventNotifier, opts UserFeedOptions) *UserFeed {
+	return &UserFeed{
+		users:       users,
+		outbox:      outbox,
+		notifier:    notifier,
+		opts:        opts,
+		subscribers: make(map[*userSubscriber]struct{}),
+	}
+}
+
+// Subscribe devuelve los eventos de usuario que cumplen los mismos filtros que List. Con
+// lastEventID > 0 primero entrega los eventos posteriores a ese ID. Un cambio se entrega si el
+// usuario cumple el filtro antes o después, para que el cliente pueda agregarlo o quitarlo.
+// El canal se cierra cuando termina ctx o si el suscriptor se atrasa más que Buffer.
+func (f *UserFeed) Subscribe(ctx context.Context, filter map[string]interface{}, lastEventID int64) (<-chan *model.Event, error) {
+	if err := f.users.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return nil, err
+	}
+	if err := f.users.parseFilter(filter); err != nil {
+		return nil, err
+	}
+
+	sub := &userSubscriber{events: make(chan *model.Event, f.opts.Buffer), filter: filter}
+	f.mu.Lock()
+	f.subscribers[sub] = struct{}{}
+	f.mu.Unlock()
+
+	out := make(chan *model.Event)
+	go func() {
+		defer close(out)
+		defer f.unsubscribe(sub)
+
+		// Los eventos en vivo que ya salieron en la relectura no se repiten.
+		replayed := make(map[int64]bool)
+		before := f.settledBefore()
+		for afterID := lastEventID; afterID > 0; {
+			events, err := f.outbox.ListAfter(model.AggregateUser, afterID, before, f.opts.ReplayBatch)
+			if err != nil {
+				log.Error().Err(err).Int64("lastEventID", lastEventID).Msg("🔴 Error al releer eventos de usuario")
+				return
+			}
+			for _, event := range events {
+				replayed[event.ID] = true
+				afterID = event.ID
+				if matchesUserEvent(event, filter) && !send(ctx, out, event) {
+					return
+				}
+			}
+			if len(events) < f.opts.ReplayBatch {
+				break
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub.events:
+				if !ok {
+					return
+				}
+				if !replayed[event.ID] && !send(ctx, out, event) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func send(ctx context.Context, out chan<- *model.Event, event *model.Event) bool {
+	select {
+	case out <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (f *UserFeed) unsubscribe(sub *userSubscriber) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subscribers, sub)
+}
+
+// Run escucha los avisos del outbox y reparte los eventos hasta que termine ctx. Se ejecuta
+// como tarea en segundo plano; si se pierde la escucha devuelve el error y la tarea la reabre.
+func (f *UserFeed) Run(ctx context.Context) error {
+	ids, err := f.notifier.Listen(ctx)
+	if err != nil {
+		return err
+	}
+	if err := f.start(); err != nil {
+		return err
+	}
+	log.Info().Msg("📡 Escuchando cambios de usuarios")
+
+	// Un aviso (o un 0, si se perdieron) sólo indica que hay eventos nuevos: se leen cuando se
+	// asientan, y si llegan más avisos mientras tanto se vuelve a leer al asentarse el último.
+	timer := time.NewTimer(f.opts.Settle)
+	timer.Stop()
+	defer timer.Stop()
+	var pending bool
+	var due time.Time
+	for {
+		select {
+		case _, ok := <-ids:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.New("user feed: notifier closed")
+			}
+			due = time.Now().Add(f.opts.Settle)
+			if !pending {
+				timer.Reset(f.opts.Settle)
+				pending = true
+			}
+		case <-timer.C:
+			pending = false
+			if err := f.poll(); err != nil {
+				log.Error().Err(err).Msg("🔴 Error al repartir eventos de usuario")
+				due = time.Now().Add(f.opts.Settle)
+			}
+			if wait := time.Until(due); wait > 0 {
+				timer.Reset(wait)
+				pending = true
+			}
+		}
+	}
+}
+
+// start fija, la primera vez, el último evento asentado: los anteriores no se reparten en vivo.
+func (f *UserFeed) start() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lastID != 0 {
+		return nil
+	}
+
+	lastID, err := f.outbox.LastID(model.AggregateUser, f.settledBefore())
+	if err != nil {
+		return err
+	}
+	f.lastID = lastID
+	return nil
+}
+
+// poll reparte en orden de ID los eventos asentados posteriores al último repartido.
+func (f *UserFeed) poll() error {
+	f.mu.Lock()
+	afterID := f.lastID
+	f.mu.Unlock()
+
+	before := f.settledBefore()
+	for {
+		events, err := f.outbox.ListAfter(model.AggregateUser, afterID, before, f.opts.ReplayBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			f.dispatch(event)
+			afterID = event.ID
+		}
+		if len(events) < f.opts.ReplayBatch {
+			return nil
+		}
+	}
+}
+
+// settledBefore es el límite de los eventos que ya no pueden tener antes un ID menor pendiente.
+func (f *UserFeed) settledBefore() time.Time {
+	return time.Now().UTC().Add(-f.opts.Settle)
+}
+
+// dispatch entrega el evento a los suscriptores cuyo filtro cumple. Un suscriptor con el buffer
+// lleno se desconecta en lugar de frenar a los demás.
+func (f *UserFeed) dispatch(event *model.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastID = max(f.lastID, event.ID)
+	for sub := range f.subscribers {
+		if !matchesUserEvent(event, sub.filter) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			log.Warn().Int64("eventID", event.ID).Msg("⚠️ Suscriptor del stream de usuarios atrasado; se desconecta")
+			close(sub.events)
+			delete(f.subscribers, sub)
+		}
+	}
+}
+
+// matchesUserEvent indica si el usuario del evento cumple el filtro antes o después del cambio.
+func matchesUserEvent(event *model.Event, filter map[string]interface{}) bool {
+	change, err := model.DecodeUserChange(event)
+	if err != nil {
+		return false
+	}
+	return (change.Before != nil && matchesUserFilter(change.Before, filter)) ||
+		(change.After != nil && matchesUserFilter(change.After, filter))
+}
+
+// matchesUserFilter evalúa en memoria los filtros de List: name y email contienen el texto sin
+// distinguir mayúsculas, status es uno de los indicados y cada atributo es igual al valor.
+func matchesUserFilter(u *model.User, filter map[string]interface{}) bool {
+	for key, value := range filter {
+		switch key {
+		case "name":
+			if !containsFold(u.Name, value) {
+				return false
+			}
+		case "email":
+			if !containsFold(u.Email, value) {
+				return false
+			}
+		case "status":
+			statuses, _ := value.([]string)
+			if !slices.Contains(statuses, string(u.Status)) {
+				return false
+			}
+		case attributesFilterKey:
+			attrs, _ := value.(map[string]interface{})
+			for name, want := range attrs {
+				if !sameJSON(u.Attributes[name], want) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func containsFold(s string, value interface{}) bool {
+	text, _ := value.(string)
+	return strings.Contains(strings.ToLower(s), strings.ToLower(text))
+}
+
+// sameJSON compara dos valores por su forma JSON: el payload decodificado trae los números como
+// float64 y los filtros parseados pueden traerlos como int64.
+func sameJSON(a, b interface{}) bool {
+	if a == nil {
+		return false
+	}
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
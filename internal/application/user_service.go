@@ -1,34 +1,374 @@
 package application
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/jnates/crud_golang/internal/domain/model"
 	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
 )
 
+const attributesFilterKey = "attributes"
+
+// MaxLookupIDs es el máximo de IDs de una consulta de varios usuarios.
+const MaxLookupIDs = 1000
+
 type UserService struct {
-	repo ports.UserRepository
+	repo       ports.UserRepository
+	attributes ports.AttributeDefinitionRepository
+	authz      *Authorizer
 }
 
-func NewUserService(repo ports.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(
+	repo ports.UserRepository,
+	attributes ports.AttributeDefinitionRepository,
+	authz *Authorizer,
+) *UserService {
+	return &UserService{repo: repo, attributes: attributes, authz: authz}
 }
 
-func (s *UserService) Get(id int64) (*model.User, error) {
+func (s *UserService) Get(ctx context.Context, id int64) (*model.User, error) {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersRead, id); err != nil {
+		return nil, err
+	}
 	return s.repo.GetByID(id)
 }
 
-func (s *UserService) Create(user *model.User) (int64, error) {
-	return s.repo.Create(user)
+// ResolveUserID convierte el ID de una ruta, numérico o público, en el ID interno. Los permisos
+// los comprueba la operación que use el ID; aquí solo se evita revelar qué IDs públicos existen.
+func (s *UserService) ResolveUserID(ctx context.Context, ref string) (int64, error) {
+	return resolveUserID(ctx, s.authz, s.repo, ref)
 }
 
-func (s *UserService) Update(user *model.User) error {
-	return s.repo.Update(user)
+func (s *UserService) Create(ctx context.Context, user *model.User) (int64, error) {
+	if err := s.authz.Require(ctx, model.PermUsersCreate); err != nil {
+		return 0, err
+	}
+	if err := s.validateAttributes(user); err != nil {
+		return 0, err
+	}
+	user.Status = model.UserStatusActive
+	return s.repo.CreateWithEvent(user, actorOf(ctx))
 }
 
-func (s *UserService) Delete(id int64) error {
-	return s.repo.Delete(id)
+// CreateWithStatus crea el usuario directamente en el estado indicado, con la transición desde
+// active en el historial; exige además users:status si el estado no es active.
+func (s *UserService) CreateWithStatus(ctx context.Context, user *model.User, to model.UserStatus, reason string) (int64, error) {
+	if to == model.UserStatusActive {
+		return s.Create(ctx, user)
+	}
+	if err := s.authz.Require(ctx, model.PermUsersCreate); err != nil {
+		return 0, err
+	}
+	if err := s.authz.Require(ctx, model.PermUsersStatus); err != nil {
+		return 0, err
+	}
+	if !model.UserStatusActive.CanTransitionTo(to) {
+		return 0, fmt.Errorf("%w: %s -> %s", model.ErrInvalidStatusTransition, model.UserStatusActive, to)
+	}
+	reason = strings.TrimSpace(reason)
+	if to.RequiresReason() && reason == "" {
+		return 0, model.ErrStatusReasonRequired
+	}
+	if err := s.validateAttributes(user); err != nil {
+		return 0, err
+	}
+
+	change := &model.UserStatusChange{
+		From:      model.UserStatusActive,
+		To:        to,
+		Reason:    reason,
+		Actor:     actorOf(ctx),
+		ChangedAt: time.Now().UTC(),
+	}
+	return s.repo.CreateWithStatusChange(user, change)
+}
+
+// Update actualiza el usuario; si cambia el email, vuelve a quedar sin verificar.
+func (s *UserService) Update(ctx context.Context, user *model.User) error {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersUpdate, user.ID); err != nil {
+		return err
+	}
+	if err := s.validateAttributes(user); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateWithEvent(user, actorOf(ctx))
 }
 
-func (s *UserService) List(offset, limit int, filter map[string]interface{}) ([]*model.User, error) {
+func (s *UserService) Delete(ctx context.Context, id int64) error {
+	if err := s.authz.Require(ctx, model.PermUsersDelete); err != nil {
+		return err
+	}
+	return s.repo.DeleteWithEvent(id, actorOf(ctx))
+}
+
+// List lista usuarios; los filtros de atributos llegan como map[string]string bajo la clave
+// "attributes" y se convierten aquí al tipo de cada definición.
+func (s *UserService) List(ctx context.Context, offset, limit int, filter map[string]interface{}) ([]*model.User, error) {
+	if err := s.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return nil, err
+	}
+	if err := s.parseFilter(filter); err != nil {
+		return nil, err
+	}
 	return s.repo.List(offset, limit, filter)
 }
+
+// GetFields es Get leyendo sólo los campos indicados.
+func (s *UserService) GetFields(ctx context.Context, id int64, fields []string) (*model.User, error) {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersRead, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByIDFields(id, fields)
+}
+
+// ListFields es List leyendo sólo los campos indicados.
+func (s *UserService) ListFields(ctx context.Context, offset, limit int, filter map[string]interface{}, fields []string) ([]*model.User, error) {
+	if err := s.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return nil, err
+	}
+	if err := s.parseFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.repo.ListFields(offset, limit, filter, fields)
+}
+
+// GetByIDs obtiene varios usuarios en una sola consulta, en el orden pedido y sin repetidos, e
+// informa las referencias que no existen. Cada referencia es un ID numérico o público; fields es
+// opcional, como en GetFields.
+func (s *UserService) GetByIDs(ctx context.Context, refs []model.UserRef, fields []string) (*model.UserLookup, error) {
+	if err := s.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return nil, err
+	}
+	refs = uniqueRefs(refs)
+	if len(refs) > MaxLookupIDs {
+		return nil, fmt.Errorf("%w: at most %d", model.ErrTooManyIDs, MaxLookupIDs)
+	}
+	ids, err := resolveUserIDs(s.repo, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*model.User
+	if len(fields) > 0 {
+		users, err = s.repo.GetByIDsFields(uniqueIDs(ids), fields)
+	} else {
+		users, err = s.repo.GetByIDs(uniqueIDs(ids))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*model.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	lookup := &model.UserLookup{Users: make([]*model.User, 0, len(users)), Missing: []model.UserRef{}}
+	for i, ref := range refs {
+		user, ok := byID[ids[i]]
+		if !ok {
+			lookup.Missing = append(lookup.Missing, ref)
+			continue
+		}
+		// El mismo usuario pedido por su ID numérico y por el público se devuelve una vez.
+		if user != nil {
+			lookup.Users = append(lookup.Users, user)
+			byID[ids[i]] = nil
+		}
+	}
+	return lookup, nil
+}
+
+// Count cuenta los usuarios que cumplen los mismos filtros que List.
+func (s *UserService) Count(ctx context.Context, filter map[string]interface{}) (int, error) {
+	if err := s.authz.Require(ctx, model.PermUsersRead); err != nil {
+		return 0, err
+	}
+	if err := s.parseFilter(filter); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(filter)
+}
+
+func (s *UserService) parseFilter(filter map[string]interface{}) error {
+	raw, ok := filter[attributesFilterKey].(map[string]string)
+	if !ok {
+		return nil
+	}
+	defs, err := s.attributes.List()
+	if err != nil {
+		return err
+	}
+	parsed, err := parseAttributeFilters(defs, raw)
+	if err != nil {
+		return err
+	}
+	filter[attributesFilterKey] = parsed
+	return nil
+}
+
+// uniqueIDs quita los repetidos y los ceros, que son referencias sin resolver.
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func uniqueRefs(refs []model.UserRef) []model.UserRef {
+	seen := make(map[model.UserRef]bool, len(refs))
+	unique := make([]model.UserRef, 0, len(refs))
+	for _, ref := range refs {
+		ref = model.UserRef(strings.TrimSpace(string(ref)))
+		if !seen[ref] {
+			seen[ref] = true
+			unique = append(unique, ref)
+		}
+	}
+	return unique
+}
+
+func (s *UserService) validateAttributes(user *model.User) error {
+	defs, err := s.attributes.List()
+	if err != nil {
+		return err
+	}
+	return validateAttributes(defs, user.Attributes)
+}
+
+// ChangeStatus valida y aplica una transición de estado, dejando constancia del actor
+// (el principal del contexto) y el motivo. Si el nuevo estado no permite iniciar sesión,
+// se revocan todas las sesiones abiertas del usuario.
+func (s *UserService) ChangeStatus(ctx context.Context, id int64, to model.UserStatus, reason string) (*model.UserStatusChange, error) {
+	if err := s.authz.Require(ctx, model.PermUsersStatus); err != nil {
+		return nil, err
+	}
+	if !to.IsValid() {
+		return nil, fmt.Errorf("%w: %q", model.ErrInvalidStatus, to)
+	}
+
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s -> %s", model.ErrInvalidStatusTransition, user.Status, to)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if to.RequiresReason() && reason == "" {
+		return nil, model.ErrStatusReasonRequired
+	}
+
+	change := &model.UserStatusChange{
+		UserID:    id,
+		From:      user.Status,
+		To:        to,
+		Reason:    reason,
+		Actor:     actorOf(ctx),
+		ChangedAt: time.Now().UTC(),
+	}
+	if err := s.repo.ChangeStatus(change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *UserService) Activate(ctx context.Context, id int64, reason string) (*model.UserStatusChange, error) {
+	return s.ChangeStatus(ctx, id, model.UserStatusActive, reason)
+}
+
+func (s *UserService) Suspend(ctx context.Context, id int64, reason string) (*model.UserStatusChange, error) {
+	return s.ChangeStatus(ctx, id, model.UserStatusSuspended, reason)
+}
+
+func (s *UserService) Lock(ctx context.Context, id int64, reason string) (*model.UserStatusChange, error) {
+	return s.ChangeStatus(ctx, id, model.UserStatusLocked, reason)
+}
+
+func (s *UserService) Deactivate(ctx context.Context, id int64, reason string) (*model.UserStatusChange, error) {
+	return s.ChangeStatus(ctx, id, model.UserStatusDeactivated, reason)
+}
+
+func (s *UserService) StatusHistory(ctx context.Context, id int64) ([]*model.UserStatusChange, error) {
+	if err := s.authz.RequireOnUser(ctx, model.PermUsersRead, id); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStatusChanges(id)
+}
+
+// actorOf devuelve el sujeto del principal del contexto para registrarlo en bitácoras.
+func actorOf(ctx context.Context) string {
+	if p, ok := model.PrincipalFrom(ctx); ok {
+		return p.Subject
+	}
+	return enum.Anonymous
+}
+
+// resolveUserID acepta el ID numérico de un usuario o su ID público. Un ID público desconocido
+// solo es ErrUserNotFound para quien puede leer usuarios; para el resto es el mismo 403 que
+// recibiría con uno existente, y así la respuesta no revela si el usuario existe.
+func resolveUserID(ctx context.Context, authz *Authorizer, users ports.UserRepository, ref string) (int64, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		if id <= 0 {
+			return 0, fmt.Errorf("%w: %q", model.ErrInvalidID, ref)
+		}
+		return id, nil
+	}
+	id, err := users.GetIDByPublicID(ref)
+	if errors.Is(err, model.ErrUserNotFound) {
+		if denied := authz.Require(ctx, model.PermUsersRead); denied != nil {
+			return 0, denied
+		}
+	}
+	return id, err
+}
+
+// resolveUserIDs resuelve varias referencias como resolveUserID, con una sola consulta para los
+// IDs públicos. Los IDs siguen el orden de refs, con 0 en los IDs públicos que no existen; los
+// numéricos no se comprueban.
+func resolveUserIDs(users ports.UserRepository, refs []model.UserRef) ([]int64, error) {
+	ids := make([]int64, len(refs))
+	var public []string
+	for i, ref := range refs {
+		raw := strings.TrimSpace(string(ref))
+		if id, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			if id <= 0 {
+				return nil, fmt.Errorf("%w: %q", model.ErrInvalidID, raw)
+			}
+			ids[i] = id
+			continue
+		}
+		public = append(public, raw)
+	}
+	if len(public) == 0 {
+		return ids, nil
+	}
+
+	found, err := users.GetIDsByPublicIDs(public)
+	if err != nil {
+		return nil, err
+	}
+	for i, ref := range refs {
+		if ids[i] == 0 {
+			ids[i] = found[strings.TrimSpace(string(ref))]
+		}
+	}
+	return ids, nil
+}
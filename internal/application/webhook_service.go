@@ -0,0 +1,292 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// WebhookOptions configura el envío de webhooks.
+type WebhookOptions struct {
+	// MaxAttempts es el número de intentos tras el cual una entrega queda muerta.
+	MaxAttempts int
+	// BaseBackoff es la espera tras el primer fallo; se duplica en cada intento hasta MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// BatchSize es el máximo de entregas que se reservan por vuelta.
+	BatchSize int
+	// Lease es cuánto dura la reserva de un lote; si el worker cae, otro retoma las entregas al vencer.
+	Lease time.Duration
+}
+
+// DefaultWebhookOptions hace hasta 8 intentos esperando desde 30s hasta 1h entre ellos y
+// reserva lotes de 50 entregas por 5 minutos.
+func DefaultWebhookOptions() WebhookOptions {
+	return WebhookOptions{MaxAttempts: 8, BaseBackoff: 30 * time.Second, MaxBackoff: time.Hour, BatchSize: 50, Lease: 5 * time.Minute}
+}
+
+// WebhookService administra las suscripciones de webhooks y envía los eventos de dominio a los
+// socios suscritos. Implementa EventPublisher: el relay del outbox le entrega cada evento y éste
+// lo encola para cada webhook; DeliverPending hace los envíos.
+type WebhookService struct {
+	hooks  ports.WebhookRepository
+	sender ports.WebhookSender
+	authz  *Authorizer
+	opts   WebhookOptions
+	now    func() time.Time
+}
+
+func NewWebhookService(hooks ports.WebhookRepository, sender ports.WebhookSender, authz *Authorizer, opts WebhookOptions) *WebhookService {
+	return &WebhookService{hooks: hooks, sender: sender, authz: authz, opts: opts, now: time.Now}
+}
+
+// Create registra un webhook con un secreto de firma nuevo, que sólo se devuelve aquí.
+func (s *WebhookService) Create(ctx context.Context, req *model.WebhookRequest) (*model.IssuedWebhook, error) {
+	if err := s.authz.Require(ctx, model.PermHooksWrite); err != nil {
+		return nil, err
+	}
+	eventTypes, err := validateWebhookRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	hook := &model.Webhook{
+		URL:        strings.TrimSpace(req.URL),
+		EventTypes: eventTypes,
+		Active:     req.Active == nil || *req.Active,
+		CreatedBy:  actorOf(ctx),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Secret:     secret,
+	}
+	if err := s.hooks.Create(hook); err != nil {
+		return nil, err
+	}
+	return &model.IssuedWebhook{Webhook: hook, Secret: secret}, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, id int64) (*model.Webhook, error) {
+	if err := s.authz.Require(ctx, model.PermHooksRead); err != nil {
+		return nil, err
+	}
+	return s.hooks.Get(id)
+}
+
+func (s *WebhookService) List(ctx context.Context, offset, limit int) ([]*model.Webhook, error) {
+	if err := s.authz.Require(ctx, model.PermHooksRead); err != nil {
+		return nil, err
+	}
+	return s.hooks.List(offset, limit)
+}
+
+// Update reemplaza el URL y los tipos de evento; Active nil conserva el estado actual.
+func (s *WebhookService) Update(ctx context.Context, id int64, req *model.WebhookRequest) (*model.Webhook, error) {
+	if err := s.authz.Require(ctx, model.PermHooksWrite); err != nil {
+		return nil, err
+	}
+	eventTypes, err := validateWebhookRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	hook, err := s.hooks.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	hook.URL = strings.TrimSpace(req.URL)
+	hook.EventTypes = eventTypes
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+	hook.UpdatedAt = s.now().UTC()
+	if err := s.hooks.Update(hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id int64) error {
+	if err := s.authz.Require(ctx, model.PermHooksWrite); err != nil {
+		return err
+	}
+	return s.hooks.Delete(id)
+}
+
+// RotateSecret reemplaza el secreto de firma. Las entregas siguientes se firman con el nuevo.
+func (s *WebhookService) RotateSecret(ctx context.Context, id int64) (*model.IssuedWebhook, error) {
+	if err := s.authz.Require(ctx, model.PermHooksWrite); err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	hook, err := s.hooks.SetSecret(id, secret, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return &model.IssuedWebhook{Webhook: hook, Secret: secret}, nil
+}
+
+// Deliveries lista las entregas del webhook; status vacío no filtra.
+func (s *WebhookService) Deliveries(ctx context.Context, webhookID int64, status model.DeliveryStatus, offset, limit int) ([]*model.WebhookDelivery, error) {
+	if err := s.authz.Require(ctx, model.PermHooksRead); err != nil {
+		return nil, err
+	}
+	if _, err := s.hooks.Get(webhookID); err != nil {
+		return nil, err
+	}
+	return s.hooks.ListDeliveries(webhookID, status, offset, limit)
+}
+
+func (s *WebhookService) Delivery(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	if err := s.authz.Require(ctx, model.PermHooksRead); err != nil {
+		return nil, err
+	}
+	return s.hooks.GetDelivery(webhookID, id)
+}
+
+// Redeliver envía la entrega otra vez en el momento, también si ya tuvo éxito o está muerta,
+// y devuelve el resultado registrado.
+func (s *WebhookService) Redeliver(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	if err := s.authz.Require(ctx, model.PermHooksWrite); err != nil {
+		return nil, err
+	}
+	hook, err := s.hooks.Get(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.hooks.GetDelivery(webhookID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int64("webhookID", webhookID).Int64("deliveryID", id).Str("actor", actorOf(ctx)).
+		Msg("🔁 Reenviando entrega de webhook")
+	return s.attempt(ctx, hook, delivery)
+}
+
+// Publish encola el evento para los webhooks suscritos a su tipo. El cuerpo de cada entrega
+// es el evento serializado.
+func (s *WebhookService) Publish(_ context.Context, event *model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	enqueued, err := s.hooks.Enqueue(event, payload, s.now().UTC())
+	if err != nil {
+		return err
+	}
+	if enqueued > 0 {
+		log.Debug().Int64("eventID", event.ID).Str("type", event.Type).Int("deliveries", enqueued).
+			Msg("📬 Evento encolado para webhooks")
+	}
+	return nil
+}
+
+// DeliverPending envía lotes de entregas pendientes hasta vaciar la cola o encontrar un error
+// de la base de datos; los fallos de envío sólo programan un reintento.
+func (s *WebhookService) DeliverPending(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := s.now().UTC()
+		deliveries, err := s.hooks.ClaimDeliveries(s.opts.BatchSize, now, now.Add(s.opts.Lease))
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		hooks := make(map[int64]*model.Webhook)
+		for _, delivery := range deliveries {
+			hook, ok := hooks[delivery.WebhookID]
+			if !ok {
+				if hook, err = s.hooks.Get(delivery.WebhookID); err != nil {
+					return err
+				}
+				hooks[delivery.WebhookID] = hook
+			}
+			if _, err := s.attempt(ctx, hook, delivery); err != nil {
+				return err
+			}
+		}
+		if len(deliveries) < s.opts.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// attempt envía la entrega y registra el resultado: éxito, reintento programado o entrega muerta
+// si se agotaron los intentos.
+func (s *WebhookService) attempt(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	result := s.sender.Send(ctx, hook, delivery)
+	attempts := delivery.Attempts + 1
+
+	status := model.DeliverySucceeded
+	var nextAttemptAt *time.Time
+	switch {
+	case result.Err == nil:
+		log.Info().Int64("webhookID", hook.ID).Int64("deliveryID", delivery.ID).Int("status", result.ResponseCode).
+			Msg("✅ Webhook entregado")
+	case attempts >= s.opts.MaxAttempts:
+		status = model.DeliveryDead
+		log.Warn().Err(result.Err).Int64("webhookID", hook.ID).Int64("deliveryID", delivery.ID).Int("attempts", attempts).
+			Msg("💀 Entrega de webhook agotó sus reintentos")
+	default:
+		status = model.DeliveryPending
+		retryAt := result.At.Add(s.backoff(attempts))
+		nextAttemptAt = &retryAt
+		log.Warn().Err(result.Err).Int64("webhookID", hook.ID).Int64("deliveryID", delivery.ID).Int("attempts", attempts).
+			Time("retryAt", retryAt).Msg("⚠️ Error al entregar webhook; se reintentará")
+	}
+	return s.hooks.RecordAttempt(delivery.ID, result, status, nextAttemptAt)
+}
+
+// backoff duplica la espera en cada intento fallido, desde BaseBackoff hasta MaxBackoff.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	wait := s.opts.BaseBackoff
+	for i := 1; i < attempts && wait < s.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, s.opts.MaxBackoff)
+}
+
+// validateWebhookRequest comprueba el URL y devuelve los tipos de evento sin duplicados.
+func validateWebhookRequest(req *model.WebhookRequest) ([]string, error) {
+	target, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, model.ErrInvalidWebhookURL
+	}
+
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		t = strings.TrimSpace(t)
+		if t != model.WebhookAllEvents && !slices.Contains(model.EventTypes, t) {
+			return nil, fmt.Errorf("%w: %q", model.ErrInvalidEventType, t)
+		}
+		if !slices.Contains(eventTypes, t) {
+			eventTypes = append(eventTypes, t)
+		}
+	}
+	return eventTypes, nil
+}
+
+// newWebhookSecret genera el secreto whsec_<aleatorio> con el que se firman las entregas.
+func newWebhookSecret() (string, error) {
+	token, _, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return model.WebhookSecretPrefix + token, nil
+}
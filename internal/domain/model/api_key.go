@@ -0,0 +1,73 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKeyTokenPrefix distingue una API key de un JWT cuando llega como bearer token.
+const APIKeyTokenPrefix = "ck_"
+
+// AuthMethodAPIKey identifica a los principales autenticados con una API key.
+const AuthMethodAPIKey = "api_key"
+
+// APIKey es una credencial para servicios que llaman a la API sin un usuario humano. La clave
+// completa sólo se muestra al crearla o rotarla; se guarda su hash y el prefijo visible que la
+// identifica en listados y registros.
+type APIKey struct {
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	Prefix      string       `json:"prefix"`
+	Permissions []Permission `json:"permissions"`
+	// AllowedIPs limita las IPs (o rangos CIDR) desde las que se acepta la clave; vacío no limita.
+	AllowedIPs []string   `json:"allowed_ips"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	Hash string `json:"-"`
+	// PreviousHash sigue valiendo hasta PreviousExpiresAt tras una rotación con período de gracia.
+	PreviousHash      string     `json:"-"`
+	PreviousExpiresAt *time.Time `json:"-"`
+}
+
+// IsActive indica si la clave no fue revocada ni venció.
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// IssuedAPIKey acompaña a la clave recién creada o rotada con su valor en claro.
+type IssuedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyRequest es el cuerpo de POST /api-keys.
+type APIKeyRequest struct {
+	Name        string       `json:"name" validate:"required,max=100"`
+	Permissions []Permission `json:"permissions" validate:"required,min=1"`
+	AllowedIPs  []string     `json:"allowed_ips"`
+	ExpiresAt   *time.Time   `json:"expires_at"`
+}
+
+// APIKeyRotation es el cuerpo opcional de POST /api-keys/{id}/rotate. Durante GraceSeconds la
+// clave anterior sigue siendo válida, para cambiarla en los clientes sin cortar el servicio.
+type APIKeyRotation struct {
+	GraceSeconds int `json:"grace_seconds" validate:"min=0,max=604800"`
+}
+
+// ParseAPIKey separa una clave con formato ck_<prefijo>_<secreto> en su prefijo visible.
+func ParseAPIKey(key string) (prefix string, ok bool) {
+	rest, ok := strings.CutPrefix(key, APIKeyTokenPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// AttributeType es el tipo de dato admitido por un atributo personalizado.
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeDate    AttributeType = "date"
+	AttributeTypeEnum    AttributeType = "enum"
+)
+
+// AttributeDateLayout es el formato esperado para atributos de tipo fecha.
+const AttributeDateLayout = "2006-01-02"
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// AttributeDefinition describe un atributo personalizado registrado por un administrador.
+type AttributeDefinition struct {
+	ID         int64         `json:"id"`
+	Name       string        `json:"name" validate:"required"`
+	Type       AttributeType `json:"type" validate:"required"`
+	Required   bool          `json:"required"`
+	EnumValues []string      `json:"enum_values,omitempty"`
+	Pattern    string        `json:"pattern,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// Check valida que la definición sea coherente: nombre, tipo, valores enumerados y expresión regular.
+func (d *AttributeDefinition) Check() error {
+	if !attributeNamePattern.MatchString(d.Name) {
+		return fmt.Errorf("%w: name must match %s", ErrInvalidAttributeDefinition, attributeNamePattern)
+	}
+
+	switch d.Type {
+	case AttributeTypeString, AttributeTypeNumber, AttributeTypeBoolean, AttributeTypeDate:
+		if len(d.EnumValues) > 0 {
+			return fmt.Errorf("%w: enum_values only apply to enum attributes", ErrInvalidAttributeDefinition)
+		}
+	case AttributeTypeEnum:
+		if len(d.EnumValues) == 0 {
+			return fmt.Errorf("%w: enum attributes need enum_values", ErrInvalidAttributeDefinition)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidAttributeDefinition, d.Type)
+	}
+
+	if d.Pattern != "" {
+		if d.Type != AttributeTypeString {
+			return fmt.Errorf("%w: pattern only applies to string attributes", ErrInvalidAttributeDefinition)
+		}
+		if _, err := regexp.Compile(d.Pattern); err != nil {
+			return fmt.Errorf("%w: invalid pattern: %v", ErrInvalidAttributeDefinition, err)
+		}
+	}
+	return nil
+}
+
+// Validate comprueba que un valor decodificado de JSON cumpla con la definición.
+func (d *AttributeDefinition) Validate(value interface{}) error {
+	switch d.Type {
+	case AttributeTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return d.invalid("must be a string")
+		}
+		if d.Pattern != "" {
+			re, err := regexp.Compile(d.Pattern)
+			if err != nil || !re.MatchString(s) {
+				return d.invalid("does not match pattern " + d.Pattern)
+			}
+		}
+	case AttributeTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return d.invalid("must be a number")
+		}
+	case AttributeTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return d.invalid("must be a boolean")
+		}
+	case AttributeTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return d.invalid("must be a date string")
+		}
+		if _, err := time.Parse(AttributeDateLayout, s); err != nil {
+			return d.invalid("must be a date formatted as " + AttributeDateLayout)
+		}
+	case AttributeTypeEnum:
+		s, ok := value.(string)
+		if !ok || !contains(d.EnumValues, s) {
+			return d.invalid(fmt.Sprintf("must be one of %v", d.EnumValues))
+		}
+	}
+	return nil
+}
+
+// Parse convierte un valor recibido como texto (por ejemplo, en un query param) al tipo de la definición.
+func (d *AttributeDefinition) Parse(raw string) (interface{}, error) {
+	var value interface{} = raw
+	switch d.Type {
+	case AttributeTypeNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, d.invalid("must be a number")
+		}
+		value = n
+	case AttributeTypeBoolean:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, d.invalid("must be a boolean")
+		}
+		value = b
+	}
+	if err := d.Validate(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (d *AttributeDefinition) invalid(reason string) error {
+	return fmt.Errorf("%w: %s %s", ErrInvalidAttribute, d.Name, reason)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
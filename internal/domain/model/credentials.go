@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+)
+
+// Credentials son los datos de acceso por contraseña de un usuario. Se guardan junto al usuario
+// pero nunca forman parte de model.User, por lo que no aparecen en las respuestas de /users.
+type Credentials struct {
+	UserID            int64
+	PasswordHash      string
+	FailedLogins      int
+	LockedUntil       *time.Time
+	PasswordChangedAt *time.Time
+}
+
+// IsLocked indica si el acceso está bloqueado temporalmente por intentos fallidos.
+func (c *Credentials) IsLocked(now time.Time) bool {
+	return c.LockedUntil != nil && now.Before(*c.LockedUntil)
+}
+
+// passwordMaxBytes es el límite de bcrypt; los bytes adicionales se ignorarían en silencio.
+const passwordMaxBytes = 72
+
+// PasswordPolicy define los requisitos mínimos de una contraseña.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultPasswordPolicy exige 12 caracteres con mayúsculas, minúsculas y dígitos.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 12, RequireUpper: true, RequireLower: true, RequireDigit: true}
+}
+
+// Validate devuelve ErrWeakPassword con el primer requisito que la contraseña no cumple.
+func (p PasswordPolicy) Validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, p.MinLength)
+	}
+	if len(password) > passwordMaxBytes {
+		return fmt.Errorf("%w: must be at most %d bytes", ErrWeakPassword, passwordMaxBytes)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	switch {
+	case p.RequireUpper && !upper:
+		return fmt.Errorf("%w: must contain an uppercase letter", ErrWeakPassword)
+	case p.RequireLower && !lower:
+		return fmt.Errorf("%w: must contain a lowercase letter", ErrWeakPassword)
+	case p.RequireDigit && !digit:
+		return fmt.Errorf("%w: must contain a digit", ErrWeakPassword)
+	case p.RequireSymbol && !symbol:
+		return fmt.Errorf("%w: must contain a symbol", ErrWeakPassword)
+	}
+	return nil
+}
+
+// TokenPair es la respuesta de un inicio de sesión exitoso.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import "context"
+
+// Plantillas de email disponibles; cada una existe en todos los idiomas soportados.
+const (
+	EmailTemplatePasswordReset     = "password_reset"
+	EmailTemplateEmailVerification = "email_verification"
+	EmailTemplateInvitation        = "invitation"
+)
+
+// Idiomas soportados en los mensajes al usuario.
+const (
+	LocaleES = "es"
+	LocaleEN = "en"
+)
+
+// Email es un mensaje a partir de una plantilla; el adaptador de ports.Mailer lo renderiza
+// en el idioma indicado (o en el predeterminado si está vacío o no está soportado).
+type Email struct {
+	To       string
+	Template string
+	Locale   string
+	Data     map[string]interface{}
+}
+
+type localeKey struct{}
+
+// WithLocale devuelve un contexto con el idioma preferido de quien hace la petición.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFrom obtiene el idioma preferido del contexto; vacío si no se indicó.
+func LocaleFrom(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
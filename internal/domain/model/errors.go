@@ -0,0 +1,98 @@
+package model
+
+import "errors"
+
+// Categorías de error de dominio. Cada error concreto envuelve una de ellas para que los
+// adaptadores (HTTP, gRPC, ...) puedan traducirlo sin conocer cada error por separado.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrInvalid  = errors.New("invalid")
+	ErrConflict = errors.New("conflict")
+
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("forbidden")
+)
+
+var (
+	ErrUserNotFound            = notFound("user not found")
+	ErrInvalidStatus           = invalid("invalid user status")
+	ErrInvalidStatusTransition = conflict("invalid user status transition")
+	ErrStatusReasonRequired    = invalid("a reason is required for this status change")
+	ErrStatusConflict          = conflict("user status changed concurrently")
+
+	ErrAttributeNotFound          = notFound("attribute definition not found")
+	ErrAttributeExists            = conflict("attribute definition already exists")
+	ErrInvalidAttributeDefinition = invalid("invalid attribute definition")
+	ErrInvalidAttribute           = invalid("invalid attribute value")
+	ErrUnknownAttribute           = invalid("unknown attribute")
+	ErrRequiredAttributeWithUsers = conflict("a required attribute cannot be added while users exist")
+
+	ErrGroupNotFound = notFound("group not found")
+	ErrGroupExists   = conflict("group already exists")
+
+	ErrWeakPassword       = invalid("password does not meet the policy")
+	ErrInvalidCredentials = unauthenticated("invalid email or password")
+	ErrAccountLocked      = forbidden("account temporarily locked after repeated failed logins")
+	ErrAccountInactive    = forbidden("account is not active")
+
+	ErrSessionNotFound     = notFound("session not found")
+	ErrInvalidRefreshToken = unauthenticated("invalid or expired refresh token")
+	ErrRefreshTokenReused  = unauthenticated("refresh token already used; session revoked")
+
+	ErrInvalidUserToken     = invalid("invalid or expired token")
+	ErrEmailAlreadyVerified = conflict("email already verified")
+
+	ErrInvitationNotFound      = notFound("invitation not found")
+	ErrInvitationExists        = conflict("a pending invitation already exists for this email")
+	ErrInvitationNotPending    = conflict("invitation is no longer pending")
+	ErrInvalidInvitation       = invalid("invalid or expired invitation")
+	ErrInvalidInvitationStatus = invalid("invalid invitation status")
+	ErrEmailTaken              = conflict("a user with this email already exists")
+
+	ErrAPIKeyNotFound      = notFound("API key not found")
+	ErrAPIKeyRevoked       = conflict("API key is revoked")
+	ErrInvalidAPIKey       = unauthenticated("invalid or expired API key")
+	ErrInvalidAPIKeyExpiry = invalid("API key expiry must be in the future")
+	ErrAPIKeyIPNotAllowed  = forbidden("API key not allowed from this IP address")
+	ErrInvalidPermission   = invalid("invalid permission")
+	ErrInvalidIPRange      = invalid("invalid IP address or CIDR range")
+
+	ErrEventNotFound = notFound("event not found")
+
+	ErrInvalidID    = invalid("invalid ID")
+	ErrInvalidInput = invalid("invalid input")
+	ErrUnknownField = invalid("unknown field")
+	ErrTooManyIDs   = invalid("too many IDs")
+
+	ErrWebhookNotFound   = notFound("webhook not found")
+	ErrDeliveryNotFound  = notFound("webhook delivery not found")
+	ErrInvalidWebhookURL = invalid("webhook URL must be an absolute http or https URL")
+	ErrInvalidEventType  = invalid("unknown event type")
+
+	ErrIdentityNotFound        = notFound("external identity not found")
+	ErrIdentityLinked          = conflict("external identity is already linked to a user")
+	ErrInvalidLoginState       = unauthenticated("invalid or expired login state")
+	ErrExternalLoginFailed     = unauthenticated("identity provider login failed")
+	ErrExternalEmailUnverified = forbidden("identity provider has not verified the email")
+	ErrExternalUserNotFound    = forbidden("no local account matches this identity")
+)
+
+// domainError es un error con mensaje propio que pertenece a una categoría.
+type domainError struct {
+	msg  string
+	kind error
+}
+
+func (e *domainError) Error() string { return e.msg }
+
+func (e *domainError) Unwrap() error { return e.kind }
+
+func notFound(msg string) error { return &domainError{msg: msg, kind: ErrNotFound} }
+
+func invalid(msg string) error { return &domainError{msg: msg, kind: ErrInvalid} }
+
+func conflict(msg string) error { return &domainError{msg: msg, kind: ErrConflict} }
+
+func unauthenticated(msg string) error { return &domainError{msg: msg, kind: ErrUnauthenticated} }
+
+func forbidden(msg string) error { return &domainError{msg: msg, kind: ErrForbidden} }
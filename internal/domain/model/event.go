@@ -0,0 +1,102 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Tipos de evento de dominio.
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+	EventUserDeleted = "user.deleted"
+)
+
+// EventTypes son los tipos de evento que se pueden suscribir.
+var EventTypes = []string{EventUserCreated, EventUserUpdated, EventUserDeleted}
+
+// AggregateUser es el tipo de agregado de los eventos de usuario; el orden de entrega se
+// garantiza por agregado (tipo + ID).
+const AggregateUser = "user"
+
+// DomainEvent es un evento tipado antes de serializarse al outbox.
+type DomainEvent interface {
+	EventType() string
+	// Aggregate identifica la entidad que cambió.
+	Aggregate() (aggregateType, aggregateID string)
+}
+
+// UserCreated se emite al crear un usuario (también por invitación o aprovisionamiento).
+type UserCreated struct {
+	After *User `json:"after"`
+}
+
+// UserUpdated se emite al cambiar los datos o el estado de un usuario.
+type UserUpdated struct {
+	Before *User `json:"before"`
+	After  *User `json:"after"`
+}
+
+// UserDeleted se emite al eliminar un usuario.
+type UserDeleted struct {
+	Before *User `json:"before"`
+}
+
+func (UserCreated) EventType() string { return EventUserCreated }
+func (UserUpdated) EventType() string { return EventUserUpdated }
+func (UserDeleted) EventType() string { return EventUserDeleted }
+
+func (e UserCreated) Aggregate() (string, string) { return userAggregate(e.After) }
+func (e UserUpdated) Aggregate() (string, string) { return userAggregate(e.After) }
+func (e UserDeleted) Aggregate() (string, string) { return userAggregate(e.Before) }
+
+func userAggregate(u *User) (string, string) {
+	return AggregateUser, strconv.FormatInt(u.ID, 10)
+}
+
+// UserChange es el payload de cualquier evento de usuario: Before es nil al crear y After al eliminar.
+type UserChange struct {
+	Before *User `json:"before"`
+	After  *User `json:"after"`
+}
+
+// DecodeUserChange lee el payload de un evento de usuario.
+func DecodeUserChange(e *Event) (*UserChange, error) {
+	var change UserChange
+	if err := json.Unmarshal(e.Payload, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// Event es un evento de dominio serializado tal como se guarda en el outbox y se publica.
+// La entrega es al menos una vez: los consumidores deben deduplicar por ID.
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Actor         string          `json:"actor,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	// Attempts son los intentos de publicación fallidos.
+	Attempts int `json:"-"`
+}
+
+// NewEvent serializa un evento de dominio.
+func NewEvent(e DomainEvent, actor string, at time.Time) (*Event, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	aggregateType, aggregateID := e.Aggregate()
+	return &Event{
+		Type:          e.EventType(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Actor:         actor,
+		Payload:       payload,
+		OccurredAt:    at,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package model
+
+// FilterOp es el operador de un filtro de List. Los textos se comparan sin distinguir mayúsculas.
+type FilterOp string
+
+const (
+	FilterEq         FilterOp = "eq"
+	FilterNe         FilterOp = "ne"
+	FilterContains   FilterOp = "co"
+	FilterStartsWith FilterOp = "sw"
+	FilterEndsWith   FilterOp = "ew"
+	// FilterPresent exige un valor no nulo ni vacío; ignora Value.
+	FilterPresent FilterOp = "pr"
+)
+
+// Filter es un valor de filtro con operador explícito. Los valores sin envolver conservan el
+// comportamiento por defecto de cada columna (ILIKE '%valor%' o igualdad).
+type Filter struct {
+	Op    FilterOp
+	Value interface{}
+}
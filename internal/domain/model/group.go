@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Group agrupa usuarios y les otorga un conjunto de roles.
+type Group struct {
+	ID          int64     `json:"id" db:"id,pk"`
+	Name        string    `json:"name" db:"name" validate:"required,max=100"`
+	Description string    `json:"description" db:"description" validate:"max=500"`
+	Roles       []string  `json:"roles" db:"roles,array"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at,readonly"`
+}
+
+// GroupMembersRequest es el cuerpo de las operaciones de membresía.
+type GroupMembersRequest struct {
+	// UserIDs admite IDs numéricos y públicos.
+	UserIDs []UserRef `json:"user_ids" validate:"required,min=1,dive,required" swaggertype:"array,string"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// ExternalIdentity es lo que un proveedor OpenID Connect afirma del usuario tras iniciar sesión.
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// UserIdentity vincula un usuario local con una identidad de un proveedor externo. La pareja
+// Issuer y Subject identifica a la persona aunque cambie su email en el proveedor.
+type UserIdentity struct {
+	UserID      int64     `json:"user_id"`
+	Issuer      string    `json:"issuer"`
+	Subject     string    `json:"subject"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+// OIDCLoginState es lo que se guarda entre la redirección al proveedor y la vuelta al callback:
+// el verificador PKCE y el nonce que debe traer el ID token.
+type OIDCLoginState struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// InvitationStatus es el estado de una invitación; sólo las pendientes pueden aceptarse,
+// reenviarse o revocarse.
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	InvitationStatusExpired  InvitationStatus = "expired"
+)
+
+// IsValid indica si el estado es uno de los estados conocidos.
+func (s InvitationStatus) IsValid() bool {
+	switch s {
+	case InvitationStatusPending, InvitationStatusAccepted, InvitationStatusRevoked, InvitationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// Invitation invita a alguien por email a crear su cuenta. Mientras está pendiente existe un
+// usuario en estado invited; si se revoca o vence, ese usuario se elimina y UserID queda en 0.
+type Invitation struct {
+	ID         int64            `json:"id"`
+	Email      string           `json:"email"`
+	UserID     int64            `json:"user_id,omitempty"`
+	Status     InvitationStatus `json:"status"`
+	InvitedBy  string           `json:"invited_by"`
+	SentCount  int              `json:"sent_count"`
+	CreatedAt  time.Time        `json:"created_at"`
+	LastSentAt time.Time        `json:"last_sent_at"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	AcceptedAt *time.Time       `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time       `json:"revoked_at,omitempty"`
+}
+
+// InvitationRequest es el cuerpo de POST /invitations. Name y Attributes son opcionales: el
+// invitado elige su nombre al aceptar.
+type InvitationRequest struct {
+	Email      string                 `json:"email" validate:"required,email,max=255"`
+	Name       string                 `json:"name" validate:"max=100"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// InvitationAcceptance es el cuerpo de POST /auth/invitations/accept.
+type InvitationAcceptance struct {
+	Token    string `json:"token" validate:"required"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Password string `json:"password" validate:"required"`
+}
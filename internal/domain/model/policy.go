@@ -0,0 +1,107 @@
+package model
+
+import "strings"
+
+// Permission es una acción sobre un recurso con formato "recurso:acción".
+// El sufijo ":self" restringe la acción a la propia cuenta del principal.
+type Permission string
+
+const (
+	PermUsersRead   Permission = "users:read"
+	PermUsersCreate Permission = "users:create"
+	PermUsersUpdate Permission = "users:update"
+	PermUsersDelete Permission = "users:delete"
+	PermUsersStatus Permission = "users:status"
+	PermGroupsRead  Permission = "groups:read"
+	PermGroupsWrite Permission = "groups:write"
+	PermAttrsRead   Permission = "attributes:read"
+	PermAttrsWrite  Permission = "attributes:write"
+	PermKeysRead    Permission = "apikeys:read"
+	PermKeysWrite   Permission = "apikeys:write"
+	PermHooksRead   Permission = "webhooks:read"
+	PermHooksWrite  Permission = "webhooks:write"
+	PermWildcard    Permission = "*"
+)
+
+const permSelfSuffix = ":self"
+
+// Roles predefinidos.
+const (
+	RoleAdmin   = "admin"
+	RoleUser    = "user"
+	RoleAuditor = "auditor"
+)
+
+// Self devuelve la variante del permiso restringida a la propia cuenta.
+func (p Permission) Self() Permission {
+	return p + permSelfSuffix
+}
+
+// IsValid indica si el permiso tiene el formato "*", "recurso:*", "recurso:acción" o
+// "recurso:acción:self".
+func (p Permission) IsValid() bool {
+	if p == PermWildcard {
+		return true
+	}
+	parts := strings.Split(string(p), ":")
+	if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "self") {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, " \t") {
+			return false
+		}
+	}
+	return true
+}
+
+// matches indica si el permiso otorgado cubre el requerido; admite "*" y "recurso:*".
+func (p Permission) matches(required Permission) bool {
+	if p == PermWildcard || p == required {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(string(p), ":*"); ok {
+		return strings.HasPrefix(string(required), prefix+":")
+	}
+	return false
+}
+
+// Policy asigna permisos a cada rol.
+type Policy struct {
+	Roles map[string][]Permission `json:"roles"`
+}
+
+// DefaultPolicy: los administradores gestionan todo, los usuarios sólo leen y actualizan su
+// propia cuenta y los auditores tienen acceso de solo lectura.
+func DefaultPolicy() *Policy {
+	return &Policy{Roles: map[string][]Permission{
+		RoleAdmin: {PermWildcard},
+		RoleUser:  {PermUsersRead.Self(), PermUsersUpdate.Self()},
+		RoleAuditor: {
+			PermUsersRead, PermGroupsRead, PermAttrsRead, PermKeysRead, PermHooksRead,
+		},
+	}}
+}
+
+// Allows indica si alguno de los roles tiene el permiso requerido.
+func (p *Policy) Allows(roles []string, required Permission) bool {
+	for _, role := range roles {
+		for _, granted := range p.Roles[role] {
+			if granted.matches(required) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ForbiddenError indica que al principal le falta un permiso.
+type ForbiddenError struct {
+	Permission Permission
+}
+
+func (e *ForbiddenError) Error() string {
+	return "missing permission " + string(e.Permission)
+}
+
+func (e *ForbiddenError) Unwrap() error { return ErrForbidden }
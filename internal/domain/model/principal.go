@@ -0,0 +1,54 @@
+package model
+
+import "context"
+
+// Principal identifica a quien realiza una petición autenticada.
+type Principal struct {
+	Subject string `json:"sub"`
+	// UserID es el ID del usuario local cuando el sujeto corresponde a una cuenta de este servicio.
+	UserID int64 `json:"user_id,omitempty"`
+	// SessionID es la sesión de inicio de sesión de la que proviene el token, si la hay.
+	SessionID  int64                  `json:"session_id,omitempty"`
+	Email      string                 `json:"email,omitempty"`
+	Roles      []string               `json:"roles,omitempty"`
+	Claims     map[string]interface{} `json:"claims,omitempty"`
+	AuthMethod string                 `json:"auth_method"`
+	// Permissions se otorgan directamente, además de los de los roles (p. ej. los de una API key).
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// HasRole indica si el principal tiene el rol indicado.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Grants indica si alguno de los permisos otorgados directamente cubre el requerido.
+func (p *Principal) Grants(required Permission) bool {
+	for _, granted := range p.Permissions {
+		if granted.matches(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// SystemPrincipal representa procesos internos (tareas en segundo plano, migraciones, ...).
+var SystemPrincipal = &Principal{Subject: "system", Roles: []string{RoleAdmin}, AuthMethod: "system"}
+
+type principalKey struct{}
+
+// WithPrincipal devuelve un contexto que transporta al principal autenticado.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom obtiene el principal autenticado del contexto, si existe.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Session es un inicio de sesión de un usuario en un dispositivo. Cada sesión tiene un token de
+// renovación vigente que rota en cada uso; presentar uno ya rotado revoca la sesión.
+type Session struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// ExternalRoles son los roles que otorgó el proveedor de identidad al abrir la sesión; se
+	// suman a los del usuario en cada renovación.
+	ExternalRoles []string `json:"external_roles,omitempty"`
+	// Current marca la sesión del token con el que se hizo la consulta.
+	Current bool `json:"current"`
+}
+
+// IsActive indica si la sesión no fue revocada ni venció.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// ClientInfo describe el dispositivo desde el que se inicia o renueva una sesión.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
@@ -1,7 +1,56 @@
 package model
 
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
 type User struct {
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID int64 `json:"id" db:"id,pk"`
+	// PublicID es el identificador no adivinable que se usa en las URLs; se asigna al crear el usuario.
+	PublicID string `json:"public_id" db:"public_id,createonly"`
+	Name     string `json:"name" db:"name"`
+	Email    string `json:"email" db:"email"`
+	// EmailVerified sólo cambia mediante el flujo de verificación de email.
+	EmailVerified bool                   `json:"email_verified" db:"email_verified,readonly"`
+	Status        UserStatus             `json:"status,omitempty" db:"status,createonly"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty" db:"attributes,json"`
+}
+
+// UserRef identifica a un usuario por su ID numérico o por su ID público. En JSON admite un
+// número o un string, y un ID numérico se escribe como número.
+type UserRef string
+
+func (r *UserRef) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		return json.Unmarshal(data, (*string)(r))
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*r = UserRef(n)
+	return nil
+}
+
+func (r UserRef) MarshalJSON() ([]byte, error) {
+	if id, err := strconv.ParseInt(string(r), 10, 64); err == nil {
+		return strconv.AppendInt(nil, id, 10), nil
+	}
+	return json.Marshal(string(r))
+}
+
+// UserLookupRequest es el cuerpo de POST /users/lookup.
+type UserLookupRequest struct {
+	IDs []UserRef `json:"ids" validate:"required,min=1,dive,required" swaggertype:"array,string"`
+	// Fields limita los campos devueltos, como ?fields= en GET /users.
+	Fields []string `json:"fields"`
+}
+
+// UserLookup son los usuarios encontrados, en el orden pedido, y los IDs que no existen tal
+// como se pidieron.
+type UserLookup struct {
+	Users   []*User   `json:"users"`
+	Missing []UserRef `json:"missing" swaggertype:"array,string"`
 }
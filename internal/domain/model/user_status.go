@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// UserStatus representa el estado del ciclo de vida de una cuenta de usuario.
+type UserStatus string
+
+const (
+	UserStatusInvited     UserStatus = "invited"
+	UserStatusActive      UserStatus = "active"
+	UserStatusSuspended   UserStatus = "suspended"
+	UserStatusLocked      UserStatus = "locked"
+	UserStatusDeactivated UserStatus = "deactivated"
+)
+
+// userStatusTransitions define las transiciones permitidas desde cada estado.
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusInvited:     {UserStatusActive, UserStatusDeactivated},
+	UserStatusActive:      {UserStatusSuspended, UserStatusLocked, UserStatusDeactivated},
+	UserStatusSuspended:   {UserStatusActive, UserStatusDeactivated},
+	UserStatusLocked:      {UserStatusActive, UserStatusDeactivated},
+	UserStatusDeactivated: {UserStatusActive},
+}
+
+// IsValid indica si el estado es uno de los estados conocidos.
+func (s UserStatus) IsValid() bool {
+	_, ok := userStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo indica si se permite pasar del estado actual al estado destino.
+func (s UserStatus) CanTransitionTo(to UserStatus) bool {
+	for _, allowed := range userStatusTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresReason indica si el cambio hacia este estado exige un motivo.
+func (s UserStatus) RequiresReason() bool {
+	return s == UserStatusSuspended || s == UserStatusLocked || s == UserStatusDeactivated
+}
+
+// AllowsLogin indica si un usuario en este estado puede iniciar sesión y mantener sesiones abiertas.
+func (s UserStatus) AllowsLogin() bool {
+	return s == UserStatusActive
+}
+
+// UserStatusChange registra una transición de estado con su actor y fecha.
+type UserStatusChange struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	From      UserStatus `json:"from"`
+	To        UserStatus `json:"to"`
+	Reason    string     `json:"reason,omitempty"`
+	Actor     string     `json:"actor"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// TokenPurpose indica para qué flujo se emitió un token de un solo uso.
+type TokenPurpose string
+
+const (
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// UserToken es un token de un solo uso enviado por email. Sólo se guarda su hash; el token en
+// claro únicamente viaja en el mensaje.
+type UserToken struct {
+	Hash    string
+	UserID  int64
+	Purpose TokenPurpose
+	// Email es la dirección a la que se envió; si el usuario la cambia, el token deja de valer.
+	Email     string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
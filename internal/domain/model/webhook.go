@@ -0,0 +1,90 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSecretPrefix identifica los secretos de firma de webhooks.
+const WebhookSecretPrefix = "whsec_"
+
+// WebhookAllEvents suscribe un webhook a todos los tipos de evento.
+const WebhookAllEvents = "*"
+
+// Webhook es una suscripción de un socio a eventos de dominio. Las entregas se firman con el
+// secreto, que sólo se muestra al crearlo o rotarlo.
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Secret string `json:"-"`
+}
+
+// Subscribes indica si el webhook recibe eventos del tipo indicado.
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == WebhookAllEvents || t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IssuedWebhook acompaña al webhook recién creado o con el secreto rotado con su secreto en claro.
+type IssuedWebhook struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookRequest es el cuerpo de POST y PUT /webhooks. Active es opcional al crear (por defecto true).
+type WebhookRequest struct {
+	URL        string   `json:"url" validate:"required,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	Active     *bool    `json:"active"`
+}
+
+// DeliveryStatus es el estado de una entrega.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending espera su primer intento o un reintento.
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySucceeded recibió una respuesta 2xx.
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryDead agotó los reintentos; sólo se vuelve a enviar manualmente.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// WebhookDelivery es el envío de un evento a un webhook y el resultado de su último intento.
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	WebhookID     int64           `json:"webhook_id"`
+	EventID       int64           `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	Status        DeliveryStatus  `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at,omitempty"`
+	ResponseCode  int             `json:"response_code,omitempty"`
+	ResponseBody  string          `json:"response_body,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	DurationMs    int64           `json:"duration_ms,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// DeliveryAttempt es el resultado de un intento de entrega.
+type DeliveryAttempt struct {
+	At           time.Time
+	ResponseCode int
+	ResponseBody string
+	Duration     time.Duration
+	// Err es el error de red o la respuesta no 2xx; nil si la entrega tuvo éxito.
+	Err error
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+type APIKeyRepository interface {
+	Create(key *model.APIKey) error
+	Get(id int64) (*model.APIKey, error)
+	// GetByPrefix busca la clave por su prefijo visible, incluidos sus hashes.
+	GetByPrefix(prefix string) (*model.APIKey, error)
+	// List devuelve las claves más recientes primero, incluidas las revocadas.
+	List(offset, limit int) ([]*model.APIKey, error)
+	// Rotate reemplaza el hash de una clave no revocada; el anterior sigue valiendo hasta
+	// previousExpiresAt (nil lo anula de inmediato).
+	Rotate(id int64, hash string, previousExpiresAt *time.Time, now time.Time) (*model.APIKey, error)
+	Revoke(id int64, now time.Time) error
+	// Touch registra el último uso de la clave.
+	Touch(id int64, now time.Time) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/jnates/crud_golang/internal/domain/model"
+
+type AttributeDefinitionRepository interface {
+	GetByName(name string) (*model.AttributeDefinition, error)
+	// Create falla con ErrRequiredAttributeWithUsers si la definición es obligatoria y ya hay
+	// usuarios, que no tendrían el atributo.
+	Create(def *model.AttributeDefinition) (int64, error)
+	// Delete elimina la definición y quita el atributo de los usuarios en una misma transacción.
+	Delete(name string) error
+	List() ([]*model.AttributeDefinition, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/jnates/crud_golang/internal/domain/model"
+
+// PasswordHasher calcula y comprueba hashes de contraseñas.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) bool
+}
+
+// TokenIssuer emite el token de acceso de un principal; los de renovación son opacos y los
+// gestiona la aplicación con sesiones en servidor.
+type TokenIssuer interface {
+	Issue(principal *model.Principal) (*model.TokenPair, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+type CredentialRepository interface {
+	Get(userID int64) (*model.Credentials, error)
+	GetByEmail(email string) (*model.Credentials, error)
+	SetPassword(userID int64, hash string, changedAt time.Time) error
+	// RecordFailedLogin incrementa el contador de intentos fallidos y devuelve el nuevo valor.
+	RecordFailedLogin(userID int64) (int, error)
+	// Lock bloquea el acceso hasta until y reinicia el contador de intentos.
+	Lock(userID int64, until time.Time) error
+	ResetFailedLogins(userID int64) error
+}
@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// EventPublisher entrega eventos de dominio a sistemas externos. Un mismo evento puede
+// publicarse más de una vez; devolver un error hace que se reintente más tarde.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *model.Event) error
+}
+
+// OutboxRepository administra los eventos pendientes del outbox.
+type OutboxRepository interface {
+	// Claim reserva hasta limit eventos pendientes hasta leaseUntil y los devuelve por ID
+	// ascendente. Un evento no se reserva mientras haya otro anterior del mismo agregado sin
+	// publicar que esté reservado o esperando un reintento, para respetar el orden por agregado.
+	Claim(limit int, now, leaseUntil time.Time) ([]*model.Event, error)
+	MarkPublished(id int64, at time.Time) error
+	// MarkFailed libera el evento y programa el siguiente intento para retryAt.
+	MarkFailed(id int64, reason string, retryAt time.Time) error
+	// Release libera la reserva de un evento que no se intentó publicar.
+	Release(id int64) error
+	// PurgePublished elimina los eventos publicados antes de before.
+	PurgePublished(before time.Time) (int, error)
+	// Get obtiene un evento, publicado o no.
+	Get(id int64) (*model.Event, error)
+	// ListAfter devuelve hasta limit eventos del tipo de agregado con ID mayor que afterID y
+	// registrados antes de before, por ID ascendente, publicados o no. Los IDs se asignan al
+	// insertar y no al confirmar: before deja fuera los eventos recientes, entre los que aún
+	// pueden aparecer IDs menores que los ya visibles.
+	ListAfter(aggregateType string, afterID int64, before time.Time, limit int) ([]*model.Event, error)
+	// LastID devuelve el mayor ID de los eventos del tipo de agregado registrados antes de
+	// before, o 0 si no hay ninguno.
+	LastID(aggregateType string, before time.Time) (int64, error)
+}
+
+// EventNotifier avisa a cada instancia de los eventos que se confirman en el outbox.
+type EventNotifier interface {
+	// Listen entrega el ID de cada evento nuevo hasta que ctx termine. Un 0 indica que pudieron
+	// perderse avisos (por ejemplo, tras una reconexión) y hay que releer el outbox. El canal se
+	// cierra al terminar.
+	Listen(ctx context.Context) (<-chan int64, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import "github.com/jnates/crud_golang/internal/domain/model"
+
+// GroupRepository persiste grupos y su membresía. Al eliminar un usuario
+// sus membresías deben eliminarse en cascada.
+type GroupRepository interface {
+	Repository[model.Group, int64]
+	AddMembers(groupID int64, userIDs []int64) error
+	// CreateWithMembers crea el grupo con sus miembros iniciales en una misma transacción.
+	CreateWithMembers(group *model.Group, userIDs []int64) (int64, error)
+	// ReplaceWithMembers actualiza el grupo y reemplaza su lista de miembros en una misma transacción.
+	ReplaceWithMembers(group *model.Group, userIDs []int64) error
+	RemoveMembers(groupID int64, userIDs []int64) error
+	ListMembers(groupID int64) ([]*model.User, error)
+	ListByUser(userID int64) ([]*model.Group, error)
+	// ListByUsers obtiene los grupos de varios usuarios en una sola consulta; los usuarios sin
+	// grupos no aparecen en el mapa.
+	ListByUsers(userIDs []int64) (map[int64][]*model.Group, error)
+}
@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// IdentityProvider es un proveedor OpenID Connect con el flujo authorization code + PKCE.
+type IdentityProvider interface {
+	// AuthCodeURL devuelve la URL del proveedor a la que se redirige al usuario.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange canjea el código por un ID token, lo valida (firma, emisor, audiencia y nonce)
+	// y devuelve la identidad que afirma.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error)
+}
+
+// LoginStateStore guarda el estado de los inicios de sesión externos en curso.
+type LoginStateStore interface {
+	Save(state *model.OIDCLoginState) error
+	// Take devuelve el estado y lo elimina, de modo que cada uno se canjea una sola vez.
+	// Devuelve ErrInvalidLoginState si no existe o ya venció.
+	Take(state string, now time.Time) (*model.OIDCLoginState, error)
+}
+
+type IdentityRepository interface {
+	// GetBySubject devuelve el vínculo de una identidad externa o ErrIdentityNotFound.
+	GetBySubject(issuer, subject string) (*model.UserIdentity, error)
+	// Link vincula la identidad con un usuario existente.
+	Link(identity *model.UserIdentity) error
+	// Provision crea el usuario (activo y con el email verificado) y su vínculo en una misma
+	// transacción.
+	Provision(user *model.User, identity *model.UserIdentity) error
+	// Touch registra un nuevo inicio de sesión y el email actual en el proveedor.
+	Touch(issuer, subject, email string, now time.Time) error
+	ListByUser(userID int64) ([]*model.UserIdentity, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+type InvitationRepository interface {
+	// Create guarda en una transacción el usuario invitado y la invitación con el hash de su token.
+	Create(invitation *model.Invitation, user *model.User, tokenHash string) error
+	Get(id int64) (*model.Invitation, error)
+	// List devuelve las invitaciones más recientes primero; status vacío no filtra.
+	List(offset, limit int, status model.InvitationStatus) ([]*model.Invitation, error)
+	// Renew reemplaza el token de una invitación pendiente y extiende su vencimiento.
+	Renew(id int64, tokenHash string, expiresAt, now time.Time) (*model.Invitation, error)
+	// Accept canjea en una transacción la invitación pendiente y vigente del token: la marca
+	// como aceptada, fija el nombre y la contraseña del invitado, verifica su email y lo activa.
+	// Cualquier otro caso produce ErrInvalidInvitation.
+	Accept(tokenHash, name, passwordHash string, now time.Time) (*model.Invitation, *model.User, error)
+	// Revoke revoca una invitación pendiente y elimina al usuario invitado.
+	Revoke(id int64, actor string, now time.Time) (*model.Invitation, error)
+	// ExpirePending marca como vencidas las invitaciones pendientes cuyo plazo pasó, elimina a
+	// sus usuarios invitados y devuelve cuántas eran.
+	ExpirePending(now time.Time) (int, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// Mailer renderiza y entrega emails a partir de plantillas localizadas.
+type Mailer interface {
+	Send(ctx context.Context, email *model.Email) error
+}
@@ -0,0 +1,23 @@
+package ports
+
+// Repository es el puerto genérico de persistencia para cualquier entidad T identificada por ID.
+// Los puertos específicos (GroupRepository, ...) lo embeben y agregan sus propias operaciones;
+// UserRepository no, porque sus escrituras deben guardar eventos.
+type Repository[T any, ID comparable] interface {
+	GetByID(id ID) (*T, error)
+	Create(entity *T) (ID, error)
+	Update(entity *T) error
+	Delete(id ID) error
+	List(offset, limit int, filter map[string]interface{}) ([]*T, error)
+	// Count cuenta los registros que cumplen los mismos filtros que List.
+	Count(filter map[string]interface{}) (int, error)
+}
+
+// FieldSelector lo implementan los repositorios que pueden leer sólo algunos campos de T,
+// nombrados como en su JSON. Los campos no leídos quedan con su valor cero.
+type FieldSelector[T any, ID comparable] interface {
+	GetByIDFields(id ID, fields []string) (*T, error)
+	// GetByIDsFields lee siempre también la clave primaria.
+	GetByIDsFields(ids []ID, fields []string) ([]*T, error)
+	ListFields(offset, limit int, filter map[string]interface{}, fields []string) ([]*T, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+type SessionRepository interface {
+	// Create registra la sesión junto con el hash de su primer token de renovación.
+	Create(session *model.Session, tokenHash string) error
+	Get(id int64) (*model.Session, error)
+	// Rotate canjea el token de renovación oldHash por newHash de forma atómica. Si oldHash ya
+	// fue usado, revoca la sesión y devuelve ErrRefreshTokenReused.
+	Rotate(oldHash, newHash string, client model.ClientInfo, now time.Time) (*model.Session, error)
+	// GetByToken devuelve la sesión a la que pertenece un token de renovación.
+	GetByToken(tokenHash string) (*model.Session, error)
+	Revoke(id int64, now time.Time) error
+	// RevokeAll revoca todas las sesiones activas del usuario y devuelve cuántas eran.
+	RevokeAll(userID int64, now time.Time) (int, error)
+	// RevokeOthers es RevokeAll salvo la sesión keepID; con keepID 0 las revoca todas.
+	RevokeOthers(userID, keepID int64, now time.Time) (int, error)
+	ListActive(userID int64, now time.Time) ([]*model.Session, error)
+}
@@ -2,10 +2,32 @@ package ports
 
 import "github.com/jnates/crud_golang/internal/domain/model"
 
+// UserRepository persiste usuarios. CreateWithEvent, CreateWithStatusChange, UpdateWithEvent,
+// DeleteWithEvent y ChangeStatus guardan el evento de dominio en el outbox en la misma transacción que el cambio.
+// No embebe Repository: sus Create, Update y Delete no guardan eventos, así que toda escritura
+// pasa por una de las variantes anteriores.
 type UserRepository interface {
 	GetByID(id int64) (*model.User, error)
-	Create(user *model.User) (int64, error)
-	Update(user *model.User) error
-	Delete(id int64) error
 	List(offset, limit int, filter map[string]interface{}) ([]*model.User, error)
+	// Count cuenta los usuarios que cumplen los mismos filtros que List.
+	Count(filter map[string]interface{}) (int, error)
+	FieldSelector[model.User, int64]
+	// GetByIDs obtiene varios usuarios en una sola consulta; los IDs inexistentes se omiten.
+	GetByIDs(ids []int64) ([]*model.User, error)
+	// GetIDByPublicID devuelve el ID interno del usuario con ese ID público.
+	GetIDByPublicID(publicID string) (int64, error)
+	// GetIDsByPublicIDs es GetIDByPublicID para varios IDs públicos en una consulta; los que no
+	// existen no aparecen en el resultado.
+	GetIDsByPublicIDs(publicIDs []string) (map[string]int64, error)
+	CreateWithEvent(user *model.User, actor string) (int64, error)
+	// CreateWithStatusChange crea el usuario en el estado change.To y registra la transición en
+	// la misma transacción.
+	CreateWithStatusChange(user *model.User, change *model.UserStatusChange) (int64, error)
+	// UpdateWithEvent actualiza el usuario; si cambia el email, vuelve a quedar sin verificar.
+	UpdateWithEvent(user *model.User, actor string) error
+	DeleteWithEvent(id int64, actor string) error
+	ChangeStatus(change *model.UserStatusChange) error
+	ListStatusChanges(userID int64) ([]*model.UserStatusChange, error)
+	// SetEmailVerified cambia la verificación del email y guarda UserUpdated en una misma transacción.
+	SetEmailVerified(userID int64, verified bool, actor string) error
 }
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+type UserTokenRepository interface {
+	Create(token *model.UserToken) error
+	// Consume marca como usado un token vigente del propósito indicado y lo devuelve; un token
+	// inexistente, vencido o ya usado produce ErrInvalidUserToken.
+	Consume(hash string, purpose model.TokenPurpose, now time.Time) (*model.UserToken, error)
+	// ResetPassword canjea un token de restablecimiento y fija el hash de la contraseña nueva
+	// en una misma transacción; un token inválido, o emitido para un email que el usuario ya
+	// no tiene, produce ErrInvalidUserToken.
+	ResetPassword(hash, passwordHash string, now time.Time) (*model.UserToken, error)
+	// InvalidateAll anula los tokens pendientes de un usuario para ese propósito.
+	InvalidateAll(userID int64, purpose model.TokenPurpose, now time.Time) error
+}
@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// WebhookRepository persiste las suscripciones y sus entregas.
+type WebhookRepository interface {
+	Create(hook *model.Webhook) error
+	// Get obtiene el webhook, incluido su secreto.
+	Get(id int64) (*model.Webhook, error)
+	// List devuelve los webhooks más recientes primero.
+	List(offset, limit int) ([]*model.Webhook, error)
+	Update(hook *model.Webhook) error
+	SetSecret(id int64, secret string, now time.Time) (*model.Webhook, error)
+	Delete(id int64) error
+
+	// Enqueue crea una entrega del evento por cada webhook activo suscrito a su tipo y devuelve
+	// cuántas creó. Es idempotente: el mismo evento no se encola dos veces para un webhook.
+	Enqueue(event *model.Event, payload []byte, now time.Time) (int, error)
+	// ClaimDeliveries reserva hasta leaseUntil hasta limit entregas pendientes cuyo intento
+	// venció, de webhooks activos.
+	ClaimDeliveries(limit int, now, leaseUntil time.Time) ([]*model.WebhookDelivery, error)
+	GetDelivery(webhookID, id int64) (*model.WebhookDelivery, error)
+	// ListDeliveries devuelve las entregas del webhook más recientes primero; status vacío no filtra.
+	ListDeliveries(webhookID int64, status model.DeliveryStatus, offset, limit int) ([]*model.WebhookDelivery, error)
+	// RecordAttempt guarda el resultado de un intento, el nuevo estado y el próximo intento
+	// (nil si no habrá otro) y libera la reserva.
+	RecordAttempt(id int64, attempt *model.DeliveryAttempt, status model.DeliveryStatus, nextAttemptAt *time.Time) (*model.WebhookDelivery, error)
+}
+
+// WebhookSender envía una entrega firmada al URL del webhook.
+type WebhookSender interface {
+	Send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) *model.DeliveryAttempt
+}
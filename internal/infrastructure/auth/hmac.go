@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/pkg/client"
+)
+
+// AuthMethodHMAC identifica a los principales autenticados con una petición firmada.
+const AuthMethodHMAC = "hmac"
+
+const (
+	defaultClockSkew    = 5 * time.Minute
+	defaultMaxBodyBytes = 10 << 20
+	minSecretLength     = 32
+)
+
+var (
+	// ErrInvalidSignature agrupa los motivos de rechazo de una petición firmada.
+	ErrInvalidSignature = errors.New("invalid request signature")
+	// ErrSignatureExpired indica que el timestamp está fuera de la tolerancia de reloj.
+	ErrSignatureExpired = errors.New("request signature timestamp outside the allowed clock skew")
+	// ErrReplayedRequest indica que el nonce ya se usó.
+	ErrReplayedRequest = errors.New("request nonce already used")
+)
+
+// HMACClient es un cliente interno con su secreto compartido y lo que se le permite.
+type HMACClient struct {
+	Secret      string             `json:"secret"`
+	Roles       []string           `json:"roles"`
+	Permissions []model.Permission `json:"permissions"`
+}
+
+// HMACConfig configura la verificación de peticiones firmadas.
+type HMACConfig struct {
+	Clients map[string]HMACClient `json:"clients"`
+	// ClockSkew es la diferencia máxima aceptada entre el timestamp firmado y el reloj local.
+	ClockSkew time.Duration `json:"-"`
+	// MaxBodyBytes limita el cuerpo que se lee para calcular su hash.
+	MaxBodyBytes int64 `json:"-"`
+	// Nonces recuerda los nonces usados; por defecto una caché en memoria.
+	Nonces NonceCache `json:"-"`
+}
+
+// HMACVerifier valida peticiones firmadas con client.Signer.
+type HMACVerifier struct {
+	cfg HMACConfig
+	now func() time.Time
+}
+
+func NewHMACVerifier(cfg HMACConfig) (*HMACVerifier, error) {
+	if len(cfg.Clients) == 0 {
+		return nil, errors.New("HMAC: configure at least one client")
+	}
+	for id, c := range cfg.Clients {
+		if len(c.Secret) < minSecretLength {
+			return nil, fmt.Errorf("HMAC: secret of client %q must have at least %d characters", id, minSecretLength)
+		}
+	}
+	if cfg.ClockSkew <= 0 {
+		cfg.ClockSkew = defaultClockSkew
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if cfg.Nonces == nil {
+		cfg.Nonces = NewMemoryNonceCache()
+	}
+	return &HMACVerifier{cfg: cfg, now: time.Now}, nil
+}
+
+// NewHMACVerifierFromEnv carga los clientes del JSON de HMAC_CLIENTS_FILE
+// ({"clients": {"id": {"secret", "roles", "permissions"}}}) y aplica HMAC_CLOCK_SKEW.
+// Devuelve nil si HMAC_CLIENTS_FILE no está definido.
+func NewHMACVerifierFromEnv() (*HMACVerifier, error) {
+	clientsFile := os.Getenv(enum.HMACClientsFile)
+	if clientsFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(clientsFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading HMAC clients file: %w", err)
+	}
+
+	var cfg HMACConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing HMAC clients file: %w", err)
+	}
+	if skew := os.Getenv(enum.HMACClockSkew); skew != "" {
+		if cfg.ClockSkew, err = time.ParseDuration(skew); err != nil {
+			return nil, fmt.Errorf("%s: %w", enum.HMACClockSkew, err)
+		}
+	}
+	return NewHMACVerifier(cfg)
+}
+
+// IsSigned indica si la petición trae firma HMAC.
+func IsSigned(r *http.Request) bool {
+	return r.Header.Get(client.HeaderSignature) != ""
+}
+
+// VerifyRequest comprueba la firma, el timestamp y el nonce de la petición y devuelve el
+// principal del cliente. El cuerpo queda disponible de nuevo para el handler.
+func (v *HMACVerifier) VerifyRequest(r *http.Request) (*model.Principal, error) {
+	clientID := r.Header.Get(client.HeaderClientID)
+	timestamp := r.Header.Get(client.HeaderTimestamp)
+	nonce := r.Header.Get(client.HeaderNonce)
+	signature := r.Header.Get(client.HeaderSignature)
+	if clientID == "" || timestamp == "" || nonce == "" || signature == "" {
+		return nil, fmt.Errorf("%w: missing signature headers", ErrInvalidSignature)
+	}
+
+	c, ok := v.cfg.Clients[clientID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown client", ErrInvalidSignature)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	signedAt := time.Unix(seconds, 0)
+	now := v.now()
+	if skew := now.Sub(signedAt); skew > v.cfg.ClockSkew || skew < -v.cfg.ClockSkew {
+		return nil, ErrSignatureExpired
+	}
+
+	body, err := v.readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	expected := client.ComputeSignature([]byte(c.Secret), client.CanonicalString(r.Method, r.URL.RequestURI(), timestamp, nonce, body))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+
+	// El nonce sólo se registra con la firma ya validada, para que nadie pueda "gastar" los
+	// nonces de otro cliente. Pasado el plazo el timestamp ya lo rechaza, así que basta con
+	// recordarlo hasta entonces.
+	if !v.cfg.Nonces.Add(clientID+":"+nonce, signedAt.Add(v.cfg.ClockSkew)) {
+		return nil, ErrReplayedRequest
+	}
+
+	return &model.Principal{
+		Subject:     "client:" + clientID,
+		Roles:       c.Roles,
+		Permissions: c.Permissions,
+		AuthMethod:  AuthMethodHMAC,
+	}, nil
+}
+
+func (v *HMACVerifier) readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, v.cfg.MaxBodyBytes+1))
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > v.cfg.MaxBodyBytes {
+		return nil, fmt.Errorf("%w: body too large", ErrInvalidSignature)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
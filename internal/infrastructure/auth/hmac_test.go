@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/pkg/client"
+)
+
+const (
+	testClientID = "billing"
+	testSecret   = "0123456789abcdef0123456789abcdef"
+	testBody     = `{"name":"Ana"}`
+)
+
+var testNow = time.Unix(1_700_000_000, 0)
+
+// newTestHMACVerifier crea un verificador con el reloj fijo en testNow, también en la caché
+// de nonces, para que los plazos no dependan de la hora real.
+func newTestHMACVerifier(t *testing.T, maxBodyBytes int64) *HMACVerifier {
+	t.Helper()
+	nonces := NewMemoryNonceCache()
+	nonces.now = func() time.Time { return testNow }
+
+	v, err := NewHMACVerifier(HMACConfig{
+		Clients: map[string]HMACClient{
+			testClientID: {Secret: testSecret, Roles: []string{model.RoleUser}},
+		},
+		ClockSkew:    time.Minute,
+		MaxBodyBytes: maxBodyBytes,
+		Nonces:       nonces,
+	})
+	if err != nil {
+		t.Fatalf("NewHMACVerifier: %v", err)
+	}
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+// signedRequest arma una petición firmada por el cliente de prueba en el instante signedAt.
+func signedRequest(t *testing.T, signedAt time.Time, body string) *http.Request {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/users?notify=true", strings.NewReader(body))
+	signer := &client.Signer{ClientID: testClientID, Secret: []byte(testSecret), Now: func() time.Time { return signedAt }}
+	if err := signer.Sign(r); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	return r
+}
+
+func TestVerifyRequestAcceptsValidSignature(t *testing.T) {
+	v := newTestHMACVerifier(t, 0)
+	r := signedRequest(t, testNow, testBody)
+
+	p, err := v.VerifyRequest(r)
+	if err != nil {
+		t.Fatalf("VerifyRequest: %v", err)
+	}
+	if p.Subject != "client:"+testClientID || p.AuthMethod != AuthMethodHMAC {
+		t.Fatalf("principal = %+v, want client:%s via %s", p, testClientID, AuthMethodHMAC)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("reading body after verification: %v", err)
+	}
+	if string(body) != testBody {
+		t.Fatalf("body after verification = %q, want %q", body, testBody)
+	}
+}
+
+func TestVerifyRequestRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		request func(t *testing.T) *http.Request
+		want    error
+	}{
+		{
+			name: "cuerpo alterado",
+			request: func(t *testing.T) *http.Request {
+				r := signedRequest(t, testNow, testBody)
+				r.Body = io.NopCloser(strings.NewReader(`{"name":"Eva"}`))
+				return r
+			},
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "ruta alterada",
+			request: func(t *testing.T) *http.Request {
+				r := signedRequest(t, testNow, testBody)
+				r.URL.Path = "/users/1"
+				return r
+			},
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "query alterada",
+			request: func(t *testing.T) *http.Request {
+				r := signedRequest(t, testNow, testBody)
+				r.URL.RawQuery = "notify=false"
+				return r
+			},
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "firma del pasado fuera de tolerancia",
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, testNow.Add(-2*time.Minute), testBody)
+			},
+			want: ErrSignatureExpired,
+		},
+		{
+			name: "firma del futuro fuera de tolerancia",
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, testNow.Add(2*time.Minute), testBody)
+			},
+			want: ErrSignatureExpired,
+		},
+		{
+			name: "cliente desconocido",
+			request: func(t *testing.T) *http.Request {
+				r := signedRequest(t, testNow, testBody)
+				r.Header.Set(client.HeaderClientID, "reports")
+				return r
+			},
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "sin headers de firma",
+			request: func(t *testing.T) *http.Request {
+				r := signedRequest(t, testNow, testBody)
+				r.Header.Del(client.HeaderNonce)
+				return r
+			},
+			want: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestHMACVerifier(t, 0)
+			if _, err := v.VerifyRequest(tt.request(t)); !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyRequest: got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRequestAcceptsSkewWithinTolerance(t *testing.T) {
+	for _, offset := range []time.Duration{-time.Minute, time.Minute} {
+		v := newTestHMACVerifier(t, 0)
+		if _, err := v.VerifyRequest(signedRequest(t, testNow.Add(offset), testBody)); err != nil {
+			t.Fatalf("VerifyRequest with skew %v: %v", offset, err)
+		}
+	}
+}
+
+func TestVerifyRequestRejectsReusedNonce(t *testing.T) {
+	v := newTestHMACVerifier(t, 0)
+	r := signedRequest(t, testNow, testBody)
+	replay := r.Clone(r.Context())
+	replay.Body = io.NopCloser(strings.NewReader(testBody))
+
+	if _, err := v.VerifyRequest(r); err != nil {
+		t.Fatalf("first VerifyRequest: %v", err)
+	}
+	if _, err := v.VerifyRequest(replay); !errors.Is(err, ErrReplayedRequest) {
+		t.Fatalf("replayed VerifyRequest: got %v, want ErrReplayedRequest", err)
+	}
+}
+
+func TestVerifyRequestRejectsOversizedBody(t *testing.T) {
+	v := newTestHMACVerifier(t, int64(len(testBody)-1))
+	_, err := v.VerifyRequest(signedRequest(t, testNow, testBody))
+	if !errors.Is(err, ErrInvalidSignature) || !strings.Contains(err.Error(), "body too large") {
+		t.Fatalf("VerifyRequest: got %v, want body too large", err)
+	}
+
+	v = newTestHMACVerifier(t, int64(len(testBody)))
+	if _, err := v.VerifyRequest(signedRequest(t, testNow, testBody)); err != nil {
+		t.Fatalf("VerifyRequest at the limit: %v", err)
+	}
+}
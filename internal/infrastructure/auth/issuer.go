@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+)
+
+const defaultAccessTTL = 15 * time.Minute
+
+// ErrSigningKeyMissing indica que no hay secreto configurado para firmar tokens.
+var ErrSigningKeyMissing = errors.New("JWT: JWT_SECRET is required to issue tokens")
+
+// IssuerConfig configura la emisión de tokens de acceso propios (HS256).
+type IssuerConfig struct {
+	Secret     string
+	Issuer     string
+	Audience   string
+	RolesClaim string
+	AccessTTL  time.Duration
+}
+
+// JWTIssuer firma tokens de acceso con el mismo secreto que acepta JWTVerifier.
+// Los tokens de renovación son opacos y los gestiona AuthService con sesiones en servidor.
+type JWTIssuer struct {
+	cfg IssuerConfig
+	now func() time.Time
+}
+
+func NewJWTIssuer(cfg IssuerConfig) *JWTIssuer {
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.AccessTTL <= 0 {
+		cfg.AccessTTL = defaultAccessTTL
+	}
+	return &JWTIssuer{cfg: cfg, now: time.Now}
+}
+
+// NewJWTIssuerFromEnv construye el emisor a partir de JWT_SECRET, JWT_ISSUER, JWT_AUDIENCE,
+// JWT_ROLES_CLAIM y JWT_ACCESS_TTL.
+func NewJWTIssuerFromEnv() (*JWTIssuer, error) {
+	cfg := IssuerConfig{
+		Secret:     os.Getenv(enum.JWTSecret),
+		Issuer:     os.Getenv(enum.JWTIssuer),
+		Audience:   os.Getenv(enum.JWTAudience),
+		RolesClaim: os.Getenv(enum.JWTRolesClaim),
+	}
+	if value := os.Getenv(enum.JWTAccessTTL); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", enum.JWTAccessTTL, err)
+		}
+		cfg.AccessTTL = d
+	}
+	return NewJWTIssuer(cfg), nil
+}
+
+// Issue firma un token de acceso con los roles del principal. Si el principal pertenece a una
+// sesión, su ID viaja en el claim "sid".
+func (i *JWTIssuer) Issue(p *model.Principal) (*model.TokenPair, error) {
+	if i.cfg.Secret == "" {
+		return nil, ErrSigningKeyMissing
+	}
+
+	now := i.now()
+	claims := jwt.MapClaims{
+		"sub":            p.Subject,
+		"iat":            now.Unix(),
+		"exp":            now.Add(i.cfg.AccessTTL).Unix(),
+		i.cfg.RolesClaim: p.Roles,
+	}
+	if p.Email != "" {
+		claims["email"] = p.Email
+	}
+	if p.SessionID != 0 {
+		claims[sessionClaim] = p.SessionID
+	}
+	if i.cfg.Issuer != "" {
+		claims["iss"] = i.cfg.Issuer
+	}
+	if i.cfg.Audience != "" {
+		claims["aud"] = i.cfg.Audience
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(i.cfg.Secret))
+	if err != nil {
+		return nil, err
+	}
+	return &model.TokenPair{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(i.cfg.AccessTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// jwksRefreshInterval limita la frecuencia con la que se vuelve a descargar un JWKS remoto.
+const jwksRefreshInterval = time.Minute
+
+// ErrUnknownKey indica que el token referencia un kid que no está en el JWKS.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// jwk es una clave pública en formato JSON Web Key (RFC 7517). Sólo se admiten RSA y EC.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet es un conjunto de claves públicas indexadas por kid, cargado desde un archivo o una URL.
+// Cuando el origen es una URL, las claves desconocidas provocan una recarga limitada por jwksRefreshInterval.
+type KeySet struct {
+	file   string
+	url    string
+	client *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+// NewFileKeySet carga un JWKS desde un archivo local.
+func NewFileKeySet(path string) (*KeySet, error) {
+	ks := &KeySet{file: path}
+	return ks, ks.refresh()
+}
+
+// NewURLKeySet descarga un JWKS desde una URL.
+func NewURLKeySet(url string, client *http.Client) (*KeySet, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	ks := &KeySet{url: url, client: client}
+	return ks, ks.refresh()
+}
+
+// Key devuelve la clave pública asociada al kid. Si el JWKS sólo tiene una clave y el token no trae kid, se usa esa.
+func (ks *KeySet) Key(kid string) (crypto.PublicKey, error) {
+	if key, ok := ks.lookup(kid); ok {
+		return key, nil
+	}
+
+	if ks.url != "" && ks.canRefresh() {
+		if err := ks.refresh(); err != nil {
+			log.Warn().Err(err).Str("jwks", ks.url).Msg("⚠️ No se pudo refrescar el JWKS")
+		} else if key, ok := ks.lookup(kid); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+}
+
+func (ks *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+func (ks *KeySet) canRefresh() bool {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return time.Since(ks.lastRefresh) >= jwksRefreshInterval
+}
+
+func (ks *KeySet) refresh() error {
+	data, err := ks.read()
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.lastRefresh = time.Now()
+	ks.mu.Unlock()
+
+	log.Debug().Int("keys", len(keys)).Msg("🔑 JWKS cargado")
+	return nil
+}
+
+func (ks *KeySet) read() ([]byte, error) {
+	if ks.file != "" {
+		return os.ReadFile(ks.file)
+	}
+
+	resp, err := ks.client.Get(ks.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWK %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS has no signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+)
+
+// AuthMethodJWT identifica a los principales autenticados con un bearer token.
+const AuthMethodJWT = "jwt"
+
+// sessionClaim lleva el ID de la sesión en los tokens emitidos por este servicio.
+const sessionClaim = "sid"
+
+var (
+	// ErrTokenExpired indica que el token venció.
+	ErrTokenExpired = errors.New("token expired")
+	// ErrInvalidToken agrupa cualquier otro motivo de rechazo del token.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// JWTConfig configura la validación de tokens.
+type JWTConfig struct {
+	// Secret habilita HS256 con un secreto compartido.
+	Secret string
+	// Keys habilita RS256 y ES256 con las claves públicas de un JWKS.
+	Keys *KeySet
+	// Issuer y Audience, si se indican, deben coincidir con los claims iss y aud.
+	Issuer   string
+	Audience string
+	// RolesClaim es el claim que contiene los roles; por defecto "roles".
+	RolesClaim string
+	// Leeway es la tolerancia de reloj al validar exp, nbf e iat.
+	Leeway time.Duration
+	// Sessions, si se indica, rechaza los tokens con claim "sid" cuya sesión fue revocada o
+	// venció, así un token de acceso deja de valer al cerrar la sesión o suspender al usuario.
+	Sessions SessionGetter
+}
+
+// SessionGetter obtiene una sesión por ID.
+type SessionGetter interface {
+	Get(id int64) (*model.Session, error)
+}
+
+// JWTVerifier valida bearer tokens y los convierte en un model.Principal.
+type JWTVerifier struct {
+	cfg    JWTConfig
+	parser *jwt.Parser
+}
+
+// NewJWTVerifier crea un verificador; requiere al menos un secreto HS256 o un JWKS.
+func NewJWTVerifier(cfg JWTConfig) (*JWTVerifier, error) {
+	var methods []string
+	if cfg.Secret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.Keys != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("JWT: configure a secret or a JWKS")
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return &JWTVerifier{cfg: cfg, parser: jwt.NewParser(opts...)}, nil
+}
+
+// NewJWTVerifierFromEnv construye el verificador a partir de las variables JWT_* y comprueba
+// las sesiones de los tokens en sessions.
+func NewJWTVerifierFromEnv(sessions SessionGetter) (*JWTVerifier, error) {
+	cfg := JWTConfig{
+		Secret:     os.Getenv(enum.JWTSecret),
+		Issuer:     os.Getenv(enum.JWTIssuer),
+		Audience:   os.Getenv(enum.JWTAudience),
+		RolesClaim: os.Getenv(enum.JWTRolesClaim),
+		Sessions:   sessions,
+	}
+
+	if leeway := os.Getenv(enum.JWTLeeway); leeway != "" {
+		d, err := time.ParseDuration(leeway)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", enum.JWTLeeway, err)
+		}
+		cfg.Leeway = d
+	}
+
+	var err error
+	switch {
+	case os.Getenv(enum.JWKSFile) != "":
+		cfg.Keys, err = NewFileKeySet(os.Getenv(enum.JWKSFile))
+	case os.Getenv(enum.JWKSURL) != "":
+		cfg.Keys, err = NewURLKeySet(os.Getenv(enum.JWKSURL), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewJWTVerifier(cfg)
+}
+
+// Verify valida firma, vencimiento, emisor y audiencia del token y devuelve su principal.
+func (v *JWTVerifier) Verify(raw string) (*model.Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(raw, claims, v.keyFunc)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	sub, _ := claims.GetSubject()
+	if sub == "" {
+		return nil, fmt.Errorf("%w: missing sub claim", ErrInvalidToken)
+	}
+
+	// Sólo un token emitido por este servicio, con una sesión vigente del usuario de su "sub",
+	// identifica a un usuario local y habilita los permisos ":self". Un "sub" numérico de otro
+	// emisor (JWKS) no es el ID de ningún usuario local.
+	var userID, sessionID int64
+	if sid, _ := claims[sessionClaim].(float64); sid != 0 && v.cfg.Sessions != nil {
+		userID, _ = strconv.ParseInt(sub, 10, 64)
+		sessionID = int64(sid)
+		if err := v.checkSession(sessionID, userID); err != nil {
+			return nil, err
+		}
+	}
+	email, _ := claims["email"].(string)
+	return &model.Principal{
+		Subject:    sub,
+		UserID:     userID,
+		SessionID:  sessionID,
+		Email:      email,
+		Roles:      stringList(claims[v.cfg.RolesClaim]),
+		Claims:     claims,
+		AuthMethod: AuthMethodJWT,
+	}, nil
+}
+
+// checkSession exige que la sesión del token siga activa y pertenezca a su usuario.
+func (v *JWTVerifier) checkSession(id, userID int64) error {
+	session, err := v.cfg.Sessions.Get(id)
+	if errors.Is(err, model.ErrSessionNotFound) {
+		return fmt.Errorf("%w: unknown session", ErrInvalidToken)
+	}
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || !session.IsActive(time.Now()) {
+		return fmt.Errorf("%w: session revoked or expired", ErrInvalidToken)
+	}
+	return nil
+}
+
+func (v *JWTVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return []byte(v.cfg.Secret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, _ := token.Header["kid"].(string)
+		return v.cfg.Keys.Key(kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// stringList acepta un claim como arreglo JSON o como string separado por espacios (estilo "scope").
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// MemoryLoginStateStore guarda en memoria el estado de los inicios de sesión OIDC en curso.
+// Con varias instancias, la vuelta del proveedor debe llegar a la misma que inició el flujo
+// (afinidad de sesión en el balanceador).
+type MemoryLoginStateStore struct {
+	mu        sync.Mutex
+	entries   map[string]*model.OIDCLoginState
+	lastSweep time.Time
+}
+
+func NewMemoryLoginStateStore() *MemoryLoginStateStore {
+	return &MemoryLoginStateStore{entries: make(map[string]*model.OIDCLoginState)}
+}
+
+func (s *MemoryLoginStateStore) Save(state *model.OIDCLoginState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Los estados abandonados se descartan como mucho una vez por minuto.
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= nonceSweepInterval {
+		for key, entry := range s.entries {
+			if !now.Before(entry.ExpiresAt) {
+				delete(s.entries, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	s.entries[state.State] = state
+	return nil
+}
+
+func (s *MemoryLoginStateStore) Take(state string, now time.Time) (*model.OIDCLoginState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[state]
+	if !ok {
+		return nil, model.ErrInvalidLoginState
+	}
+	delete(s.entries, state)
+	if !now.Before(entry.ExpiresAt) {
+		return nil, model.ErrInvalidLoginState
+	}
+	return entry, nil
+}
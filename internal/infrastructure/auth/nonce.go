@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// nonceSweepInterval es cada cuánto se descartan los nonces vencidos de la caché en memoria.
+const nonceSweepInterval = time.Minute
+
+// NonceCache recuerda los nonces de las peticiones firmadas para rechazar repeticiones.
+type NonceCache interface {
+	// Add registra el nonce hasta expires y devuelve false si ya estaba registrado.
+	Add(nonce string, expires time.Time) bool
+}
+
+// MemoryNonceCache guarda los nonces en memoria. Con varias instancias detrás de un balanceador
+// cada una tiene su propia caché, así que una repetición enviada a otra instancia no se detecta.
+type MemoryNonceCache struct {
+	mu        sync.Mutex
+	entries   map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryNonceCache() *MemoryNonceCache {
+	return &MemoryNonceCache{entries: make(map[string]time.Time), now: time.Now}
+}
+
+func (c *MemoryNonceCache) Add(nonce string, expires time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= nonceSweepInterval {
+		for key, until := range c.entries {
+			if !now.Before(until) {
+				delete(c.entries, key)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	if until, ok := c.entries[nonce]; ok && now.Before(until) {
+		return false
+	}
+	c.entries[nonce] = expires
+	return true
+}
@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+)
+
+const (
+	defaultOIDCScopes      = "openid email profile"
+	defaultOIDCGroupsClaim = "groups"
+	oidcDiscoveryPath      = "/.well-known/openid-configuration"
+)
+
+// OIDCConfig configura el cliente de un proveedor OpenID Connect.
+type OIDCConfig struct {
+	// IssuerURL es el emisor; su documento de descubrimiento está en IssuerURL + oidcDiscoveryPath.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL es el callback registrado en el proveedor (p. ej. .../auth/oidc/callback).
+	RedirectURL string
+	Scopes      []string
+	// GroupsClaim es el claim del ID token con los grupos del usuario; por defecto "groups".
+	GroupsClaim string
+	// Leeway es la tolerancia de reloj al validar el ID token.
+	Leeway     time.Duration
+	HTTPClient *http.Client
+}
+
+// oidcMetadata son los campos del documento de descubrimiento que usa el cliente.
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCProvider implementa ports.IdentityProvider con el flujo authorization code + PKCE.
+// El documento de descubrimiento se descarga la primera vez que se usa, así que el servicio
+// arranca aunque el proveedor no esté disponible todavía.
+type OIDCProvider struct {
+	cfg OIDCConfig
+
+	mu       sync.Mutex
+	metadata *oidcMetadata
+	keys     *KeySet
+	parser   *jwt.Parser
+}
+
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("OIDC: issuer URL, client ID and redirect URL are required")
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = strings.Fields(defaultOIDCScopes)
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = defaultOIDCGroupsClaim
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCProvider{
+		cfg: cfg,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+			jwt.WithIssuer(cfg.IssuerURL),
+			jwt.WithAudience(cfg.ClientID),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(cfg.Leeway),
+		),
+	}, nil
+}
+
+// NewOIDCProviderFromEnv construye el cliente a partir de las variables OIDC_*. Devuelve nil si
+// OIDC_ISSUER_URL no está definido.
+func NewOIDCProviderFromEnv() (*OIDCProvider, error) {
+	issuer := os.Getenv(enum.OIDCIssuerURL)
+	if issuer == "" {
+		return nil, nil
+	}
+	cfg := OIDCConfig{
+		IssuerURL:    issuer,
+		ClientID:     os.Getenv(enum.OIDCClientID),
+		ClientSecret: os.Getenv(enum.OIDCClientSecret),
+		RedirectURL:  os.Getenv(enum.OIDCRedirectURL),
+		Scopes:       strings.Fields(os.Getenv(enum.OIDCScopes)),
+		GroupsClaim:  os.Getenv(enum.OIDCGroupsClaim),
+	}
+	if leeway := os.Getenv(enum.JWTLeeway); leeway != "" {
+		d, err := time.ParseDuration(leeway)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", enum.JWTLeeway, err)
+		}
+		cfg.Leeway = d
+	}
+	return NewOIDCProvider(cfg)
+}
+
+// AuthCodeURL devuelve la URL de autorización con el state, el nonce y el code_challenge S256.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange canjea el código en el token endpoint y valida el ID token recibido.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("OIDC token response: status %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return nil, fmt.Errorf("OIDC token response: status %d: %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return nil, errors.New("OIDC token response without id_token")
+	}
+	return p.verifyIDToken(body.IDToken, nonce)
+}
+
+// verifyIDToken valida firma, emisor, audiencia, vencimiento y nonce del ID token.
+func (p *OIDCProvider) verifyIDToken(raw, nonce string) (*model.ExternalIdentity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := p.parser.ParseWithClaims(raw, claims, p.keyFunc); err != nil {
+		return nil, fmt.Errorf("OIDC ID token: %w", err)
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, errors.New("OIDC ID token: nonce mismatch")
+	}
+
+	sub, _ := claims.GetSubject()
+	if sub == "" {
+		return nil, errors.New("OIDC ID token: missing sub claim")
+	}
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+	return &model.ExternalIdentity{
+		Issuer:        p.cfg.IssuerURL,
+		Subject:       sub,
+		Email:         strings.TrimSpace(email),
+		EmailVerified: boolClaim(claims["email_verified"]),
+		Name:          name,
+		Groups:        stringList(claims[p.cfg.GroupsClaim]),
+	}, nil
+}
+
+func (p *OIDCProvider) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	return p.keys.Key(kid)
+}
+
+// discover descarga el documento de descubrimiento y el JWKS la primera vez; si falla, se
+// vuelve a intentar en la siguiente petición.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.IssuerURL+oidcDiscoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery: unexpected status %d", resp.StatusCode)
+	}
+
+	var metadata oidcMetadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("OIDC discovery: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("OIDC discovery: issuer %q does not match %q", metadata.Issuer, p.cfg.IssuerURL)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery: missing endpoints")
+	}
+
+	keys, err := NewURLKeySet(metadata.JWKSURI, p.cfg.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	p.metadata, p.keys = &metadata, keys
+	return p.metadata, nil
+}
+
+// boolClaim acepta un claim booleano o su forma de texto ("true"), que usan algunos proveedores.
+func boolClaim(claim interface{}) bool {
+	switch v := claim.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// apiKeyRepository implementa el puerto APIKeyRepository sobre la tabla api_keys.
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository crea una nueva instancia de apiKeyRepository.
+func NewAPIKeyRepository(db *sql.DB) ports.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create registra la clave con su hash.
+func (r *apiKeyRepository) Create(key *model.APIKey) error {
+	log.Debug().Str(enum.Name, key.Name).Str("prefix", key.Prefix).Msg("🟢 Creando API key")
+
+	err := r.db.QueryRow(queryVar.QueryInsertAPIKey,
+		key.Name, key.Prefix, key.Hash, pq.Array(key.Permissions), pq.Array(key.AllowedIPs),
+		key.CreatedBy, key.CreatedAt, key.ExpiresAt,
+	).Scan(&key.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al crear API key")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, key.ID).Str("prefix", key.Prefix).Msg("✅ API key creada")
+	return nil
+}
+
+// Get obtiene una clave por ID.
+func (r *apiKeyRepository) Get(id int64) (*model.APIKey, error) {
+	return r.get(queryVar.QueryGetAPIKey, id)
+}
+
+// GetByPrefix obtiene una clave por su prefijo visible.
+func (r *apiKeyRepository) GetByPrefix(prefix string) (*model.APIKey, error) {
+	return r.get(queryVar.QueryGetAPIKeyByPrefix, prefix)
+}
+
+func (r *apiKeyRepository) get(query string, arg interface{}) (*model.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAPIKeyNotFound
+		}
+		log.Error().Err(err).Msg("🔴 Error al obtener API key")
+		return nil, err
+	}
+	return key, nil
+}
+
+// List obtiene una página de claves.
+func (r *apiKeyRepository) List(offset, limit int) ([]*model.APIKey, error) {
+	rows, err := r.db.Query(queryVar.QueryListAPIKeys, offset, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error listando API keys")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.APIKey, error) {
+		return scanAPIKey(row)
+	})
+}
+
+// Rotate reemplaza el hash de la clave; devuelve ErrAPIKeyRevoked si ya estaba revocada.
+func (r *apiKeyRepository) Rotate(id int64, hash string, previousExpiresAt *time.Time, now time.Time) (*model.APIKey, error) {
+	log.Debug().Int64(enum.ID, id).Msg("🟡 Rotando API key")
+
+	key, err := scanAPIKey(r.db.QueryRow(queryVar.QueryRotateAPIKey, id, hash, previousExpiresAt, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAPIKeyRevoked
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al rotar API key")
+		return nil, err
+	}
+
+	log.Info().Int64(enum.ID, id).Msg("✅ API key rotada")
+	return key, nil
+}
+
+// Revoke revoca la clave; devuelve ErrAPIKeyRevoked si ya estaba revocada.
+func (r *apiKeyRepository) Revoke(id int64, now time.Time) error {
+	log.Debug().Int64(enum.ID, id).Msg("🟠 Revocando API key")
+
+	res, err := r.db.Exec(queryVar.QueryRevokeAPIKey, id, now)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al revocar API key")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return model.ErrAPIKeyRevoked
+	}
+
+	log.Info().Int64(enum.ID, id).Msg("✅ API key revocada")
+	return nil
+}
+
+// Touch registra el último uso de la clave.
+func (r *apiKeyRepository) Touch(id int64, now time.Time) error {
+	if _, err := r.db.Exec(queryVar.QueryTouchAPIKey, id, now); err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al registrar uso de API key")
+		return err
+	}
+	return nil
+}
+
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*model.APIKey, error) {
+	var key model.APIKey
+	var permissions []string
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, pq.Array(&permissions), pq.Array(&key.AllowedIPs),
+		&key.CreatedBy, &key.CreatedAt, &key.ExpiresAt, &key.RotatedAt, &key.LastUsedAt, &key.RevokedAt,
+		&key.Hash, &key.PreviousHash, &key.PreviousExpiresAt); err != nil {
+		return nil, err
+	}
+	key.Permissions = make([]model.Permission, len(permissions))
+	for i, perm := range permissions {
+		key.Permissions[i] = model.Permission(perm)
+	}
+	return &key, nil
+}
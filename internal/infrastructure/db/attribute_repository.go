@@ -0,0 +1,128 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// attributeDefinitionRepository implementa el puerto AttributeDefinitionRepository sobre SQL.
+type attributeDefinitionRepository struct {
+	db *sql.DB
+}
+
+// NewAttributeDefinitionRepository crea una nueva instancia de attributeDefinitionRepository.
+func NewAttributeDefinitionRepository(db *sql.DB) ports.AttributeDefinitionRepository {
+	return &attributeDefinitionRepository{db: db}
+}
+
+// GetByName obtiene una definición de atributo por su nombre.
+func (r *attributeDefinitionRepository) GetByName(name string) (*model.AttributeDefinition, error) {
+	log.Debug().Str(enum.Name, name).Msg("🟢 Buscando definición de atributo")
+
+	def, err := scanAttributeDefinition(r.db.QueryRow(queryVar.QueryGetAttributeDefinitionByName, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAttributeNotFound
+		}
+		log.Error().Err(err).Str(enum.Name, name).Msg("🔴 Error al escanear definición de atributo")
+		return nil, err
+	}
+	return def, nil
+}
+
+// Create registra una nueva definición de atributo.
+// Devuelve ErrAttributeExists si ya existe un atributo con el mismo nombre y
+// ErrRequiredAttributeWithUsers si es obligatorio y ya hay usuarios.
+func (r *attributeDefinitionRepository) Create(def *model.AttributeDefinition) (int64, error) {
+	log.Debug().Str(enum.Name, def.Name).Str("type", string(def.Type)).Msg("🟢 Registrando definición de atributo")
+
+	// enum_values es NOT NULL y pq.Array(nil) se escribe como NULL.
+	if def.EnumValues == nil {
+		def.EnumValues = []string{}
+	}
+	err := r.db.QueryRow(queryVar.QueryInsertAttributeDefinition,
+		def.Name, def.Type, def.Required, pq.Array(def.EnumValues), def.Pattern,
+	).Scan(&def.ID, &def.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Str(enum.Name, def.Name).Msg("⚠️ Atributo obligatorio con usuarios existentes")
+			return 0, model.ErrRequiredAttributeWithUsers
+		}
+		if isPgError(err, uniqueViolation) {
+			return 0, model.ErrAttributeExists
+		}
+		log.Error().Err(err).Msg("🔴 Error al registrar definición de atributo")
+		return 0, err
+	}
+
+	log.Info().Int64(enum.ID, def.ID).Str(enum.Name, def.Name).Msg("✅ Definición de atributo registrada")
+	return def.ID, nil
+}
+
+// Delete elimina una definición de atributo por nombre y, en la misma transacción, quita el
+// atributo de los usuarios que lo tienen; si no, sus próximas actualizaciones lo rechazarían por
+// desconocido.
+func (r *attributeDefinitionRepository) Delete(name string) error {
+	log.Debug().Str(enum.Name, name).Msg("🟠 Eliminando definición de atributo")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(queryVar.QueryDeleteAttributeDefinition, name)
+	if err != nil {
+		log.Error().Err(err).Str(enum.Name, name).Msg("🔴 Error al eliminar definición de atributo")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return model.ErrAttributeNotFound
+	}
+
+	res, err = tx.Exec(queryVar.QueryRemoveUserAttribute, name)
+	if err != nil {
+		log.Error().Err(err).Str(enum.Name, name).Msg("🔴 Error al quitar el atributo de los usuarios")
+		return err
+	}
+	users, _ := res.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	log.Info().Str(enum.Name, name).Int64(enum.Total, users).Msg("✅ Definición de atributo eliminada")
+	return nil
+}
+
+// List obtiene todas las definiciones de atributos ordenadas por nombre.
+func (r *attributeDefinitionRepository) List() ([]*model.AttributeDefinition, error) {
+	rows, err := r.db.Query(queryVar.QueryListAttributeDefinitions)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error listando definiciones de atributos")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.AttributeDefinition, error) {
+		return scanAttributeDefinition(row)
+	})
+}
+
+func scanAttributeDefinition(row interface{ Scan(...interface{}) error }) (*model.AttributeDefinition, error) {
+	var def model.AttributeDefinition
+	if err := row.Scan(&def.ID, &def.Name, &def.Type, &def.Required,
+		pq.Array(&def.EnumValues), &def.Pattern, &def.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Conn es la conexión de los repositorios que pueden trabajar dentro de una transacción ajena:
+// la base de datos (NewConn) o una transacción ya abierta (BeginTxConn).
+type Conn interface {
+	querier
+	Begin() (Tx, error)
+}
+
+// Tx es una transacción iniciada con Conn.Begin.
+type Tx interface {
+	querier
+	Commit() error
+	Rollback() error
+}
+
+// NewConn adapta *sql.DB a Conn.
+func NewConn(db *sql.DB) Conn {
+	return sqlConn{DB: db}
+}
+
+type sqlConn struct {
+	*sql.DB
+}
+
+func (c sqlConn) Begin() (Tx, error) {
+	return c.DB.Begin()
+}
+
+// TxConn es una Conn sobre una transacción abierta. Las transacciones que inicien los
+// repositorios se anidan con SAVEPOINT, así que nada queda confirmado hasta TxConn.Commit.
+type TxConn struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+// BeginTxConn abre una transacción en db.
+func BeginTxConn(db *sql.DB) (*TxConn, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return nil, err
+	}
+	return &TxConn{tx: tx}, nil
+}
+
+func (c *TxConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.tx.Exec(query, args...)
+}
+
+func (c *TxConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.tx.Query(query, args...)
+}
+
+func (c *TxConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.tx.QueryRow(query, args...)
+}
+
+// Begin abre un savepoint dentro de la transacción.
+func (c *TxConn) Begin() (Tx, error) {
+	c.savepoints++
+	name := fmt.Sprintf("sp_%d", c.savepoints)
+	if _, err := c.tx.Exec("SAVEPOINT " + name); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al abrir savepoint")
+		return nil, err
+	}
+	return &savepoint{conn: c, name: name}, nil
+}
+
+// Commit confirma la transacción.
+func (c *TxConn) Commit() error {
+	return c.tx.Commit()
+}
+
+// Rollback descarta la transacción.
+func (c *TxConn) Rollback() error {
+	return c.tx.Rollback()
+}
+
+// savepoint es la Tx de TxConn.Begin. Como *sql.Tx, Rollback no hace nada después de Commit,
+// así que los repositorios pueden seguir usando defer tx.Rollback().
+type savepoint struct {
+	conn *TxConn
+	name string
+	done bool
+}
+
+func (s *savepoint) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return s.conn.Exec(query, args...)
+}
+
+func (s *savepoint) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return s.conn.Query(query, args...)
+}
+
+func (s *savepoint) QueryRow(query string, args ...interface{}) *sql.Row {
+	return s.conn.QueryRow(query, args...)
+}
+
+func (s *savepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.conn.tx.Exec("RELEASE SAVEPOINT " + s.name)
+	return err
+}
+
+func (s *savepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.conn.tx.Exec("ROLLBACK TO SAVEPOINT " + s.name)
+	return err
+}
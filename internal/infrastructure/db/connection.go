@@ -10,18 +10,17 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func NewPostgresConnection() *sql.DB {
-	host := os.Getenv(enum.DBHost)
-	port := os.Getenv(enum.DBPort)
-	user := os.Getenv(enum.DBUser)
-	password := os.Getenv(enum.DBPassword)
-	dbname := os.Getenv(enum.DBName)
-	sslmode := os.Getenv(enum.SSLMode)
-
-	dsn := fmt.Sprintf(
+// PostgresDSN arma la cadena de conexión con las variables DB_*.
+func PostgresDSN() string {
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		host, port, user, password, dbname, sslmode,
+		os.Getenv(enum.DBHost), os.Getenv(enum.DBPort), os.Getenv(enum.DBUser),
+		os.Getenv(enum.DBPassword), os.Getenv(enum.DBName), os.Getenv(enum.SSLMode),
 	)
+}
+
+func NewPostgresConnection() *sql.DB {
+	dsn := PostgresDSN()
 
 	log.Debug().Str("dsn", dsn).Msg("Construyendo conexión a la DB")
 
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/rs/zerolog/log"
+)
+
+// credentialRepository implementa el puerto CredentialRepository sobre las columnas de
+// credenciales de la tabla users (ver migrations/0004_user_credentials.sql).
+type credentialRepository struct {
+	db *sql.DB
+}
+
+// NewCredentialRepository crea una nueva instancia de credentialRepository.
+func NewCredentialRepository(db *sql.DB) ports.CredentialRepository {
+	return &credentialRepository{db: db}
+}
+
+// Get obtiene las credenciales de un usuario; devuelve ErrUserNotFound si no existe.
+func (r *credentialRepository) Get(userID int64) (*model.Credentials, error) {
+	return r.get(queryVar.QueryGetCredentials, userID)
+}
+
+// GetByEmail obtiene las credenciales del usuario con ese email, sin distinguir mayúsculas.
+func (r *credentialRepository) GetByEmail(email string) (*model.Credentials, error) {
+	return r.get(queryVar.QueryGetCredentialsByEmail, email)
+}
+
+func (r *credentialRepository) get(query string, arg interface{}) (*model.Credentials, error) {
+	var creds model.Credentials
+	err := r.db.QueryRow(query, arg).Scan(
+		&creds.UserID, &creds.PasswordHash, &creds.FailedLogins, &creds.LockedUntil, &creds.PasswordChangedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("🔴 Error al obtener credenciales")
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// SetPassword guarda el hash de una contraseña nueva y desbloquea el acceso.
+func (r *credentialRepository) SetPassword(userID int64, hash string, changedAt time.Time) error {
+	log.Debug().Int64(enum.ID, userID).Msg("🟡 Actualizando contraseña")
+	return r.exec(queryVar.QuerySetPassword, userID, hash, changedAt)
+}
+
+// RecordFailedLogin incrementa de forma atómica el contador de intentos fallidos.
+func (r *credentialRepository) RecordFailedLogin(userID int64) (int, error) {
+	var attempts int
+	if err := r.db.QueryRow(queryVar.QueryRecordFailedLogin, userID).Scan(&attempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, model.ErrUserNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, userID).Msg("🔴 Error al registrar intento fallido")
+		return 0, err
+	}
+	return attempts, nil
+}
+
+// Lock bloquea el inicio de sesión hasta until.
+func (r *credentialRepository) Lock(userID int64, until time.Time) error {
+	log.Warn().Int64(enum.ID, userID).Time("until", until).Msg("🔒 Bloqueando inicio de sesión")
+	return r.exec(queryVar.QueryLockLogin, userID, until)
+}
+
+// ResetFailedLogins reinicia el contador de intentos tras un inicio de sesión correcto.
+func (r *credentialRepository) ResetFailedLogins(userID int64) error {
+	return r.exec(queryVar.QueryResetFailedLogins, userID)
+}
+
+func (r *credentialRepository) exec(query string, args ...interface{}) error {
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al actualizar credenciales")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// column describe un campo de la entidad mapeado a una columna mediante la etiqueta `db`.
+//
+// Formato de la etiqueta: `db:"nombre[,opción...]"` con las opciones:
+//   - pk: clave primaria generada por la base de datos; se devuelve con RETURNING.
+//   - readonly: la base de datos la asigna (p. ej. DEFAULT NOW()); nunca se escribe.
+//   - createonly: se escribe al insertar pero no al actualizar.
+//   - json: se serializa como JSON/JSONB.
+//   - array: se mapea a un arreglo de PostgreSQL.
+//
+// Los campos sin etiqueta o con `db:"-"` se ignoran.
+type column struct {
+	name       string
+	index      []int
+	pk         bool
+	readonly   bool
+	createonly bool
+	json       bool
+	array      bool
+
+	// field es el nombre del campo en el JSON de la entidad, con el que se eligen columnas.
+	field string
+}
+
+// entityMeta contiene las columnas de una entidad y las sentencias SQL derivadas de ellas.
+type entityMeta struct {
+	table   string
+	columns []column
+	pk      column
+
+	selectBase string
+	count      string
+	getByID    string
+	lock       string
+	insert     string
+	update     string
+	delete     string
+}
+
+// newEntityMeta lee las etiquetas `db` del tipo t y construye las sentencias CRUD para la tabla.
+func newEntityMeta(t reflect.Type, table string) (*entityMeta, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("db: %s is not a struct", t)
+	}
+
+	meta := &entityMeta{table: table}
+	hasPK := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "" || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		col := column{name: parts[0], field: jsonName(field), index: field.Index}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "pk":
+				col.pk = true
+			case "readonly":
+				col.readonly = true
+			case "createonly":
+				col.createonly = true
+			case "json":
+				col.json = true
+			case "array":
+				col.array = true
+			default:
+				return nil, fmt.Errorf("db: unknown tag option %q on %s.%s", opt, t, field.Name)
+			}
+		}
+		if col.pk {
+			if hasPK {
+				return nil, fmt.Errorf("db: %s declares more than one pk column", t)
+			}
+			hasPK = true
+			meta.pk = col
+		}
+		meta.columns = append(meta.columns, col)
+	}
+	if !hasPK {
+		return nil, fmt.Errorf("db: %s has no column tagged as pk", t)
+	}
+
+	meta.buildQueries()
+	return meta, nil
+}
+
+func (m *entityMeta) buildQueries() {
+	var all, insertCols, insertArgs, returning, sets []string
+	for _, col := range m.columns {
+		all = append(all, col.name)
+		switch {
+		case col.pk || col.readonly:
+			returning = append(returning, col.name)
+		case col.createonly:
+			insertCols = append(insertCols, col.name)
+			insertArgs = append(insertArgs, fmt.Sprintf("$%d", len(insertCols)))
+		default:
+			insertCols = append(insertCols, col.name)
+			insertArgs = append(insertArgs, fmt.Sprintf("$%d", len(insertCols)))
+			sets = append(sets, fmt.Sprintf("%s = $%d", col.name, len(sets)+1))
+		}
+	}
+
+	m.selectBase = fmt.Sprintf("SELECT %s FROM %s", strings.Join(all, ", "), m.table)
+	m.count = fmt.Sprintf("SELECT COUNT(*) FROM %s", m.table)
+	m.getByID = fmt.Sprintf("%s WHERE %s = $1", m.selectBase, m.pk.name)
+	m.lock = m.getByID + " FOR UPDATE"
+	m.insert = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
+		m.table, strings.Join(insertCols, ", "), strings.Join(insertArgs, ", "), strings.Join(returning, ", "))
+	m.update = fmt.Sprintf("UPDATE %s SET %s WHERE %s = $%d",
+		m.table, strings.Join(sets, ", "), m.pk.name, len(sets)+1)
+	m.delete = fmt.Sprintf("DELETE FROM %s WHERE %s = $1", m.table, m.pk.name)
+}
+
+// project devuelve el SELECT y las columnas de los campos indicados por su nombre JSON, en el
+// orden de la entidad. Sin campos devuelve todas las columnas.
+func (m *entityMeta) project(fields []string) (string, []column, error) {
+	if len(fields) == 0 {
+		return m.selectBase, m.columns, nil
+	}
+
+	selected := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !m.hasField(field) {
+			return "", nil, fmt.Errorf("%w: %q", model.ErrUnknownField, field)
+		}
+		selected[field] = true
+	}
+
+	var cols []column
+	var names []string
+	for _, col := range m.columns {
+		if selected[col.field] {
+			cols = append(cols, col)
+			names = append(names, col.name)
+		}
+	}
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(names, ", "), m.table), cols, nil
+}
+
+func (m *entityMeta) hasField(field string) bool {
+	for _, col := range m.columns {
+		if col.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonName devuelve el nombre del campo en JSON, o el nombre Go si no tiene etiqueta `json`.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// has indica si la entidad tiene una columna con ese nombre.
+func (m *entityMeta) has(name string) (column, bool) {
+	for _, col := range m.columns {
+		if col.name == name {
+			return col, true
+		}
+	}
+	return column{}, false
+}
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Códigos de error de PostgreSQL que los repositorios traducen a errores de dominio.
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// isPgError indica si err es un error de PostgreSQL con el código indicado.
+func isPgError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
@@ -0,0 +1,205 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// groupRepository implementa el puerto GroupRepository con una fuente de datos SQL.
+// Las operaciones CRUD se delegan en el repositorio genérico a partir de las etiquetas `db` de model.Group.
+type groupRepository struct {
+	*SQLRepository[model.Group, int64]
+	db Conn
+}
+
+// NewGroupRepository crea una nueva instancia de groupRepository.
+func NewGroupRepository(db Conn) ports.GroupRepository {
+	return &groupRepository{
+		SQLRepository: newSQLRepository[model.Group, int64](db, SQLOptions{
+			Table:    "groups",
+			NotFound: model.ErrGroupNotFound,
+			Conflict: model.ErrGroupExists,
+		}),
+		db: db,
+	}
+}
+
+// AddMembers agrega usuarios a un grupo en una transacción; las membresías existentes se ignoran.
+// Devuelve ErrUserNotFound si alguno de los usuarios no existe.
+func (r *groupRepository) AddMembers(groupID int64, userIDs []int64) error {
+	log.Debug().Int64(enum.ID, groupID).Ints64("userIDs", userIDs).Msg("🟢 Agregando miembros al grupo")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.addMembers(tx, groupID, userIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, groupID).Int(enum.Total, len(userIDs)).Msg("✅ Miembros agregados")
+	return nil
+}
+
+// CreateWithMembers crea el grupo con sus miembros iniciales en una transacción.
+func (r *groupRepository) CreateWithMembers(group *model.Group, userIDs []int64) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err := r.create(tx, group)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.addMembers(tx, id, userIDs); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return 0, err
+	}
+	return id, nil
+}
+
+// ReplaceWithMembers actualiza el grupo y deja como miembros exactamente userIDs en una
+// transacción.
+func (r *groupRepository) ReplaceWithMembers(group *model.Group, userIDs []int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	if userIDs == nil {
+		// pq.Array(nil) es NULL y "<> ALL(NULL)" no quitaría a nadie.
+		userIDs = []int64{}
+	}
+	if err := r.update(tx, group); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(queryVar.QueryDeleteOtherGroupMembers, group.ID, pq.Array(userIDs)); err != nil {
+		log.Error().Err(err).Int64(enum.ID, group.ID).Msg("🔴 Error al quitar miembros")
+		return err
+	}
+	if err := r.addMembers(tx, group.ID, userIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, group.ID).Int(enum.Total, len(userIDs)).Msg("✅ Miembros del grupo reemplazados")
+	return nil
+}
+
+func (r *groupRepository) addMembers(q querier, groupID int64, userIDs []int64) error {
+	for _, userID := range userIDs {
+		if _, err := q.Exec(queryVar.QueryInsertGroupMember, groupID, userID); err != nil {
+			if isPgError(err, foreignKeyViolation) {
+				log.Warn().Int64("userID", userID).Msg("⚠️ Usuario o grupo inexistente")
+				return model.ErrUserNotFound
+			}
+			log.Error().Err(err).Int64(enum.ID, groupID).Msg("🔴 Error al agregar miembro")
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveMembers quita usuarios de un grupo.
+func (r *groupRepository) RemoveMembers(groupID int64, userIDs []int64) error {
+	log.Debug().Int64(enum.ID, groupID).Ints64("userIDs", userIDs).Msg("🟠 Quitando miembros del grupo")
+
+	if _, err := r.db.Exec(queryVar.QueryDeleteGroupMembers, groupID, pq.Array(userIDs)); err != nil {
+		log.Error().Err(err).Int64(enum.ID, groupID).Msg("🔴 Error al quitar miembros")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, groupID).Int(enum.Total, len(userIDs)).Msg("✅ Miembros quitados")
+	return nil
+}
+
+// ListMembers obtiene los usuarios que pertenecen a un grupo.
+func (r *groupRepository) ListMembers(groupID int64) ([]*model.User, error) {
+	rows, err := r.db.Query(queryVar.QueryListGroupMembers, groupID)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, groupID).Msg("🔴 Error listando miembros del grupo")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.User, error) {
+		var user model.User
+		if err := row.Scan(&user.ID, &user.PublicID, &user.Name, &user.Email, &user.EmailVerified, &user.Status, (*dbutils.JSONMap)(&user.Attributes)); err != nil {
+			log.Error().Err(err).Msg("🔴 Error al escanear miembro del grupo")
+			return nil, err
+		}
+		return &user, nil
+	})
+}
+
+// ListByUser obtiene los grupos a los que pertenece un usuario.
+func (r *groupRepository) ListByUser(userID int64) ([]*model.Group, error) {
+	rows, err := r.db.Query(queryVar.QueryListGroupsByUser, userID)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, userID).Msg("🔴 Error listando grupos del usuario")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.Group, error) {
+		return r.scan(row)
+	})
+}
+
+// ListByUsers obtiene los grupos de varios usuarios agrupados por usuario.
+func (r *groupRepository) ListByUsers(userIDs []int64) (map[int64][]*model.Group, error) {
+	rows, err := r.db.Query(queryVar.QueryListGroupsByUsers, pq.Array(userIDs))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Total, len(userIDs)).Msg("🔴 Error listando grupos de usuarios")
+		return nil, err
+	}
+
+	type membership struct {
+		userID int64
+		group  *model.Group
+	}
+	memberships, err := dbutils.ScanRows(rows, func(row *sql.Rows) (*membership, error) {
+		m := membership{group: new(model.Group)}
+		g := m.group
+		if err := row.Scan(&m.userID, &g.ID, &g.Name, &g.Description, pq.Array(&g.Roles), &g.CreatedAt); err != nil {
+			log.Error().Err(err).Msg("🔴 Error al escanear grupo del usuario")
+			return nil, err
+		}
+		return &m, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[int64][]*model.Group, len(userIDs))
+	for _, m := range memberships {
+		groups[m.userID] = append(groups[m.userID], m.group)
+	}
+	return groups, nil
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/rs/zerolog/log"
+)
+
+// identityRepository implementa el puerto IdentityRepository sobre la tabla user_identities.
+type identityRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository crea una nueva instancia de identityRepository.
+func NewIdentityRepository(db *sql.DB) ports.IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+// GetBySubject obtiene el vínculo de una identidad externa.
+func (r *identityRepository) GetBySubject(issuer, subject string) (*model.UserIdentity, error) {
+	identity, err := scanIdentity(r.db.QueryRow(queryVar.QueryGetIdentity, issuer, subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrIdentityNotFound
+		}
+		log.Error().Err(err).Str("issuer", issuer).Msg("🔴 Error al obtener identidad externa")
+		return nil, err
+	}
+	return identity, nil
+}
+
+// Link vincula la identidad con un usuario existente; devuelve ErrIdentityLinked si ya lo estaba.
+func (r *identityRepository) Link(identity *model.UserIdentity) error {
+	log.Debug().Int64("userID", identity.UserID).Str("issuer", identity.Issuer).Msg("🟢 Vinculando identidad externa")
+
+	if err := insertIdentity(r.db, identity); err != nil {
+		return err
+	}
+
+	log.Info().Int64("userID", identity.UserID).Str("issuer", identity.Issuer).Msg("✅ Identidad externa vinculada")
+	return nil
+}
+
+// Provision crea el usuario y su vínculo en una misma transacción.
+func (r *identityRepository) Provision(user *model.User, identity *model.UserIdentity) error {
+	log.Debug().Str("issuer", identity.Issuer).Msg("🟢 Creando usuario desde identidad externa")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	if user.PublicID, err = newPublicID(); err != nil {
+		return err
+	}
+	if err := tx.QueryRow(queryVar.QueryInsertProvisionedUser, user.Name, user.Email, user.PublicID).Scan(&user.ID); err != nil {
+		if isPgError(err, uniqueViolation) {
+			return model.ErrEmailTaken
+		}
+		log.Error().Err(err).Msg("🔴 Error al crear usuario")
+		return err
+	}
+	user.Status = model.UserStatusActive
+	user.EmailVerified = true
+	if err := recordEvent(tx, model.UserCreated{After: user}, "oidc:"+identity.Issuer); err != nil {
+		return err
+	}
+
+	identity.UserID = user.ID
+	if err := insertIdentity(tx, identity); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, user.ID).Str("issuer", identity.Issuer).Msg("✅ Usuario creado desde identidad externa")
+	return nil
+}
+
+// Touch registra un nuevo inicio de sesión con la identidad.
+func (r *identityRepository) Touch(issuer, subject, email string, now time.Time) error {
+	if _, err := r.db.Exec(queryVar.QueryTouchIdentity, issuer, subject, email, now); err != nil {
+		log.Error().Err(err).Str("issuer", issuer).Msg("🔴 Error al registrar inicio de sesión externo")
+		return err
+	}
+	return nil
+}
+
+// ListByUser obtiene las identidades externas vinculadas a un usuario.
+func (r *identityRepository) ListByUser(userID int64) ([]*model.UserIdentity, error) {
+	rows, err := r.db.Query(queryVar.QueryListUserIdentities, userID)
+	if err != nil {
+		log.Error().Err(err).Int64("userID", userID).Msg("🔴 Error listando identidades externas")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.UserIdentity, error) {
+		return scanIdentity(row)
+	})
+}
+
+func insertIdentity(exec interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}, identity *model.UserIdentity) error {
+	_, err := exec.Exec(queryVar.QueryInsertIdentity,
+		identity.UserID, identity.Issuer, identity.Subject, identity.Email, identity.CreatedAt,
+	)
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return model.ErrIdentityLinked
+		}
+		log.Error().Err(err).Msg("🔴 Error al vincular identidad externa")
+		return err
+	}
+	identity.LastLoginAt = identity.CreatedAt
+	return nil
+}
+
+func scanIdentity(row interface{ Scan(...interface{}) error }) (*model.UserIdentity, error) {
+	var i model.UserIdentity
+	if err := row.Scan(&i.UserID, &i.Issuer, &i.Subject, &i.Email, &i.CreatedAt, &i.LastLoginAt); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
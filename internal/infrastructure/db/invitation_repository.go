@@ -0,0 +1,298 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// invitationRepository implementa el puerto InvitationRepository sobre la tabla invitations.
+type invitationRepository struct {
+	db *sql.DB
+}
+
+// NewInvitationRepository crea una nueva instancia de invitationRepository.
+func NewInvitationRepository(db *sql.DB) ports.InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+// Create crea el usuario en estado invited y la invitación en una misma transacción.
+// Devuelve ErrEmailTaken si ya hay un usuario con ese email, invitado o no, y
+// ErrInvitationExists si ya hay una invitación pendiente para él.
+func (r *invitationRepository) Create(invitation *model.Invitation, user *model.User, tokenHash string) error {
+	log.Debug().Str("email", invitation.Email).Msg("🟢 Creando invitación")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	if user.PublicID, err = newPublicID(); err != nil {
+		return err
+	}
+	err = tx.QueryRow(queryVar.QueryInsertInvitedUser, user.Name, user.Email, dbutils.JSONMap(user.Attributes), user.PublicID).Scan(&user.ID)
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return model.ErrEmailTaken
+		}
+		log.Error().Err(err).Msg("🔴 Error al crear usuario invitado")
+		return err
+	}
+	user.Status = model.UserStatusInvited
+	if err := recordEvent(tx, model.UserCreated{After: user}, invitation.InvitedBy); err != nil {
+		return err
+	}
+
+	created, err := scanInvitation(tx.QueryRow(queryVar.QueryInsertInvitation,
+		invitation.Email, user.ID, tokenHash, invitation.InvitedBy, invitation.CreatedAt, invitation.ExpiresAt,
+	))
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return model.ErrInvitationExists
+		}
+		log.Error().Err(err).Msg("🔴 Error al crear invitación")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	*invitation = *created
+	log.Info().Int64(enum.ID, invitation.ID).Int64("userID", user.ID).Msg("✅ Invitación creada")
+	return nil
+}
+
+// Get obtiene una invitación por ID.
+func (r *invitationRepository) Get(id int64) (*model.Invitation, error) {
+	invitation, err := scanInvitation(r.db.QueryRow(queryVar.QueryGetInvitation, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvitationNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al obtener invitación")
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// List obtiene una página de invitaciones, opcionalmente filtradas por estado.
+func (r *invitationRepository) List(offset, limit int, status model.InvitationStatus) ([]*model.Invitation, error) {
+	rows, err := r.db.Query(queryVar.QueryListInvitations, offset, limit, status)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error listando invitaciones")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.Invitation, error) {
+		return scanInvitation(row)
+	})
+}
+
+// Renew reemplaza el token de una invitación pendiente y extiende su vencimiento.
+func (r *invitationRepository) Renew(id int64, tokenHash string, expiresAt, now time.Time) (*model.Invitation, error) {
+	log.Debug().Int64(enum.ID, id).Msg("🟡 Renovando invitación")
+
+	invitation, err := scanInvitation(r.db.QueryRow(queryVar.QueryRenewInvitation, id, tokenHash, expiresAt, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvitationNotPending
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al renovar invitación")
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Accept canjea la invitación en una misma transacción: la marca como aceptada con una sola
+// sentencia, así dos aceptaciones simultáneas no pueden usar el mismo token; fija el nombre y la
+// contraseña del invitado, verifica su email, lo activa, registra el cambio de estado y guarda
+// UserUpdated. El actor es el propio invitado.
+func (r *invitationRepository) Accept(tokenHash, name, passwordHash string, now time.Time) (*model.Invitation, *model.User, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	invitation, err := scanInvitation(tx.QueryRow(queryVar.QueryAcceptInvitation, tokenHash, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, model.ErrInvalidInvitation
+		}
+		log.Error().Err(err).Msg("🔴 Error al aceptar invitación")
+		return nil, nil, err
+	}
+
+	before, err := scanInvitedUser(tx.QueryRow(queryVar.QueryLockInvitedUser, invitation.UserID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, model.ErrInvalidInvitation
+		}
+		log.Error().Err(err).Int64("userID", invitation.UserID).Msg("🔴 Error al obtener usuario invitado")
+		return nil, nil, err
+	}
+	after, err := scanInvitedUser(tx.QueryRow(queryVar.QueryActivateInvitedUser, invitation.UserID, name, passwordHash, now))
+	if err != nil {
+		log.Error().Err(err).Int64("userID", invitation.UserID).Msg("🔴 Error al activar usuario invitado")
+		return nil, nil, err
+	}
+
+	actor := strconv.FormatInt(after.ID, 10)
+	_, err = tx.Exec(queryVar.QueryInsertUserStatusChange,
+		after.ID, model.UserStatusInvited, model.UserStatusActive, fmt.Sprintf("invitation %d accepted", invitation.ID), actor, now,
+	)
+	if err != nil {
+		log.Error().Err(err).Int64("userID", after.ID).Msg("🔴 Error al registrar cambio de estado")
+		return nil, nil, err
+	}
+	if err := recordEvent(tx, model.UserUpdated{Before: before, After: after}, actor); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return nil, nil, err
+	}
+
+	log.Info().Int64(enum.ID, invitation.ID).Int64("userID", after.ID).Msg("✅ Invitación aceptada")
+	return invitation, after, nil
+}
+
+// Revoke revoca la invitación y elimina al usuario invitado en una misma transacción.
+func (r *invitationRepository) Revoke(id int64, actor string, now time.Time) (*model.Invitation, error) {
+	log.Debug().Int64(enum.ID, id).Msg("🟠 Revocando invitación")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	invitation, err := scanInvitation(tx.QueryRow(queryVar.QueryRevokeInvitation, id, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvitationNotPending
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al revocar invitación")
+		return nil, err
+	}
+
+	if err := deleteInvitedUsers(tx, []int64{invitation.UserID}, actor); err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al eliminar usuario invitado")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return nil, err
+	}
+
+	// El usuario ya no existe: ON DELETE SET NULL dejó user_id vacío.
+	invitation.UserID = 0
+	log.Info().Int64(enum.ID, id).Msg("✅ Invitación revocada")
+	return invitation, nil
+}
+
+// ExpirePending vence las invitaciones pendientes cuyo plazo pasó y elimina a sus usuarios
+// invitados en una misma transacción.
+func (r *invitationRepository) ExpirePending(now time.Time) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(queryVar.QueryExpireInvitations, now)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al vencer invitaciones")
+		return 0, err
+	}
+	userIDs, err := dbutils.ScanRows(rows, func(row *sql.Rows) (*int64, error) {
+		var userID sql.NullInt64
+		if err := row.Scan(&userID); err != nil {
+			return nil, err
+		}
+		return &userID.Int64, nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al escanear invitaciones vencidas")
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, *id)
+	}
+	if err := deleteInvitedUsers(tx, ids, model.SystemPrincipal.Subject); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al eliminar usuarios invitados")
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return 0, err
+	}
+
+	log.Info().Int(enum.Total, len(ids)).Msg("✅ Invitaciones vencidas")
+	return len(ids), nil
+}
+
+func scanInvitation(row interface{ Scan(...interface{}) error }) (*model.Invitation, error) {
+	var invitation model.Invitation
+	var userID sql.NullInt64
+	if err := row.Scan(&invitation.ID, &invitation.Email, &userID, &invitation.Status, &invitation.InvitedBy,
+		&invitation.SentCount, &invitation.CreatedAt, &invitation.LastSentAt, &invitation.ExpiresAt,
+		&invitation.AcceptedAt, &invitation.RevokedAt); err != nil {
+		return nil, err
+	}
+	invitation.UserID = userID.Int64
+	return &invitation, nil
+}
+
+func scanInvitedUser(row interface{ Scan(...interface{}) error }) (*model.User, error) {
+	var u model.User
+	if err := row.Scan(&u.ID, &u.PublicID, &u.Name, &u.Email, &u.EmailVerified, &u.Status, &dbutils.JSON{V: &u.Attributes}); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// deleteInvitedUsers elimina los usuarios que siguen invitados y guarda UserDeleted por cada uno.
+func deleteInvitedUsers(tx *sql.Tx, ids []int64, actor string) error {
+	rows, err := tx.Query(queryVar.QueryDeleteInvitedUsers, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	users, err := dbutils.ScanRows(rows, func(row *sql.Rows) (*model.User, error) {
+		return scanInvitedUser(row)
+	})
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if err := recordEvent(tx, model.UserDeleted{Before: u}, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	listenerMinReconnect = time.Second
+	listenerMaxReconnect = time.Minute
+	// listenerPingInterval comprueba la conexión cuando no llegan avisos.
+	listenerPingInterval = 90 * time.Second
+)
+
+// outboxListener implementa el puerto EventNotifier con LISTEN sobre el canal de outbox_events.
+// Usa una conexión propia, fuera del pool de *sql.DB.
+type outboxListener struct {
+	dsn string
+}
+
+// NewOutboxListener crea una nueva instancia de outboxListener.
+func NewOutboxListener(dsn string) ports.EventNotifier {
+	return &outboxListener{dsn: dsn}
+}
+
+// Listen abre la conexión y entrega los IDs avisados. pq reconecta sola; después de reconectar
+// se entrega un 0 porque los avisos enviados mientras tanto se perdieron.
+func (l *outboxListener) Listen(ctx context.Context) (<-chan int64, error) {
+	listener := pq.NewListener(l.dsn, listenerMinReconnect, listenerMaxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			switch event {
+			case pq.ListenerEventDisconnected:
+				log.Warn().Err(err).Msg("⚠️ Conexión LISTEN perdida")
+			case pq.ListenerEventReconnected:
+				log.Info().Msg("✅ Conexión LISTEN restablecida")
+			case pq.ListenerEventConnectionAttemptFailed:
+				log.Error().Err(err).Msg("🔴 Error al reconectar LISTEN")
+			}
+		})
+	if err := listener.Listen(queryVar.OutboxChannel); err != nil {
+		listener.Close()
+		log.Error().Err(err).Msg("🔴 Error al escuchar el canal del outbox")
+		return nil, err
+	}
+
+	ids := make(chan int64, 64)
+	go func() {
+		defer close(ids)
+		defer listener.Close()
+
+		ping := time.NewTicker(listenerPingInterval)
+		defer ping.Stop()
+		for {
+			var id int64
+			select {
+			case <-ctx.Done():
+				return
+			case <-ping.C:
+				go listener.Ping()
+				continue
+			case n := <-listener.Notify:
+				// pq envía nil después de reconectar.
+				if n != nil {
+					parsed, err := strconv.ParseInt(n.Extra, 10, 64)
+					if err != nil {
+						log.Warn().Str("payload", n.Extra).Msg("⚠️ Aviso del outbox inválido")
+						continue
+					}
+					id = parsed
+				}
+			}
+
+			select {
+			case ids <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ids, nil
+}
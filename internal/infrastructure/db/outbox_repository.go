@@ -0,0 +1,163 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/rs/zerolog/log"
+)
+
+// outboxRepository implementa el puerto OutboxRepository sobre la tabla outbox_events.
+type outboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository crea una nueva instancia de outboxRepository.
+func NewOutboxRepository(db *sql.DB) ports.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// recordEvent guarda el evento en el outbox con q, que debe ser la transacción del cambio.
+func recordEvent(q querier, e model.DomainEvent, actor string) error {
+	event, err := model.NewEvent(e, actor, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	err = q.QueryRow(queryVar.QueryInsertOutboxEvent,
+		event.Type, event.AggregateType, event.AggregateID, event.Actor, []byte(event.Payload), event.OccurredAt,
+	).Scan(&event.ID)
+	if err != nil {
+		log.Error().Err(err).Str("type", event.Type).Msg("🔴 Error al guardar evento en el outbox")
+		return err
+	}
+	return nil
+}
+
+// Claim reserva eventos pendientes bajo un lock de transacción compartido por todas las instancias.
+func (r *outboxRepository) Claim(limit int, now, leaseUntil time.Time) ([]*model.Event, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(queryVar.QueryLockOutbox); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al bloquear el outbox")
+		return nil, err
+	}
+
+	rows, err := tx.Query(queryVar.QueryClaimOutboxEvents, now, leaseUntil, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al reservar eventos del outbox")
+		return nil, err
+	}
+	events, err := dbutils.ScanRows(rows, func(row *sql.Rows) (*model.Event, error) {
+		return scanEvent(row)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al escanear eventos del outbox")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return nil, err
+	}
+
+	// RETURNING no garantiza el orden.
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+// MarkPublished marca el evento como publicado.
+func (r *outboxRepository) MarkPublished(id int64, at time.Time) error {
+	if _, err := r.db.Exec(queryVar.QueryMarkOutboxPublished, id, at); err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al marcar evento como publicado")
+		return err
+	}
+	return nil
+}
+
+// MarkFailed registra el fallo y programa el reintento.
+func (r *outboxRepository) MarkFailed(id int64, reason string, retryAt time.Time) error {
+	if _, err := r.db.Exec(queryVar.QueryMarkOutboxFailed, id, reason, retryAt); err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al registrar fallo de publicación")
+		return err
+	}
+	return nil
+}
+
+// Release libera la reserva del evento.
+func (r *outboxRepository) Release(id int64) error {
+	if _, err := r.db.Exec(queryVar.QueryReleaseOutboxEvent, id); err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al liberar evento del outbox")
+		return err
+	}
+	return nil
+}
+
+// PurgePublished elimina los eventos publicados antes de before.
+func (r *outboxRepository) PurgePublished(before time.Time) (int, error) {
+	res, err := r.db.Exec(queryVar.QueryPurgeOutboxEvents, before)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al purgar eventos publicados")
+		return 0, err
+	}
+	purged, _ := res.RowsAffected()
+	return int(purged), nil
+}
+
+// Get obtiene un evento del outbox.
+func (r *outboxRepository) Get(id int64) (*model.Event, error) {
+	event, err := scanEvent(r.db.QueryRow(queryVar.QueryGetOutboxEvent, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrEventNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al obtener evento del outbox")
+		return nil, err
+	}
+	return event, nil
+}
+
+// ListAfter obtiene los eventos del agregado posteriores a afterID registrados antes de before.
+func (r *outboxRepository) ListAfter(aggregateType string, afterID int64, before time.Time, limit int) ([]*model.Event, error) {
+	rows, err := r.db.Query(queryVar.QueryListOutboxEventsAfter, aggregateType, afterID, before, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error listando eventos del outbox")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.Event, error) {
+		return scanEvent(row)
+	})
+}
+
+// LastID obtiene el mayor ID de los eventos del agregado registrados antes de before, o 0.
+func (r *outboxRepository) LastID(aggregateType string, before time.Time) (int64, error) {
+	var id int64
+	if err := r.db.QueryRow(queryVar.QueryLastOutboxEventID, aggregateType, before).Scan(&id); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al obtener el último evento del outbox")
+		return 0, err
+	}
+	return id, nil
+}
+
+func scanEvent(row interface{ Scan(...interface{}) error }) (*model.Event, error) {
+	var e model.Event
+	var payload []byte
+	if err := row.Scan(&e.ID, &e.Type, &e.AggregateType, &e.AggregateID, &e.Actor,
+		&payload, &e.OccurredAt, &e.Attempts); err != nil {
+		return nil, err
+	}
+	e.Payload = payload
+	return &e, nil
+}
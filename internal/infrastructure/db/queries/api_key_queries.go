@@ -0,0 +1,52 @@
+package db
+
+const (
+	queryAPIKeyColumns = `id, name, prefix, permissions, allowed_ips, created_by, created_at, expires_at,
+		rotated_at, last_used_at, revoked_at, key_hash, COALESCE(previous_hash, ''), previous_expires_at`
+
+	QueryInsertAPIKey = `
+		INSERT INTO api_keys (name, prefix, key_hash, permissions, allowed_ips, created_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	QueryGetAPIKey = `
+		SELECT ` + queryAPIKeyColumns + `
+		FROM api_keys
+		WHERE id = $1
+	`
+
+	QueryGetAPIKeyByPrefix = `
+		SELECT ` + queryAPIKeyColumns + `
+		FROM api_keys
+		WHERE prefix = $1
+	`
+
+	QueryListAPIKeys = `
+		SELECT ` + queryAPIKeyColumns + `
+		FROM api_keys
+		ORDER BY created_at DESC, id DESC
+		OFFSET $1 LIMIT $2
+	`
+
+	QueryRotateAPIKey = `
+		UPDATE api_keys
+		SET previous_hash = CASE WHEN $3::timestamptz IS NULL THEN NULL ELSE key_hash END,
+		    previous_expires_at = $3, key_hash = $2, rotated_at = $4
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING ` + queryAPIKeyColumns + `
+	`
+
+	QueryRevokeAPIKey = `
+		UPDATE api_keys
+		SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	// Touch escribe como mucho una vez por minuto para no actualizar la fila en cada petición.
+	QueryTouchAPIKey = `
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute')
+	`
+)
@@ -0,0 +1,34 @@
+package db
+
+const (
+	QueryGetAttributeDefinitionByName = `
+		SELECT id, name, type, required, enum_values, pattern, created_at
+		FROM attribute_definitions
+		WHERE name = $1
+	`
+
+	// Un atributo obligatorio sólo se inserta si no hay usuarios, que no lo tendrían.
+	QueryInsertAttributeDefinition = `
+		INSERT INTO attribute_definitions (name, type, required, enum_values, pattern)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT $3 OR NOT EXISTS (SELECT 1 FROM users)
+		RETURNING id, created_at
+	`
+
+	QueryDeleteAttributeDefinition = `
+		DELETE FROM attribute_definitions
+		WHERE name = $1
+	`
+
+	QueryRemoveUserAttribute = `
+		UPDATE users
+		SET attributes = attributes - $1
+		WHERE attributes ? $1
+	`
+
+	QueryListAttributeDefinitions = `
+		SELECT id, name, type, required, enum_values, pattern, created_at
+		FROM attribute_definitions
+		ORDER BY name
+	`
+)
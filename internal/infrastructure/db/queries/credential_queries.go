@@ -0,0 +1,48 @@
+package db
+
+const (
+	QueryGetCredentials = `
+		SELECT id, COALESCE(password_hash, ''), failed_logins, locked_until, password_changed_at
+		FROM users
+		WHERE id = $1
+	`
+
+	QueryGetCredentialsByEmail = `
+		SELECT id, COALESCE(password_hash, ''), failed_logins, locked_until, password_changed_at
+		FROM users
+		WHERE LOWER(email) = LOWER($1)
+	`
+
+	QuerySetPassword = `
+		UPDATE users
+		SET password_hash = $2, password_changed_at = $3, failed_logins = 0, locked_until = NULL
+		WHERE id = $1
+	`
+
+	// QueryResetPassword es QuerySetPassword sólo si el email del usuario sigue siendo $4, el
+	// del token de restablecimiento.
+	QueryResetPassword = `
+		UPDATE users
+		SET password_hash = $2, password_changed_at = $3, failed_logins = 0, locked_until = NULL
+		WHERE id = $1 AND LOWER(email) = LOWER($4)
+	`
+
+	QueryRecordFailedLogin = `
+		UPDATE users
+		SET failed_logins = failed_logins + 1
+		WHERE id = $1
+		RETURNING failed_logins
+	`
+
+	QueryLockLogin = `
+		UPDATE users
+		SET locked_until = $2, failed_logins = 0
+		WHERE id = $1
+	`
+
+	QueryResetFailedLogins = `
+		UPDATE users
+		SET failed_logins = 0, locked_until = NULL
+		WHERE id = $1
+	`
+)
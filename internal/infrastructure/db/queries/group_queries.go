@@ -0,0 +1,43 @@
+package db
+
+const (
+	QueryInsertGroupMember = `
+		INSERT INTO group_members (group_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (group_id, user_id) DO NOTHING
+	`
+
+	QueryDeleteGroupMembers = `
+		DELETE FROM group_members
+		WHERE group_id = $1 AND user_id = ANY($2)
+	`
+
+	QueryDeleteOtherGroupMembers = `
+		DELETE FROM group_members
+		WHERE group_id = $1 AND user_id <> ALL($2)
+	`
+
+	QueryListGroupMembers = `
+		SELECT u.id, u.public_id, u.name, u.email, u.email_verified, u.status, u.attributes
+		FROM users u
+		JOIN group_members gm ON gm.user_id = u.id
+		WHERE gm.group_id = $1
+		ORDER BY u.id
+	`
+
+	QueryListGroupsByUser = `
+		SELECT g.id, g.name, g.description, g.roles, g.created_at
+		FROM groups g
+		JOIN group_members gm ON gm.group_id = g.id
+		WHERE gm.user_id = $1
+		ORDER BY g.name
+	`
+
+	QueryListGroupsByUsers = `
+		SELECT gm.user_id, g.id, g.name, g.description, g.roles, g.created_at
+		FROM groups g
+		JOIN group_members gm ON gm.group_id = g.id
+		WHERE gm.user_id = ANY($1)
+		ORDER BY g.name
+	`
+)
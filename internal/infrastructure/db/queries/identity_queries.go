@@ -0,0 +1,35 @@
+package db
+
+const (
+	queryIdentityColumns = `user_id, issuer, subject, email, created_at, last_login_at`
+
+	QueryGetIdentity = `
+		SELECT ` + queryIdentityColumns + `
+		FROM user_identities
+		WHERE issuer = $1 AND subject = $2
+	`
+
+	QueryInsertIdentity = `
+		INSERT INTO user_identities (user_id, issuer, subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+	`
+
+	QueryInsertProvisionedUser = `
+		INSERT INTO users (name, email, status, email_verified, public_id)
+		VALUES ($1, $2, 'active', TRUE, $3)
+		RETURNING id
+	`
+
+	QueryTouchIdentity = `
+		UPDATE user_identities
+		SET email = $3, last_login_at = $4
+		WHERE issuer = $1 AND subject = $2
+	`
+
+	QueryListUserIdentities = `
+		SELECT ` + queryIdentityColumns + `
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at, issuer
+	`
+)
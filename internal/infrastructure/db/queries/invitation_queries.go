@@ -0,0 +1,84 @@
+package db
+
+const (
+	queryInvitationColumns = `id, email, user_id, status, invited_by, sent_count, created_at, last_sent_at, expires_at, accepted_at, revoked_at`
+
+	QueryInsertInvitedUser = `
+		INSERT INTO users (name, email, status, attributes, public_id)
+		VALUES ($1, $2, 'invited', $3, $4)
+		RETURNING id
+	`
+
+	QueryInsertInvitation = `
+		INSERT INTO invitations (email, user_id, token_hash, invited_by, created_at, last_sent_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $5, $6)
+		RETURNING ` + queryInvitationColumns + `
+	`
+
+	QueryGetInvitation = `
+		SELECT ` + queryInvitationColumns + `
+		FROM invitations
+		WHERE id = $1
+	`
+
+	QueryListInvitations = `
+		SELECT ` + queryInvitationColumns + `
+		FROM invitations
+		WHERE $3::text = '' OR status = $3
+		ORDER BY created_at DESC, id DESC
+		OFFSET $1 LIMIT $2
+	`
+
+	QueryRenewInvitation = `
+		UPDATE invitations
+		SET token_hash = $2, expires_at = $3, last_sent_at = $4, sent_count = sent_count + 1
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + queryInvitationColumns + `
+	`
+
+	QueryAcceptInvitation = `
+		UPDATE invitations
+		SET status = 'accepted', accepted_at = $2
+		WHERE token_hash = $1 AND status = 'pending' AND expires_at > $2 AND user_id IS NOT NULL
+		RETURNING ` + queryInvitationColumns + `
+	`
+
+	queryInvitedUserColumns = `id, public_id, name, email, email_verified, status, attributes`
+
+	QueryLockInvitedUser = `
+		SELECT ` + queryInvitedUserColumns + `
+		FROM users
+		WHERE id = $1 AND status = 'invited'
+		FOR UPDATE
+	`
+
+	// La contraseña se fija igual que en QuerySetPassword.
+	QueryActivateInvitedUser = `
+		UPDATE users
+		SET name = $2, password_hash = $3, password_changed_at = $4, failed_logins = 0, locked_until = NULL,
+			email_verified = TRUE, status = 'active'
+		WHERE id = $1 AND status = 'invited'
+		RETURNING ` + queryInvitedUserColumns + `
+	`
+
+	QueryRevokeInvitation = `
+		UPDATE invitations
+		SET status = 'revoked', revoked_at = $2
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + queryInvitationColumns + `
+	`
+
+	QueryExpireInvitations = `
+		UPDATE invitations
+		SET status = 'expired'
+		WHERE status = 'pending' AND expires_at <= $1
+		RETURNING user_id
+	`
+
+	// Sólo se eliminan usuarios que siguen en estado invited.
+	QueryDeleteInvitedUsers = `
+		DELETE FROM users
+		WHERE id = ANY($1) AND status = 'invited'
+		RETURNING ` + queryInvitedUserColumns + `
+	`
+)
@@ -0,0 +1,86 @@
+package db
+
+// OutboxChannel es el canal de NOTIFY por el que el trigger de outbox_events avisa del ID de
+// cada evento nuevo (migrations/0012_outbox_notify.sql).
+const OutboxChannel = "outbox_events"
+
+const (
+	queryOutboxColumns = `id, event_type, aggregate_type, aggregate_id, actor, payload, occurred_at, attempts`
+
+	QueryInsertOutboxEvent = `
+		INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, actor, payload, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	// El lock de transacción serializa las reservas entre instancias: sin él, dos relays
+	// podrían tomar eventos consecutivos del mismo agregado a la vez.
+	QueryLockOutbox = `SELECT pg_advisory_xact_lock(hashtext('outbox_events'))`
+
+	QueryClaimOutboxEvents = `
+		UPDATE outbox_events
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT e.id
+			FROM outbox_events e
+			WHERE e.published_at IS NULL
+			  AND e.next_attempt_at <= $1
+			  AND (e.locked_until IS NULL OR e.locked_until <= $1)
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM outbox_events p
+				WHERE p.aggregate_type = e.aggregate_type
+				  AND p.aggregate_id = e.aggregate_id
+				  AND p.published_at IS NULL
+				  AND p.id < e.id
+				  AND (p.next_attempt_at > $1 OR p.locked_until > $1)
+			  )
+			ORDER BY e.id
+			LIMIT $3
+		)
+		RETURNING ` + queryOutboxColumns + `
+	`
+
+	QueryMarkOutboxPublished = `
+		UPDATE outbox_events
+		SET published_at = $2, locked_until = NULL
+		WHERE id = $1
+	`
+
+	QueryMarkOutboxFailed = `
+		UPDATE outbox_events
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3, locked_until = NULL
+		WHERE id = $1
+	`
+
+	QueryReleaseOutboxEvent = `
+		UPDATE outbox_events
+		SET locked_until = NULL
+		WHERE id = $1
+	`
+
+	QueryPurgeOutboxEvents = `
+		DELETE FROM outbox_events
+		WHERE published_at IS NOT NULL AND published_at < $1
+	`
+
+	QueryGetOutboxEvent = `
+		SELECT ` + queryOutboxColumns + `
+		FROM outbox_events
+		WHERE id = $1
+	`
+
+	QueryListOutboxEventsAfter = `
+		SELECT ` + queryOutboxColumns + `
+		FROM outbox_events
+		WHERE aggregate_type = $1 AND id > $2 AND occurred_at < $3
+		ORDER BY id
+		LIMIT $4
+	`
+
+	QueryLastOutboxEventID = `
+		SELECT COALESCE(MAX(id), 0)
+		FROM outbox_events
+		WHERE aggregate_type = $1 AND occurred_at < $2
+	`
+)
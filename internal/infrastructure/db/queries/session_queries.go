@@ -0,0 +1,73 @@
+package db
+
+const (
+	querySessionColumns = `id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked_at, external_roles`
+
+	QueryInsertSession = `
+		INSERT INTO sessions (user_id, user_agent, ip, created_at, last_used_at, expires_at, external_roles)
+		VALUES ($1, $2, $3, $4, $4, $5, $6)
+		RETURNING id
+	`
+
+	QueryInsertRefreshToken = `
+		INSERT INTO refresh_tokens (token_hash, session_id, created_at)
+		VALUES ($1, $2, $3)
+	`
+
+	QueryGetSession = `
+		SELECT ` + querySessionColumns + `
+		FROM sessions
+		WHERE id = $1
+	`
+
+	QueryGetSessionByToken = `
+		SELECT ` + querySessionColumns + `
+		FROM sessions
+		WHERE id = (SELECT session_id FROM refresh_tokens WHERE token_hash = $1)
+	`
+
+	QueryLockRefreshToken = `
+		SELECT session_id, used_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	QueryUseRefreshToken = `
+		UPDATE refresh_tokens
+		SET used_at = $2
+		WHERE token_hash = $1
+	`
+
+	QueryTouchSession = `
+		UPDATE sessions
+		SET last_used_at = $2, ip = $3, user_agent = $4
+		WHERE id = $1
+		RETURNING ` + querySessionColumns + `
+	`
+
+	QueryRevokeSession = `
+		UPDATE sessions
+		SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	QueryRevokeUserSessions = `
+		UPDATE sessions
+		SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+	`
+
+	QueryRevokeOtherUserSessions = `
+		UPDATE sessions
+		SET revoked_at = $2
+		WHERE user_id = $1 AND id <> $3 AND revoked_at IS NULL AND expires_at > $2
+	`
+
+	QueryListActiveSessions = `
+		SELECT ` + querySessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_used_at DESC, id DESC
+	`
+)
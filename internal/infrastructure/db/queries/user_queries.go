@@ -1,31 +1,40 @@
 package db
 
 const (
-	QueryGetUserByID = `
-		SELECT id, name, email
-		FROM users
-		WHERE id = $1
+	QueryUpdateUserStatus = `
+		UPDATE users
+		SET status = $1
+		WHERE id = $2 AND status = $3
 	`
 
-	QueryInsertUser = `
-		INSERT INTO users (name, email)
-		VALUES ($1, $2)
+	QueryInsertUserStatusChange = `
+		INSERT INTO user_status_changes (user_id, from_status, to_status, reason, actor, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 
-	QueryUpdateUser = `
-		UPDATE users
-		SET name = $1, email = $2
-		WHERE id = $3
+	QueryListUserStatusChanges = `
+		SELECT id, user_id, from_status, to_status, reason, actor, changed_at
+		FROM user_status_changes
+		WHERE user_id = $1
+		ORDER BY changed_at, id
 	`
 
-	QueryDeleteUser = `
-		DELETE FROM users
+	QuerySetEmailVerified = `
+		UPDATE users
+		SET email_verified = $2
 		WHERE id = $1
 	`
 
-	QuerySelectUserBase = `
-		SELECT id, name, email
+	QueryGetUserIDByPublicID = `
+		SELECT id
+		FROM users
+		WHERE public_id = $1
+	`
+
+	QueryGetUserIDsByPublicIDs = `
+		SELECT public_id, id
 		FROM users
+		WHERE public_id = ANY($1::uuid[])
 	`
 )
@@ -0,0 +1,21 @@
+package db
+
+const (
+	QueryInsertUserToken = `
+		INSERT INTO user_tokens (token_hash, user_id, purpose, email, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	QueryConsumeUserToken = `
+		UPDATE user_tokens
+		SET used_at = $3
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > $3
+		RETURNING token_hash, user_id, purpose, email, created_at, expires_at, used_at
+	`
+
+	QueryInvalidateUserTokens = `
+		UPDATE user_tokens
+		SET used_at = $3
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`
+)
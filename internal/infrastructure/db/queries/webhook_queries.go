@@ -0,0 +1,93 @@
+package db
+
+const (
+	queryWebhookColumns = `id, url, event_types, active, created_by, created_at, updated_at, secret`
+
+	queryDeliveryColumns = `id, webhook_id, event_id, event_type, status, attempts, next_attempt_at,
+		last_attempt_at, response_code, response_body, last_error, duration_ms, created_at, delivered_at, payload`
+
+	QueryInsertWebhook = `
+		INSERT INTO webhooks (url, event_types, secret, active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	QueryGetWebhook = `
+		SELECT ` + queryWebhookColumns + `
+		FROM webhooks
+		WHERE id = $1
+	`
+
+	QueryListWebhooks = `
+		SELECT ` + queryWebhookColumns + `
+		FROM webhooks
+		ORDER BY created_at DESC, id DESC
+		OFFSET $1 LIMIT $2
+	`
+
+	QueryUpdateWebhook = `
+		UPDATE webhooks
+		SET url = $2, event_types = $3, active = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	QuerySetWebhookSecret = `
+		UPDATE webhooks
+		SET secret = $2, updated_at = $3
+		WHERE id = $1
+		RETURNING ` + queryWebhookColumns + `
+	`
+
+	QueryDeleteWebhook = `DELETE FROM webhooks WHERE id = $1`
+
+	// ON CONFLICT hace idempotente el encolado si el relay publica el mismo evento otra vez.
+	QueryEnqueueWebhookDeliveries = `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, next_attempt_at, created_at)
+		SELECT id, $1, $2, $3, $4, $4
+		FROM webhooks
+		WHERE active AND ($2::text = ANY(event_types) OR '*' = ANY(event_types))
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	// SKIP LOCKED permite que varias instancias envíen entregas distintas a la vez.
+	QueryClaimWebhookDeliveries = `
+		UPDATE webhook_deliveries
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id AND w.active
+			WHERE d.status = 'pending'
+			  AND d.next_attempt_at <= $1
+			  AND (d.locked_until IS NULL OR d.locked_until <= $1)
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING ` + queryDeliveryColumns + `
+	`
+
+	QueryGetWebhookDelivery = `
+		SELECT ` + queryDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND id = $2
+	`
+
+	QueryListWebhookDeliveries = `
+		SELECT ` + queryDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		OFFSET $3 LIMIT $4
+	`
+
+	QueryRecordWebhookAttempt = `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, status = $2, next_attempt_at = $3, last_attempt_at = $4,
+		    response_code = $5, response_body = $6, last_error = $7, duration_ms = $8,
+		    delivered_at = CASE WHEN $2 = 'succeeded' THEN $4 ELSE delivered_at END,
+		    locked_until = NULL
+		WHERE id = $1
+		RETURNING ` + queryDeliveryColumns + `
+	`
+)
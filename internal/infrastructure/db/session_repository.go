@@ -0,0 +1,210 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// sessionRepository implementa el puerto SessionRepository sobre las tablas sessions y refresh_tokens.
+type sessionRepository struct {
+	db Conn
+}
+
+// NewSessionRepository crea una nueva instancia de sessionRepository.
+func NewSessionRepository(db Conn) ports.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create registra la sesión y su primer token de renovación en una misma transacción.
+func (r *sessionRepository) Create(session *model.Session, tokenHash string) error {
+	log.Debug().Int64("userID", session.UserID).Msg("🟢 Creando sesión")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	// external_roles es NOT NULL y pq.Array(nil) se escribe como NULL.
+	roles := session.ExternalRoles
+	if roles == nil {
+		roles = []string{}
+	}
+	err = tx.QueryRow(queryVar.QueryInsertSession,
+		session.UserID, session.UserAgent, session.IP, session.CreatedAt, session.ExpiresAt, pq.Array(roles),
+	).Scan(&session.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al crear sesión")
+		return err
+	}
+	session.LastUsedAt = session.CreatedAt
+
+	if _, err := tx.Exec(queryVar.QueryInsertRefreshToken, tokenHash, session.ID, session.CreatedAt); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al guardar token de renovación")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, session.ID).Int64("userID", session.UserID).Msg("✅ Sesión creada")
+	return nil
+}
+
+// Get obtiene una sesión por ID.
+func (r *sessionRepository) Get(id int64) (*model.Session, error) {
+	return r.get(queryVar.QueryGetSession, id)
+}
+
+// GetByToken obtiene la sesión a la que pertenece un token de renovación.
+func (r *sessionRepository) GetByToken(tokenHash string) (*model.Session, error) {
+	return r.get(queryVar.QueryGetSessionByToken, tokenHash)
+}
+
+func (r *sessionRepository) get(query string, arg interface{}) (*model.Session, error) {
+	session, err := scanSession(r.db.QueryRow(query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrSessionNotFound
+		}
+		log.Error().Err(err).Msg("🔴 Error al obtener sesión")
+		return nil, err
+	}
+	return session, nil
+}
+
+// Rotate marca el token presentado como usado, guarda el nuevo y actualiza la sesión.
+// El token se bloquea con FOR UPDATE para que dos renovaciones simultáneas no lo canjeen ambas.
+func (r *sessionRepository) Rotate(oldHash, newHash string, client model.ClientInfo, now time.Time) (*model.Session, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var sessionID int64
+	var usedAt *time.Time
+	if err := tx.QueryRow(queryVar.QueryLockRefreshToken, oldHash).Scan(&sessionID, &usedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvalidRefreshToken
+		}
+		log.Error().Err(err).Msg("🔴 Error al obtener token de renovación")
+		return nil, err
+	}
+
+	if usedAt != nil {
+		log.Warn().Int64(enum.ID, sessionID).Msg("🚨 Reutilización de token de renovación; revocando sesión")
+		if _, err := tx.Exec(queryVar.QueryRevokeSession, sessionID, now); err != nil {
+			log.Error().Err(err).Int64(enum.ID, sessionID).Msg("🔴 Error al revocar sesión")
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+			return nil, err
+		}
+		return nil, model.ErrRefreshTokenReused
+	}
+
+	session, err := scanSession(tx.QueryRow(queryVar.QueryTouchSession, sessionID, now, client.IP, client.UserAgent))
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, sessionID).Msg("🔴 Error al actualizar sesión")
+		return nil, err
+	}
+	if session.RevokedAt != nil || !now.Before(session.ExpiresAt) {
+		return nil, model.ErrInvalidRefreshToken
+	}
+
+	if _, err := tx.Exec(queryVar.QueryUseRefreshToken, oldHash, now); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al marcar token de renovación")
+		return nil, err
+	}
+	if _, err := tx.Exec(queryVar.QueryInsertRefreshToken, newHash, sessionID, now); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al guardar token de renovación")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return nil, err
+	}
+
+	log.Debug().Int64(enum.ID, sessionID).Msg("🔄 Token de renovación rotado")
+	return session, nil
+}
+
+// Revoke revoca una sesión; revocar una ya revocada no es un error.
+func (r *sessionRepository) Revoke(id int64, now time.Time) error {
+	if _, err := r.db.Exec(queryVar.QueryRevokeSession, id, now); err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al revocar sesión")
+		return err
+	}
+	log.Info().Int64(enum.ID, id).Msg("✅ Sesión revocada")
+	return nil
+}
+
+// RevokeAll revoca todas las sesiones activas de un usuario.
+func (r *sessionRepository) RevokeAll(userID int64, now time.Time) (int, error) {
+	res, err := r.db.Exec(queryVar.QueryRevokeUserSessions, userID, now)
+	if err != nil {
+		log.Error().Err(err).Int64("userID", userID).Msg("🔴 Error al revocar sesiones")
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().Int64("userID", userID).Int64(enum.Total, affected).Msg("✅ Sesiones revocadas")
+	return int(affected), nil
+}
+
+// RevokeOthers revoca las sesiones activas de un usuario salvo keepID.
+func (r *sessionRepository) RevokeOthers(userID, keepID int64, now time.Time) (int, error) {
+	res, err := r.db.Exec(queryVar.QueryRevokeOtherUserSessions, userID, now, keepID)
+	if err != nil {
+		log.Error().Err(err).Int64("userID", userID).Msg("🔴 Error al revocar sesiones")
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().Int64("userID", userID).Int64("keepID", keepID).Int64(enum.Total, affected).Msg("✅ Otras sesiones revocadas")
+	return int(affected), nil
+}
+
+// ListActive obtiene las sesiones no revocadas ni vencidas de un usuario, la más reciente primero.
+func (r *sessionRepository) ListActive(userID int64, now time.Time) ([]*model.Session, error) {
+	rows, err := r.db.Query(queryVar.QueryListActiveSessions, userID, now)
+	if err != nil {
+		log.Error().Err(err).Int64("userID", userID).Msg("🔴 Error listando sesiones")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.Session, error) {
+		return scanSession(row)
+	})
+}
+
+func scanSession(row interface{ Scan(...interface{}) error }) (*model.Session, error) {
+	var s model.Session
+	if err := row.Scan(&s.ID, &s.UserID, &s.UserAgent, &s.IP,
+		&s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt, &s.RevokedAt, pq.Array(&s.ExternalRoles)); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
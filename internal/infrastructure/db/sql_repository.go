@@ -0,0 +1,350 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// SQLOptions configura un SQLRepository.
+type SQLOptions struct {
+	// Table es el nombre de la tabla.
+	Table string
+	// NotFound se devuelve cuando el registro no existe (p. ej. model.ErrUserNotFound).
+	NotFound error
+	// Conflict se devuelve ante violaciones de unicidad; si es nil se propaga el error original.
+	Conflict error
+	// ExactFilters son las columnas que se filtran por igualdad en lugar de ILIKE.
+	ExactFilters []string
+}
+
+// querier es lo que comparten *sql.DB y *sql.Tx: permite ejecutar las operaciones del
+// repositorio dentro o fuera de una transacción.
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// SQLRepository implementa ports.Repository para cualquier entidad cuyas columnas
+// se describan con etiquetas `db` (ver column).
+type SQLRepository[T any, ID comparable] struct {
+	db   Conn
+	meta *entityMeta
+	opts SQLOptions
+}
+
+// NewSQLRepository crea un repositorio genérico para T sobre la tabla indicada.
+// Entra en pánico si las etiquetas de T no son válidas, ya que es un error de programación.
+func NewSQLRepository[T any, ID comparable](db Conn, opts SQLOptions) ports.Repository[T, ID] {
+	return newSQLRepository[T, ID](db, opts)
+}
+
+func newSQLRepository[T any, ID comparable](db Conn, opts SQLOptions) *SQLRepository[T, ID] {
+	meta, err := newEntityMeta(reflect.TypeOf((*T)(nil)).Elem(), opts.Table)
+	if err != nil {
+		panic(err)
+	}
+	if opts.NotFound == nil {
+		opts.NotFound = sql.ErrNoRows
+	}
+	return &SQLRepository[T, ID]{db: db, meta: meta, opts: opts}
+}
+
+// GetByID obtiene un registro por su clave primaria.
+func (r *SQLRepository[T, ID]) GetByID(id ID) (*T, error) {
+	log.Debug().Str("table", r.meta.table).Interface(enum.ID, id).Msg("🟢 Buscando registro por ID")
+	return r.get(r.db, r.meta.getByID, id)
+}
+
+// GetByIDFields obtiene un registro leyendo sólo las columnas de fields (nombres JSON); el resto
+// de los campos queda con su valor cero.
+func (r *SQLRepository[T, ID]) GetByIDFields(id ID, fields []string) (*T, error) {
+	base, cols, err := r.meta.project(fields)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("table", r.meta.table).Interface(enum.ID, id).Strs(enum.Fields, fields).Msg("🟢 Buscando registro por ID")
+	return r.getColumns(r.db, fmt.Sprintf("%s WHERE %s = $1", base, r.meta.pk.name), cols, id)
+}
+
+// GetByIDs obtiene en una sola consulta los registros de varias claves primarias; las que no
+// existen se omiten.
+func (r *SQLRepository[T, ID]) GetByIDs(ids []ID) ([]*T, error) {
+	return r.GetByIDsFields(ids, nil)
+}
+
+// GetByIDsFields es GetByIDs leyendo sólo las columnas de fields y siempre la clave primaria,
+// para que el llamador sepa qué IDs no se encontraron.
+func (r *SQLRepository[T, ID]) GetByIDsFields(ids []ID, fields []string) ([]*T, error) {
+	if len(fields) > 0 {
+		fields = append(fields[:len(fields):len(fields)], r.meta.pk.field)
+	}
+	base, cols, err := r.meta.project(fields)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("table", r.meta.table).Int(enum.Total, len(ids)).Strs(enum.Fields, fields).Msg("🟢 Buscando registros por IDs")
+
+	query := fmt.Sprintf("%s WHERE %s = ANY($1) ORDER BY %s", base, r.meta.pk.name, r.meta.pk.name)
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Str("table", r.meta.table).Msg("🔴 Error buscando registros por IDs")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*T, error) {
+		return r.scanColumns(row, cols)
+	})
+}
+
+// get obtiene un registro con query (getByID o lock) dentro o fuera de una transacción.
+func (r *SQLRepository[T, ID]) get(q querier, query string, id ID) (*T, error) {
+	return r.getColumns(q, query, r.meta.columns, id)
+}
+
+func (r *SQLRepository[T, ID]) getColumns(q querier, query string, cols []column, id ID) (*T, error) {
+	entity, err := r.scanColumns(q.QueryRow(query, id), cols)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Str("table", r.meta.table).Interface(enum.ID, id).Msg("⚠️ Registro no encontrado")
+			return nil, r.opts.NotFound
+		}
+		log.Error().Err(err).Str("table", r.meta.table).Interface(enum.ID, id).Msg("🔴 Error al escanear registro por ID")
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Create inserta el registro y completa en la entidad la clave primaria y las columnas de solo lectura.
+func (r *SQLRepository[T, ID]) Create(entity *T) (ID, error) {
+	return r.create(r.db, entity)
+}
+
+func (r *SQLRepository[T, ID]) create(q querier, entity *T) (ID, error) {
+	var zero ID
+	v := reflect.ValueOf(entity).Elem()
+
+	var args, returning []interface{}
+	for _, col := range r.meta.columns {
+		field := v.FieldByIndex(col.index)
+		if col.pk || col.readonly {
+			returning = append(returning, scanTarget(col, field))
+			continue
+		}
+		args = append(args, valueOf(col, field))
+	}
+
+	if err := q.QueryRow(r.meta.insert, args...).Scan(returning...); err != nil {
+		if r.opts.Conflict != nil && isPgError(err, uniqueViolation) {
+			return zero, r.opts.Conflict
+		}
+		log.Error().Err(err).Str("table", r.meta.table).Msg("🔴 Error al crear registro")
+		return zero, err
+	}
+
+	id, _ := v.FieldByIndex(r.meta.pk.index).Interface().(ID)
+	log.Info().Str("table", r.meta.table).Interface(enum.ID, id).Msg("✅ Registro creado exitosamente")
+	return id, nil
+}
+
+// Update actualiza las columnas escribibles del registro identificado por su clave primaria.
+func (r *SQLRepository[T, ID]) Update(entity *T) error {
+	return r.update(r.db, entity)
+}
+
+func (r *SQLRepository[T, ID]) update(q querier, entity *T) error {
+	v := reflect.ValueOf(entity).Elem()
+	id := v.FieldByIndex(r.meta.pk.index).Interface()
+
+	var args []interface{}
+	for _, col := range r.meta.columns {
+		if col.pk || col.readonly || col.createonly {
+			continue
+		}
+		args = append(args, valueOf(col, v.FieldByIndex(col.index)))
+	}
+	args = append(args, id)
+
+	log.Debug().Str("table", r.meta.table).Interface(enum.ID, id).Msg("🟡 Actualizando registro")
+	res, err := q.Exec(r.meta.update, args...)
+	if err != nil {
+		if r.opts.Conflict != nil && isPgError(err, uniqueViolation) {
+			return r.opts.Conflict
+		}
+		log.Error().Err(err).Str("table", r.meta.table).Interface(enum.ID, id).Msg("🔴 Error al actualizar registro")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return r.opts.NotFound
+	}
+
+	log.Info().Str("table", r.meta.table).Interface(enum.ID, id).Msg("✅ Registro actualizado correctamente")
+	return nil
+}
+
+// Delete elimina el registro por su clave primaria.
+func (r *SQLRepository[T, ID]) Delete(id ID) error {
+	return r.delete(r.db, id)
+}
+
+func (r *SQLRepository[T, ID]) delete(q querier, id ID) error {
+	log.Debug().Str("table", r.meta.table).Interface(enum.ID, id).Msg("🟠 Eliminando registro")
+
+	res, err := q.Exec(r.meta.delete, id)
+	if err != nil {
+		log.Error().Err(err).Str("table", r.meta.table).Interface(enum.ID, id).Msg("🔴 Error al eliminar registro")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return r.opts.NotFound
+	}
+
+	log.Info().Str("table", r.meta.table).Interface(enum.ID, id).Msg("✅ Registro eliminado correctamente")
+	return nil
+}
+
+// List obtiene una lista paginada con filtros dinámicos. Las claves del filtro deben ser columnas
+// de la entidad (ver filtered).
+func (r *SQLRepository[T, ID]) List(offset int, limit int, filters map[string]interface{}) ([]*T, error) {
+	return r.ListFields(offset, limit, filters, nil)
+}
+
+// ListFields es List leyendo sólo las columnas de fields (nombres JSON); sin fields lee todas.
+func (r *SQLRepository[T, ID]) ListFields(offset int, limit int, filters map[string]interface{}, fields []string) ([]*T, error) {
+	log.Debug().
+		Str("table", r.meta.table).
+		Int(enum.Offset, offset).
+		Int(enum.Limit, limit).
+		Interface(enum.Filters, filters).
+		Strs(enum.Fields, fields).
+		Msg("🔍 Listando registros con filtros")
+
+	base, cols, err := r.meta.project(fields)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := r.filtered(base, filters)
+	if err != nil {
+		return nil, err
+	}
+	query, args = dbutils.AddPagination(query, args, len(args)+1, limit, offset)
+
+	log.Debug().Str(enum.Query, query).Interface(enum.Args, args).Msg("📄 Query final construida")
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		log.Error().Err(err).Str("table", r.meta.table).Msg("🔴 Error ejecutando query de listado")
+		return nil, err
+	}
+
+	results, err := dbutils.ScanRows(rows, func(row *sql.Rows) (*T, error) {
+		return r.scanColumns(row, cols)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("table", r.meta.table).Msg("🔴 Error al escanear resultados del listado")
+		return nil, err
+	}
+
+	log.Info().Str("table", r.meta.table).Int(enum.Total, len(results)).Msg("✅ Registros listados exitosamente")
+	return results, nil
+}
+
+// Count cuenta los registros que cumplen los mismos filtros que List.
+func (r *SQLRepository[T, ID]) Count(filters map[string]interface{}) (int, error) {
+	query, args, err := r.filtered(r.meta.count, filters)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(query, args...).Scan(&total); err != nil {
+		log.Error().Err(err).Str("table", r.meta.table).Msg("🔴 Error contando registros")
+		return 0, err
+	}
+	return total, nil
+}
+
+// filtered agrega a base las condiciones de los filtros: las columnas JSON se comparan por
+// contención, las de ExactFilters por igualdad, los model.Filter con su operador y el resto con ILIKE.
+func (r *SQLRepository[T, ID]) filtered(base string, filters map[string]interface{}) (string, []interface{}, error) {
+	exactKeys := append([]string(nil), r.opts.ExactFilters...)
+	for key, val := range filters {
+		col, ok := r.meta.has(key)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: filter %q", model.ErrUnknownField, key)
+		}
+		if _, ok := val.(model.Filter); ok || col.json {
+			exactKeys = append(exactKeys, key)
+		}
+	}
+
+	likeFilters, exactFilters := dbutils.SplitFilters(filters, exactKeys...)
+	for key, val := range exactFilters {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			exactFilters[key] = dbutils.JSONContains(v)
+		case model.Filter:
+			exactFilters[key] = dbutils.Match{Op: string(v.Op), Value: v.Value}
+		}
+	}
+
+	query, args := dbutils.BuildFilteredQuery(base, likeFilters, exactFilters, 1)
+	return query, args, nil
+}
+
+func (r *SQLRepository[T, ID]) scan(row interface{ Scan(...interface{}) error }) (*T, error) {
+	return r.scanColumns(row, r.meta.columns)
+}
+
+func (r *SQLRepository[T, ID]) scanColumns(row interface{ Scan(...interface{}) error }, cols []column) (*T, error) {
+	entity := new(T)
+	v := reflect.ValueOf(entity).Elem()
+
+	targets := make([]interface{}, len(cols))
+	for i, col := range cols {
+		targets[i] = scanTarget(col, v.FieldByIndex(col.index))
+	}
+	if err := row.Scan(targets...); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// scanTarget devuelve el destino de Scan adecuado para la columna.
+func scanTarget(col column, field reflect.Value) interface{} {
+	ptr := field.Addr().Interface()
+	switch {
+	case col.json:
+		return &dbutils.JSON{V: ptr}
+	case col.array:
+		return pq.Array(ptr)
+	default:
+		return ptr
+	}
+}
+
+// valueOf devuelve el argumento SQL adecuado para la columna.
+// Los slices nulos de columnas array se envían como arreglos vacíos y no como NULL.
+func valueOf(col column, field reflect.Value) interface{} {
+	if col.array && field.Kind() == reflect.Slice && field.IsNil() {
+		field = reflect.MakeSlice(field.Type(), 0, 0)
+	}
+	value := field.Interface()
+	switch {
+	case col.json:
+		return dbutils.JSON{V: value}
+	case col.array:
+		return pq.Array(value)
+	default:
+		return value
+	}
+}
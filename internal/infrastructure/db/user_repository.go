@@ -2,123 +2,362 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
 	"github.com/jnates/crud_golang/internal/domain/model"
 	"github.com/jnates/crud_golang/internal/domain/ports"
 	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
 	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
 	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
 // userRepository implementa el puerto UserRepository con una fuente de datos SQL.
+// Las operaciones CRUD se delegan en el repositorio genérico a partir de las etiquetas `db` de model.User.
 type userRepository struct {
-	db *sql.DB
+	*SQLRepository[model.User, int64]
+	db Conn
 }
 
-// NewUserRepository crea una nueva instancia de userRepository.
-func NewUserRepository(db *sql.DB) ports.UserRepository {
-	return &userRepository{db: db}
+// NewUserRepository crea una nueva instancia de userRepository. El email es único sin distinguir
+// mayúsculas (migrations/0014_unique_user_emails.sql): crear o actualizar con uno en uso
+// devuelve ErrEmailTaken.
+// Al eliminar un usuario, sus membresías de grupos y su historial de estados se eliminan en cascada (ver migrations/).
+func NewUserRepository(db Conn) ports.UserRepository {
+	return &userRepository{
+		SQLRepository: newSQLRepository[model.User, int64](db, SQLOptions{
+			Table:        "users",
+			NotFound:     model.ErrUserNotFound,
+			Conflict:     model.ErrEmailTaken,
+			ExactFilters: []string{enum.Status},
+		}),
+		db: db,
+	}
 }
 
-// GetByID obtiene un usuario por su ID.
-// Devuelve un puntero al modelo de usuario o un error si no se encuentra o hay problemas en la base de datos.
-func (r *userRepository) GetByID(id int64) (*model.User, error) {
-	log.Debug().Int64(enum.ID, id).Msg("🟢 Buscando usuario por ID")
-
-	row := r.db.QueryRow(queryVar.QueryGetUserByID, id)
+// CreateWithEvent crea el usuario con un ID público nuevo y guarda UserCreated en una misma transacción.
+func (r *userRepository) CreateWithEvent(user *model.User, actor string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return 0, err
+	}
+	defer tx.Rollback()
 
-	var user model.User
-	if err := row.Scan(&user.ID, &user.Name, &user.Email); err != nil {
-		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al escanear usuario por ID")
-		return nil, err
+	if user.PublicID, err = newPublicID(); err != nil {
+		return 0, err
+	}
+	id, err := r.create(tx, user)
+	if err != nil {
+		return 0, err
+	}
+	if err := recordEvent(tx, model.UserCreated{After: user}, actor); err != nil {
+		return 0, err
 	}
 
-	log.Debug().Int64(enum.ID, user.ID).Msg("✅ Usuario encontrado")
-	return &user, nil
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return 0, err
+	}
+	return id, nil
 }
 
-// Create inserta un nuevo usuario en la base de datos.
-// Devuelve el ID del nuevo usuario o un error si ocurre un fallo.
-func (r *userRepository) Create(user *model.User) (int64, error) {
-	log.Debug().Str(enum.Name, user.Name).Str(enum.Email, user.Email).Msg("🟢 Creando nuevo usuario")
+// CreateWithStatusChange crea el usuario ya en el estado change.To, registra la transición desde
+// change.From y guarda UserCreated en una misma transacción; completa change.UserID y change.ID.
+func (r *userRepository) CreateWithStatusChange(user *model.User, change *model.UserStatusChange) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return 0, err
+	}
+	defer tx.Rollback()
 
-	var id int64
-	err := r.db.QueryRow(queryVar.QueryInsertUser, user.Name, user.Email).Scan(&id)
+	if user.PublicID, err = newPublicID(); err != nil {
+		return 0, err
+	}
+	user.Status = change.To
+	id, err := r.create(tx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	change.UserID = id
+	err = tx.QueryRow(queryVar.QueryInsertUserStatusChange,
+		change.UserID, change.From, change.To, change.Reason, change.Actor, change.ChangedAt,
+	).Scan(&change.ID)
 	if err != nil {
-		log.Error().Err(err).Msg("🔴 Error al crear usuario")
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al registrar cambio de estado")
+		return 0, err
+	}
+	if err := recordEvent(tx, model.UserCreated{After: user}, change.Actor); err != nil {
 		return 0, err
 	}
 
-	log.Info().Int64(enum.ID, id).Msg("✅ Usuario creado exitosamente")
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return 0, err
+	}
 	return id, nil
 }
 
-// Update actualiza los datos de un usuario existente por su ID.
-// Devuelve un error si la operación falla.
-func (r *userRepository) Update(user *model.User) error {
-	log.Debug().Int64(enum.ID, user.ID).Msg("🟡 Actualizando usuario")
+// UpdateWithEvent actualiza el usuario y guarda UserUpdated con el estado anterior y el
+// posterior en una misma transacción. Si cambia el email, vuelve a quedar sin verificar.
+func (r *userRepository) UpdateWithEvent(user *model.User, actor string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
 
-	_, err := r.db.Exec(queryVar.QueryUpdateUser, user.Name, user.Email, user.ID)
+	before, err := r.get(tx, r.meta.lock, user.ID)
 	if err != nil {
-		log.Error().Err(err).Int64(enum.ID, user.ID).Msg("🔴 Error al actualizar usuario")
+		return err
+	}
+	if err := r.update(tx, user); err != nil {
+		return err
+	}
+	if before.EmailVerified && !strings.EqualFold(before.Email, user.Email) {
+		if _, err := tx.Exec(queryVar.QuerySetEmailVerified, user.ID, false); err != nil {
+			log.Error().Err(err).Int64(enum.ID, user.ID).Msg("🔴 Error al actualizar verificación de email")
+			return err
+		}
+	}
+	after, err := r.get(tx, r.meta.getByID, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := recordEvent(tx, model.UserUpdated{Before: before, After: after}, actor); err != nil {
 		return err
 	}
 
-	log.Info().Int64(enum.ID, user.ID).Msg("✅ Usuario actualizado correctamente")
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+	user.PublicID = after.PublicID
+	user.EmailVerified = after.EmailVerified
 	return nil
 }
 
-// Delete elimina un usuario de la base de datos por su ID.
-// Devuelve un error si ocurre un fallo.
-func (r *userRepository) Delete(id int64) error {
-	log.Debug().Int64(enum.ID, id).Msg("🟠 Eliminando usuario")
+// DeleteWithEvent elimina el usuario y guarda UserDeleted en una misma transacción.
+func (r *userRepository) DeleteWithEvent(id int64, actor string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
 
-	_, err := r.db.Exec(queryVar.QueryDeleteUser, id)
+	before, err := r.get(tx, r.meta.lock, id)
 	if err != nil {
-		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al eliminar usuario")
+		return err
+	}
+	if err := r.delete(tx, id); err != nil {
+		return err
+	}
+	if err := recordEvent(tx, model.UserDeleted{Before: before}, actor); err != nil {
 		return err
 	}
 
-	log.Info().Int64(enum.ID, id).Msg("✅ Usuario eliminado correctamente")
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
 	return nil
 }
 
-// List obtiene una lista paginada de usuarios con filtros dinámicos opcionales.
-// Recibe offset, limit y un mapa de filtros (por nombre, email, etc.).
-// Devuelve un slice de punteros a modelo User o un error.
-func (r *userRepository) List(offset int, limit int, filters map[string]interface{}) ([]*model.User, error) {
+// ChangeStatus aplica una transición de estado, registra el cambio, revoca las sesiones si el
+// nuevo estado no permite iniciar sesión y guarda UserUpdated en una misma transacción. La
+// actualización sólo procede si el usuario sigue en el estado de origen; de lo contrario
+// devuelve ErrStatusConflict.
+func (r *userRepository) ChangeStatus(change *model.UserStatusChange) error {
 	log.Debug().
-		Int(enum.Offset, offset).
-		Int(enum.Limit, limit).
-		Interface(enum.Filters, filters).
-		Msg("🔍 Listando usuarios con filtros")
+		Int64(enum.ID, change.UserID).
+		Str("from", string(change.From)).
+		Str("to", string(change.To)).
+		Msg("🟡 Cambiando estado de usuario")
 
-	query, args := dbutils.BuildDynamicQuery(queryVar.QuerySelectUserBase, filters, 1)
-	query, args = dbutils.AddPagination(query, args, len(args)+1, limit, offset)
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
 
-	log.Debug().Str(enum.Query, query).Interface(enum.Args, args).Msg("📄 Query final construida")
+	before, err := r.get(tx, r.meta.lock, change.UserID)
+	if err != nil {
+		return err
+	}
 
-	rows, err := r.db.Query(query, args...)
+	res, err := tx.Exec(queryVar.QueryUpdateUserStatus, change.To, change.UserID, change.From)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, change.UserID).Msg("🔴 Error al actualizar estado de usuario")
+		return err
+	}
+	affected, err := res.RowsAffected()
 	if err != nil {
-		log.Error().Err(err).Msg("🔴 Error ejecutando query de listado")
+		return err
+	}
+	if affected == 0 {
+		log.Warn().Int64(enum.ID, change.UserID).Msg("⚠️ El estado del usuario cambió concurrentemente")
+		return model.ErrStatusConflict
+	}
+
+	err = tx.QueryRow(queryVar.QueryInsertUserStatusChange,
+		change.UserID, change.From, change.To, change.Reason, change.Actor, change.ChangedAt,
+	).Scan(&change.ID)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, change.UserID).Msg("🔴 Error al registrar cambio de estado")
+		return err
+	}
+
+	if !change.To.AllowsLogin() {
+		if _, err := tx.Exec(queryVar.QueryRevokeUserSessions, change.UserID, change.ChangedAt); err != nil {
+			log.Error().Err(err).Int64(enum.ID, change.UserID).Msg("🔴 Error al revocar sesiones")
+			return err
+		}
+	}
+
+	after := *before
+	after.Status = change.To
+	if err := recordEvent(tx, model.UserUpdated{Before: before, After: &after}, change.Actor); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, change.UserID).Str("to", string(change.To)).Msg("✅ Estado de usuario actualizado")
+	return nil
+}
+
+// ListStatusChanges obtiene el historial de transiciones de estado de un usuario en orden cronológico.
+func (r *userRepository) ListStatusChanges(userID int64) ([]*model.UserStatusChange, error) {
+	log.Debug().Int64(enum.ID, userID).Msg("🔍 Listando historial de estados")
+
+	rows, err := r.db.Query(queryVar.QueryListUserStatusChanges, userID)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, userID).Msg("🔴 Error consultando historial de estados")
 		return nil, err
 	}
-	defer rows.Close()
 
-	users, scanErr := dbutils.ScanRows(rows, func(row *sql.Rows) (*model.User, error) {
-		var user model.User
-		if err := row.Scan(&user.ID, &user.Name, &user.Email); err != nil {
-			log.Error().Err(err).Msg("🔴 Error al escanear fila de usuario")
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.UserStatusChange, error) {
+		var change model.UserStatusChange
+		if err := row.Scan(&change.ID, &change.UserID, &change.From, &change.To,
+			&change.Reason, &change.Actor, &change.ChangedAt); err != nil {
+			log.Error().Err(err).Msg("🔴 Error al escanear cambio de estado")
 			return nil, err
 		}
-		return &user, nil
+		return &change, nil
 	})
+}
+
+// SetEmailVerified actualiza el estado de verificación del email de un usuario y guarda
+// UserUpdated en una misma transacción.
+func (r *userRepository) SetEmailVerified(userID int64, verified bool, actor string) error {
+	log.Debug().Int64(enum.ID, userID).Bool("verified", verified).Msg("🟡 Actualizando verificación de email")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := r.get(tx, r.meta.lock, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(queryVar.QuerySetEmailVerified, userID, verified); err != nil {
+		log.Error().Err(err).Int64(enum.ID, userID).Msg("🔴 Error al actualizar verificación de email")
+		return err
+	}
+	after := *before
+	after.EmailVerified = verified
+	if err := recordEvent(tx, model.UserUpdated{Before: before, After: &after}, actor); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return err
+	}
+	return nil
+}
+
+// GetIDByPublicID devuelve el ID interno del usuario con ese ID público. Un valor que no es un
+// UUID es ErrInvalidID, no una consulta fallida; se consulta con la forma canónica, porque
+// uuid.Parse acepta formas (urn:uuid:..., {...}) que el tipo uuid de Postgres rechaza.
+func (r *userRepository) GetIDByPublicID(publicID string) (int64, error) {
+	parsed, err := uuid.Parse(publicID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", model.ErrInvalidID, publicID)
+	}
+
+	var id int64
+	if err := r.db.QueryRow(queryVar.QueryGetUserIDByPublicID, parsed.String()).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, model.ErrUserNotFound
+		}
+		log.Error().Err(err).Str("publicID", publicID).Msg("🔴 Error al buscar usuario por ID público")
+		return 0, err
+	}
+	return id, nil
+}
+
+// newPublicID genera el ID público de un usuario nuevo. UUIDv7 empieza por la fecha de creación,
+// así que el índice crece en orden, y el resto es aleatorio.
+func newPublicID() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al generar ID público")
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// GetIDsByPublicIDs devuelve los IDs internos indexados por el ID público tal como se pidió,
+// sin distinguir mayúsculas. Un valor que no es un UUID es ErrInvalidID.
+func (r *userRepository) GetIDsByPublicIDs(publicIDs []string) (map[string]int64, error) {
+	requested := make(map[string][]string, len(publicIDs))
+	canonical := make([]string, 0, len(publicIDs))
+	for _, publicID := range publicIDs {
+		parsed, err := uuid.Parse(publicID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", model.ErrInvalidID, publicID)
+		}
+		key := parsed.String()
+		if _, ok := requested[key]; !ok {
+			canonical = append(canonical, key)
+		}
+		requested[key] = append(requested[key], publicID)
+	}
 
-	if scanErr != nil {
-		log.Error().Err(scanErr).Msg("🔴 Error al escanear resultados del listado")
-		return nil, scanErr
+	rows, err := r.db.Query(queryVar.QueryGetUserIDsByPublicIDs, pq.Array(canonical))
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al buscar usuarios por ID público")
+		return nil, err
 	}
+	defer rows.Close()
 
-	log.Info().Int(enum.Total, len(users)).Msg("✅ Usuarios listados exitosamente")
-	return users, nil
+	ids := make(map[string]int64, len(publicIDs))
+	for rows.Next() {
+		var publicID string
+		var id int64
+		if err := rows.Scan(&publicID, &id); err != nil {
+			log.Error().Err(err).Msg("🔴 Error al escanear usuario por ID público")
+			return nil, err
+		}
+		for _, ref := range requested[publicID] {
+			ids[ref] = id
+		}
+	}
+	return ids, rows.Err()
 }
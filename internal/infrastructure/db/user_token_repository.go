@@ -0,0 +1,113 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/rs/zerolog/log"
+)
+
+// userTokenRepository implementa el puerto UserTokenRepository sobre la tabla user_tokens.
+type userTokenRepository struct {
+	db *sql.DB
+}
+
+// NewUserTokenRepository crea una nueva instancia de userTokenRepository.
+func NewUserTokenRepository(db *sql.DB) ports.UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+// Create guarda el hash de un token de un solo uso.
+func (r *userTokenRepository) Create(token *model.UserToken) error {
+	log.Debug().Int64("userID", token.UserID).Str("purpose", string(token.Purpose)).Msg("🟢 Guardando token de un solo uso")
+
+	_, err := r.db.Exec(queryVar.QueryInsertUserToken,
+		token.Hash, token.UserID, token.Purpose, token.Email, token.CreatedAt, token.ExpiresAt,
+	)
+	if err != nil {
+		if isPgError(err, foreignKeyViolation) {
+			return model.ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("🔴 Error al guardar token de un solo uso")
+		return err
+	}
+	return nil
+}
+
+// Consume marca el token como usado con una sola sentencia, así dos canjes simultáneos no
+// pueden usarlo ambos.
+func (r *userTokenRepository) Consume(hash string, purpose model.TokenPurpose, now time.Time) (*model.UserToken, error) {
+	token, err := consumeUserToken(r.db, hash, purpose, now)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int64("userID", token.UserID).Str("purpose", string(purpose)).Msg("✅ Token de un solo uso canjeado")
+	return token, nil
+}
+
+// ResetPassword canjea el token y fija la contraseña en una misma transacción, así un error al
+// guardar la contraseña no deja el token usado. Si el usuario cambió de email después de
+// recibirlo, el token no vale.
+func (r *userTokenRepository) ResetPassword(hash, passwordHash string, now time.Time) (*model.UserToken, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al iniciar transacción")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	token, err := consumeUserToken(tx, hash, model.TokenPurposePasswordReset, now)
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.Exec(queryVar.QueryResetPassword, token.UserID, passwordHash, now.UTC(), token.Email)
+	if err != nil {
+		log.Error().Err(err).Int64("userID", token.UserID).Msg("🔴 Error al actualizar contraseña")
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		log.Warn().Int64("userID", token.UserID).Msg("⚠️ El email del usuario cambió después de emitir el token")
+		return nil, model.ErrInvalidUserToken
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("🔴 Error al confirmar transacción")
+		return nil, err
+	}
+
+	log.Info().Int64("userID", token.UserID).Msg("✅ Contraseña restablecida")
+	return token, nil
+}
+
+func consumeUserToken(q querier, hash string, purpose model.TokenPurpose, now time.Time) (*model.UserToken, error) {
+	var token model.UserToken
+	err := q.QueryRow(queryVar.QueryConsumeUserToken, hash, purpose, now).Scan(
+		&token.Hash, &token.UserID, &token.Purpose, &token.Email, &token.CreatedAt, &token.ExpiresAt, &token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvalidUserToken
+		}
+		log.Error().Err(err).Msg("🔴 Error al canjear token de un solo uso")
+		return nil, err
+	}
+	return &token, nil
+}
+
+// InvalidateAll anula los tokens pendientes del usuario para el propósito indicado.
+func (r *userTokenRepository) InvalidateAll(userID int64, purpose model.TokenPurpose, now time.Time) error {
+	if _, err := r.db.Exec(queryVar.QueryInvalidateUserTokens, userID, purpose, now); err != nil {
+		log.Error().Err(err).Int64("userID", userID).Msg("🔴 Error al anular tokens pendientes")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	queryVar "github.com/jnates/crud_golang/internal/infrastructure/db/queries"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/tool/dbutils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// webhookRepository implementa el puerto WebhookRepository sobre las tablas webhooks y webhook_deliveries.
+type webhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository crea una nueva instancia de webhookRepository.
+func NewWebhookRepository(db *sql.DB) ports.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create registra el webhook con su secreto.
+func (r *webhookRepository) Create(hook *model.Webhook) error {
+	log.Debug().Str("url", hook.URL).Msg("🟢 Creando webhook")
+
+	err := r.db.QueryRow(queryVar.QueryInsertWebhook,
+		hook.URL, pq.Array(hook.EventTypes), hook.Secret, hook.Active, hook.CreatedBy, hook.CreatedAt, hook.UpdatedAt,
+	).Scan(&hook.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al crear webhook")
+		return err
+	}
+
+	log.Info().Int64(enum.ID, hook.ID).Str("url", hook.URL).Msg("✅ Webhook creado")
+	return nil
+}
+
+// Get obtiene un webhook por ID.
+func (r *webhookRepository) Get(id int64) (*model.Webhook, error) {
+	hook, err := scanWebhook(r.db.QueryRow(queryVar.QueryGetWebhook, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrWebhookNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al obtener webhook")
+		return nil, err
+	}
+	return hook, nil
+}
+
+// List obtiene una página de webhooks.
+func (r *webhookRepository) List(offset, limit int) ([]*model.Webhook, error) {
+	rows, err := r.db.Query(queryVar.QueryListWebhooks, offset, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error listando webhooks")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.Webhook, error) {
+		return scanWebhook(row)
+	})
+}
+
+// Update guarda el URL, los tipos de evento y el estado del webhook.
+func (r *webhookRepository) Update(hook *model.Webhook) error {
+	log.Debug().Int64(enum.ID, hook.ID).Msg("🟡 Actualizando webhook")
+
+	res, err := r.db.Exec(queryVar.QueryUpdateWebhook,
+		hook.ID, hook.URL, pq.Array(hook.EventTypes), hook.Active, hook.UpdatedAt)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, hook.ID).Msg("🔴 Error al actualizar webhook")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return model.ErrWebhookNotFound
+	}
+
+	log.Info().Int64(enum.ID, hook.ID).Msg("✅ Webhook actualizado")
+	return nil
+}
+
+// SetSecret reemplaza el secreto de firma del webhook.
+func (r *webhookRepository) SetSecret(id int64, secret string, now time.Time) (*model.Webhook, error) {
+	log.Debug().Int64(enum.ID, id).Msg("🟡 Rotando secreto de webhook")
+
+	hook, err := scanWebhook(r.db.QueryRow(queryVar.QuerySetWebhookSecret, id, secret, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrWebhookNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al rotar secreto de webhook")
+		return nil, err
+	}
+
+	log.Info().Int64(enum.ID, id).Msg("✅ Secreto de webhook rotado")
+	return hook, nil
+}
+
+// Delete elimina el webhook y sus entregas.
+func (r *webhookRepository) Delete(id int64) error {
+	log.Debug().Int64(enum.ID, id).Msg("🟠 Eliminando webhook")
+
+	res, err := r.db.Exec(queryVar.QueryDeleteWebhook, id)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al eliminar webhook")
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return model.ErrWebhookNotFound
+	}
+
+	log.Info().Int64(enum.ID, id).Msg("✅ Webhook eliminado")
+	return nil
+}
+
+// Enqueue crea las entregas del evento para los webhooks suscritos.
+func (r *webhookRepository) Enqueue(event *model.Event, payload []byte, now time.Time) (int, error) {
+	res, err := r.db.Exec(queryVar.QueryEnqueueWebhookDeliveries, event.ID, event.Type, payload, now)
+	if err != nil {
+		log.Error().Err(err).Int64("eventID", event.ID).Msg("🔴 Error al encolar entregas de webhook")
+		return 0, err
+	}
+	enqueued, _ := res.RowsAffected()
+	return int(enqueued), nil
+}
+
+// ClaimDeliveries reserva entregas pendientes vencidas.
+func (r *webhookRepository) ClaimDeliveries(limit int, now, leaseUntil time.Time) ([]*model.WebhookDelivery, error) {
+	rows, err := r.db.Query(queryVar.QueryClaimWebhookDeliveries, now, leaseUntil, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("🔴 Error al reservar entregas de webhook")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.WebhookDelivery, error) {
+		return scanDelivery(row)
+	})
+}
+
+// GetDelivery obtiene una entrega del webhook.
+func (r *webhookRepository) GetDelivery(webhookID, id int64) (*model.WebhookDelivery, error) {
+	delivery, err := scanDelivery(r.db.QueryRow(queryVar.QueryGetWebhookDelivery, webhookID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrDeliveryNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al obtener entrega de webhook")
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// ListDeliveries obtiene una página de entregas del webhook.
+func (r *webhookRepository) ListDeliveries(webhookID int64, status model.DeliveryStatus, offset, limit int) ([]*model.WebhookDelivery, error) {
+	rows, err := r.db.Query(queryVar.QueryListWebhookDeliveries, webhookID, string(status), offset, limit)
+	if err != nil {
+		log.Error().Err(err).Int64(enum.ID, webhookID).Msg("🔴 Error listando entregas de webhook")
+		return nil, err
+	}
+
+	return dbutils.ScanRows(rows, func(row *sql.Rows) (*model.WebhookDelivery, error) {
+		return scanDelivery(row)
+	})
+}
+
+// RecordAttempt guarda el resultado del intento y libera la reserva.
+func (r *webhookRepository) RecordAttempt(id int64, attempt *model.DeliveryAttempt, status model.DeliveryStatus, nextAttemptAt *time.Time) (*model.WebhookDelivery, error) {
+	lastError := ""
+	if attempt.Err != nil {
+		lastError = attempt.Err.Error()
+	}
+
+	delivery, err := scanDelivery(r.db.QueryRow(queryVar.QueryRecordWebhookAttempt,
+		id, string(status), nextAttemptAt, attempt.At, attempt.ResponseCode, attempt.ResponseBody,
+		lastError, attempt.Duration.Milliseconds()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrDeliveryNotFound
+		}
+		log.Error().Err(err).Int64(enum.ID, id).Msg("🔴 Error al registrar intento de entrega")
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func scanWebhook(row interface{ Scan(...interface{}) error }) (*model.Webhook, error) {
+	var hook model.Webhook
+	if err := row.Scan(&hook.ID, &hook.URL, pq.Array(&hook.EventTypes), &hook.Active, &hook.CreatedBy,
+		&hook.CreatedAt, &hook.UpdatedAt, &hook.Secret); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+func scanDelivery(row interface{ Scan(...interface{}) error }) (*model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	var status string
+	var payload []byte
+	if err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastAttemptAt, &d.ResponseCode, &d.ResponseBody, &d.LastError, &d.DurationMs, &d.CreatedAt,
+		&d.DeliveredAt, &payload); err != nil {
+		return nil, err
+	}
+	d.Status = model.DeliveryStatus(status)
+	d.Payload = payload
+	return &d, nil
+}
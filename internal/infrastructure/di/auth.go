@@ -0,0 +1,52 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+)
+
+// authOptionsFromEnv parte de application.DefaultAuthOptions y aplica PASSWORD_MIN_LENGTH,
+// LOGIN_MAX_ATTEMPTS, LOGIN_LOCKOUT_DURATION, JWT_REFRESH_TTL, RESET_TOKEN_TTL,
+// VERIFICATION_TOKEN_TTL, INVITATION_TTL y APP_PUBLIC_URL si están definidas.
+func authOptionsFromEnv() (application.AuthOptions, error) {
+	opts := application.DefaultAuthOptions()
+
+	for env, target := range map[string]*int{
+		enum.PasswordMinLength: &opts.Password.MinLength,
+		enum.LoginMaxAttempts:  &opts.MaxFailedLogins,
+	} {
+		if value := os.Getenv(env); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return opts, fmt.Errorf("%s: %w", env, err)
+			}
+			*target = n
+		}
+	}
+
+	for env, target := range map[string]*time.Duration{
+		enum.LoginLockoutDuration: &opts.LockoutDuration,
+		enum.JWTRefreshTTL:        &opts.SessionTTL,
+		enum.ResetTokenTTL:        &opts.ResetTokenTTL,
+		enum.VerificationTokenTTL: &opts.VerificationTokenTTL,
+		enum.InvitationTTL:        &opts.InvitationTTL,
+	} {
+		if value := os.Getenv(env); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return opts, fmt.Errorf("%s: %w", env, err)
+			}
+			*target = d
+		}
+	}
+
+	if value := os.Getenv(enum.AppPublicURL); value != "" {
+		opts.PublicURL = value
+	}
+	return opts, nil
+}
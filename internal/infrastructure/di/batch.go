@@ -0,0 +1,45 @@
+package di
+
+import (
+	"database/sql"
+
+	"github.com/jnates/crud_golang/internal/infrastructure/db"
+	"github.com/jnates/crud_golang/internal/infrastructure/http/handler"
+	"go.uber.org/dig"
+)
+
+// TxRoutesGroup es el grupo de dig con las rutas que admite el modo atómico de POST /batch.
+const TxRoutesGroup = "tx_routes"
+
+// TxRoutes arma los handlers de un recurso sobre la transacción de una petición atómica a
+// POST /batch. Los repositorios en los que escribe el recurso deben usar conn; el resto de
+// dependencias se comparten con el contenedor.
+type TxRoutes func(conn db.Conn) handler.RouteRegistrar
+
+// txRoutes agrupa los TxRoutes registrados con provideTxRoutes.
+type txRoutes struct {
+	dig.In
+
+	Routes []TxRoutes `group:"tx_routes"`
+}
+
+// provideTxRoutes agrega a TxRoutesGroup el TxRoutes que devuelve constructor.
+func provideTxRoutes(container *dig.Container, constructor interface{}) error {
+	return container.Provide(constructor, dig.Group(TxRoutesGroup))
+}
+
+// batchTxFactory abre una transacción por petición atómica y arma sobre ella las rutas de routes.
+func batchTxFactory(conn *sql.DB, routes []TxRoutes) handler.BatchTxFactory {
+	return func() (*handler.BatchTx, error) {
+		tx, err := db.BeginTxConn(conn)
+		if err != nil {
+			return nil, err
+		}
+
+		registrars := make([]handler.RouteRegistrar, 0, len(routes))
+		for _, build := range routes {
+			registrars = append(registrars, build(tx))
+		}
+		return &handler.BatchTx{Registrars: registrars, Commit: tx.Commit, Rollback: tx.Rollback}, nil
+	}
+}
@@ -2,31 +2,92 @@ package di
 
 import (
 	"database/sql"
+	"os"
+	"strings"
 
 	"github.com/jnates/crud_golang/internal/application"
 	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/auth"
 	"github.com/jnates/crud_golang/internal/infrastructure/db"
+	"github.com/jnates/crud_golang/internal/infrastructure/graphql"
 	"github.com/jnates/crud_golang/internal/infrastructure/http/handler"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/rpc"
 	"github.com/rs/zerolog/log"
 	"go.uber.org/dig"
 )
 
+// RoutesGroup es el grupo de dig donde se registran los handlers que exponen rutas.
+const RoutesGroup = "routes"
+
 func BuildContainer(conn *sql.DB) *dig.Container {
 	log.Debug().Msg("🧱 Iniciando construcción del contenedor de dependencias")
 
 	container := dig.New()
 
+	if err := container.Provide(func(sessions ports.SessionRepository) (*auth.JWTVerifier, error) {
+		if strings.EqualFold(os.Getenv(enum.AuthDisabled), "true") {
+			log.Warn().Msg("⚠️ Autenticación deshabilitada (AUTH_DISABLED=true)")
+			return nil, nil
+		}
+		log.Debug().Msg("🔌 Registrando JWTVerifier")
+		return auth.NewJWTVerifierFromEnv(sessions)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando JWTVerifier")
+		return nil
+	}
+
+	if err := container.Provide(func() (*auth.HMACVerifier, error) {
+		log.Debug().Msg("🔌 Registrando HMACVerifier")
+		return auth.NewHMACVerifierFromEnv()
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando HMACVerifier")
+		return nil
+	}
+
+	if err := container.Provide(func() (*application.Authorizer, error) {
+		log.Debug().Msg("🔌 Registrando Authorizer")
+		policy, err := loadPolicy(os.Getenv(enum.PolicyFile))
+		if err != nil {
+			return nil, err
+		}
+		return application.NewAuthorizer(policy), nil
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando Authorizer")
+		return nil
+	}
+
 	if err := container.Provide(func() ports.UserRepository {
 		log.Debug().Msg("🔌 Registrando UserRepository")
-		return db.NewUserRepository(conn)
+		return db.NewUserRepository(db.NewConn(conn))
 	}); err != nil {
 		log.Error().Err(err).Msg("❌ Error registrando UserRepository")
 		return nil
 	}
 
-	if err := container.Provide(func(repo ports.UserRepository) *application.UserService {
+	if err := container.Provide(func() ports.SessionRepository {
+		log.Debug().Msg("🔌 Registrando SessionRepository")
+		return db.NewSessionRepository(db.NewConn(conn))
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando SessionRepository")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.AttributeDefinitionRepository {
+		log.Debug().Msg("🔌 Registrando AttributeDefinitionRepository")
+		return db.NewAttributeDefinitionRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AttributeDefinitionRepository")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		repo ports.UserRepository,
+		attributes ports.AttributeDefinitionRepository,
+		authz *application.Authorizer,
+	) *application.UserService {
 		log.Debug().Msg("🔌 Registrando UserService")
-		return application.NewUserService(repo)
+		return application.NewUserService(repo, attributes, authz)
 	}); err != nil {
 		log.Error().Err(err).Msg("❌ Error registrando UserService")
 		return nil
@@ -40,6 +101,494 @@ func BuildContainer(conn *sql.DB) *dig.Container {
 		return nil
 	}
 
+	if err := provideRoutes[*handler.UserHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de UserHandler")
+		return nil
+	}
+
+	if err := provideTxRoutes(container, func(attributes ports.AttributeDefinitionRepository, authz *application.Authorizer) TxRoutes {
+		return func(tx db.Conn) handler.RouteRegistrar {
+			return handler.NewUserHandler(application.NewUserService(db.NewUserRepository(tx), attributes, authz))
+		}
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas transaccionales de UserHandler")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.UserService) *rpc.UserServer {
+		log.Debug().Msg("🔌 Registrando UserServer gRPC")
+		return rpc.NewUserServer(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando UserServer gRPC")
+		return nil
+	}
+
+	if err := container.Provide(func(repo ports.AttributeDefinitionRepository, authz *application.Authorizer) *application.AttributeService {
+		log.Debug().Msg("🔌 Registrando AttributeService")
+		return application.NewAttributeService(repo, authz)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AttributeService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.AttributeService) *handler.AttributeHandler {
+		log.Debug().Msg("🔌 Registrando AttributeHandler")
+		return handler.NewAttributeHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AttributeHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.AttributeHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de AttributeHandler")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.GroupRepository {
+		log.Debug().Msg("🔌 Registrando GroupRepository")
+		return db.NewGroupRepository(db.NewConn(conn))
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando GroupRepository")
+		return nil
+	}
+
+	if err := container.Provide(func(repo ports.GroupRepository, users ports.UserRepository, authz *application.Authorizer) *application.GroupService {
+		log.Debug().Msg("🔌 Registrando GroupService")
+		return application.NewGroupService(repo, users, authz)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando GroupService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.GroupService) *handler.GroupHandler {
+		log.Debug().Msg("🔌 Registrando GroupHandler")
+		return handler.NewGroupHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando GroupHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.GroupHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de GroupHandler")
+		return nil
+	}
+
+	if err := provideTxRoutes(container, func(authz *application.Authorizer) TxRoutes {
+		return func(tx db.Conn) handler.RouteRegistrar {
+			return handler.NewGroupHandler(application.NewGroupService(db.NewGroupRepository(tx), db.NewUserRepository(tx), authz))
+		}
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas transaccionales de GroupHandler")
+		return nil
+	}
+
+	if err := container.Provide(func(users *application.UserService, groups *application.GroupService) (*graphql.API, error) {
+		log.Debug().Msg("🔌 Registrando API GraphQL")
+		return graphql.NewAPI(users, groups)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando API GraphQL")
+		return nil
+	}
+
+	if err := container.Provide(func(api *graphql.API) *handler.GraphQLHandler {
+		log.Debug().Msg("🔌 Registrando GraphQLHandler")
+		return handler.NewGraphQLHandler(api)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando GraphQLHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.GraphQLHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de GraphQLHandler")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.CredentialRepository {
+		log.Debug().Msg("🔌 Registrando CredentialRepository")
+		return db.NewCredentialRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando CredentialRepository")
+		return nil
+	}
+
+	if err := container.Provide(func() (ports.TokenIssuer, error) {
+		log.Debug().Msg("🔌 Registrando TokenIssuer")
+		return auth.NewJWTIssuerFromEnv()
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando TokenIssuer")
+		return nil
+	}
+
+	if err := container.Provide(authOptionsFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AuthOptions")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.PasswordHasher {
+		log.Debug().Msg("🔌 Registrando PasswordHasher")
+		return auth.NewBcryptHasher()
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando PasswordHasher")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		users ports.UserRepository,
+		credentials ports.CredentialRepository,
+		sessions ports.SessionRepository,
+		groups ports.GroupRepository,
+		hasher ports.PasswordHasher,
+		tokens ports.TokenIssuer,
+		authz *application.Authorizer,
+		opts application.AuthOptions,
+	) *application.AuthService {
+		log.Debug().Msg("🔌 Registrando AuthService")
+		return application.NewAuthService(users, credentials, sessions, groups, hasher, tokens, authz, opts)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AuthService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.AuthService) *handler.AuthHandler {
+		log.Debug().Msg("🔌 Registrando AuthHandler")
+		return handler.NewAuthHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AuthHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.AuthHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de AuthHandler")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.UserTokenRepository {
+		log.Debug().Msg("🔌 Registrando UserTokenRepository")
+		return db.NewUserTokenRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando UserTokenRepository")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.OutboxRepository {
+		log.Debug().Msg("🔌 Registrando OutboxRepository")
+		return db.NewOutboxRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando OutboxRepository")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.WebhookRepository {
+		log.Debug().Msg("🔌 Registrando WebhookRepository")
+		return db.NewWebhookRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando WebhookRepository")
+		return nil
+	}
+
+	if err := container.Provide(newWebhookSenderFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando WebhookSender")
+		return nil
+	}
+
+	if err := container.Provide(webhookOptionsFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando WebhookOptions")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		hooks ports.WebhookRepository,
+		sender ports.WebhookSender,
+		authz *application.Authorizer,
+		opts application.WebhookOptions,
+	) *application.WebhookService {
+		log.Debug().Msg("🔌 Registrando WebhookService")
+		return application.NewWebhookService(hooks, sender, authz, opts)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando WebhookService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.WebhookService) *handler.WebhookHandler {
+		log.Debug().Msg("🔌 Registrando WebhookHandler")
+		return handler.NewWebhookHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando WebhookHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.WebhookHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de WebhookHandler")
+		return nil
+	}
+
+	if err := provideJob(container, webhookDeliveryJob); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando tarea de entrega de webhooks")
+		return nil
+	}
+
+	if err := container.Provide(newEventPublisherFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando EventPublisher")
+		return nil
+	}
+
+	if err := container.Provide(outboxOptionsFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando OutboxOptions")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		outbox ports.OutboxRepository,
+		publisher ports.EventPublisher,
+		opts application.OutboxOptions,
+	) *application.OutboxRelay {
+		log.Debug().Msg("🔌 Registrando OutboxRelay")
+		return application.NewOutboxRelay(outbox, publisher, opts)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando OutboxRelay")
+		return nil
+	}
+
+	if err := provideJob(container, outboxRelayJob); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando tarea de publicación de eventos")
+		return nil
+	}
+
+	if err := provideJob(container, outboxPurgeJob); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando tarea de purga del outbox")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.EventNotifier {
+		log.Debug().Msg("🔌 Registrando EventNotifier")
+		return db.NewOutboxListener(db.PostgresDSN())
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando EventNotifier")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		users *application.UserService,
+		outbox ports.OutboxRepository,
+		notifier ports.EventNotifier,
+	) *application.UserFeed {
+		log.Debug().Msg("🔌 Registrando UserFeed")
+		return application.NewUserFeed(users, outbox, notifier, application.DefaultUserFeedOptions())
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando UserFeed")
+		return nil
+	}
+
+	if err := container.Provide(func(feed *application.UserFeed) *handler.UserStreamHandler {
+		log.Debug().Msg("🔌 Registrando UserStreamHandler")
+		return handler.NewUserStreamHandler(feed)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando UserStreamHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.UserStreamHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de UserStreamHandler")
+		return nil
+	}
+
+	if err := provideJob(container, userFeedJob); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando tarea del stream de usuarios")
+		return nil
+	}
+
+	if err := container.Provide(newMailerFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando Mailer")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		users ports.UserRepository,
+		credentials ports.CredentialRepository,
+		sessions ports.SessionRepository,
+		tokens ports.UserTokenRepository,
+		hasher ports.PasswordHasher,
+		mailer ports.Mailer,
+		authz *application.Authorizer,
+		opts application.AuthOptions,
+	) *application.AccountService {
+		log.Debug().Msg("🔌 Registrando AccountService")
+		return application.NewAccountService(users, credentials, sessions, tokens, hasher, mailer, authz, opts)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AccountService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.AccountService) *handler.AccountHandler {
+		log.Debug().Msg("🔌 Registrando AccountHandler")
+		return handler.NewAccountHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando AccountHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.AccountHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de AccountHandler")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.InvitationRepository {
+		log.Debug().Msg("🔌 Registrando InvitationRepository")
+		return db.NewInvitationRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando InvitationRepository")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		invitations ports.InvitationRepository,
+		users ports.UserRepository,
+		attributes ports.AttributeDefinitionRepository,
+		hasher ports.PasswordHasher,
+		mailer ports.Mailer,
+		authz *application.Authorizer,
+		opts application.AuthOptions,
+	) *application.InvitationService {
+		log.Debug().Msg("🔌 Registrando InvitationService")
+		return application.NewInvitationService(invitations, users, attributes, hasher, mailer, authz, opts)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando InvitationService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.InvitationService) *handler.InvitationHandler {
+		log.Debug().Msg("🔌 Registrando InvitationHandler")
+		return handler.NewInvitationHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando InvitationHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.InvitationHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de InvitationHandler")
+		return nil
+	}
+
+	if err := provideJob(container, invitationExpiryJob); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando tarea de vencimiento de invitaciones")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.APIKeyRepository {
+		log.Debug().Msg("🔌 Registrando APIKeyRepository")
+		return db.NewAPIKeyRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando APIKeyRepository")
+		return nil
+	}
+
+	if err := container.Provide(func(keys ports.APIKeyRepository, authz *application.Authorizer) *application.APIKeyService {
+		log.Debug().Msg("🔌 Registrando APIKeyService")
+		return application.NewAPIKeyService(keys, authz)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando APIKeyService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.APIKeyService) *handler.APIKeyHandler {
+		log.Debug().Msg("🔌 Registrando APIKeyHandler")
+		return handler.NewAPIKeyHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando APIKeyHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.APIKeyHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de APIKeyHandler")
+		return nil
+	}
+
+	if err := container.Provide(newIdentityProviderFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando IdentityProvider")
+		return nil
+	}
+
+	if err := container.Provide(oidcOptionsFromEnv); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando OIDCOptions")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.LoginStateStore {
+		log.Debug().Msg("🔌 Registrando LoginStateStore")
+		return auth.NewMemoryLoginStateStore()
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando LoginStateStore")
+		return nil
+	}
+
+	if err := container.Provide(func() ports.IdentityRepository {
+		log.Debug().Msg("🔌 Registrando IdentityRepository")
+		return db.NewIdentityRepository(conn)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando IdentityRepository")
+		return nil
+	}
+
+	if err := container.Provide(func(
+		provider ports.IdentityProvider,
+		states ports.LoginStateStore,
+		identities ports.IdentityRepository,
+		users ports.UserRepository,
+		credentials ports.CredentialRepository,
+		authService *application.AuthService,
+		opts application.OIDCOptions,
+	) *application.OIDCService {
+		log.Debug().Msg("🔌 Registrando OIDCService")
+		return application.NewOIDCService(provider, states, identities, users, credentials, authService, opts)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando OIDCService")
+		return nil
+	}
+
+	if err := container.Provide(func(svc *application.OIDCService) *handler.OIDCHandler {
+		log.Debug().Msg("🔌 Registrando OIDCHandler")
+		return handler.NewOIDCHandler(svc)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando OIDCHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.OIDCHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de OIDCHandler")
+		return nil
+	}
+
+	if err := container.Provide(func(users *application.UserService, groups *application.GroupService) *handler.SCIMHandler {
+		log.Debug().Msg("🔌 Registrando SCIMHandler")
+		return handler.NewSCIMHandler(users, groups)
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando SCIMHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.SCIMHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de SCIMHandler")
+		return nil
+	}
+
+	if err := container.Provide(func(in txRoutes) *handler.BatchHandler {
+		log.Debug().Msg("🔌 Registrando BatchHandler")
+		return handler.NewBatchHandler(batchTxFactory(conn, in.Routes))
+	}); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando BatchHandler")
+		return nil
+	}
+
+	if err := provideRoutes[*handler.BatchHandler](container); err != nil {
+		log.Error().Err(err).Msg("❌ Error registrando rutas de BatchHandler")
+		return nil
+	}
+
 	log.Debug().Msg("✅ Contenedor construido exitosamente")
 	return container
 }
+
+// provideRoutes agrega el handler H al grupo de RouteRegistrar que consume el servidor HTTP.
+func provideRoutes[H handler.RouteRegistrar](container *dig.Container) error {
+	return container.Provide(func(h H) handler.RouteRegistrar { return h }, dig.Group(RoutesGroup))
+}
@@ -0,0 +1,88 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/events"
+	"github.com/jnates/crud_golang/internal/infrastructure/jobs"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultOutboxRelayInterval = time.Second
+	outboxPurgeInterval        = time.Hour
+	// userFeedRetryInterval es la espera antes de volver a escuchar si se pierde la conexión LISTEN.
+	userFeedRetryInterval = 5 * time.Second
+)
+
+// newEventPublisherFromEnv elige el publicador según EVENT_PUBLISHER: "log" (por defecto)
+// escribe los eventos en el log y "memory" los guarda en memoria. Además, cada evento se
+// encola para los webhooks suscritos.
+func newEventPublisherFromEnv(webhooks *application.WebhookService) (ports.EventPublisher, error) {
+	var publisher ports.EventPublisher
+	switch driver := envOrDefault(enum.EventPublisher, "log"); driver {
+	case "log":
+		log.Debug().Msg("🔌 Registrando publicador de eventos en el log")
+		publisher = events.NewLogPublisher()
+	case "memory":
+		log.Debug().Msg("🔌 Registrando publicador de eventos en memoria")
+		publisher = events.NewMemoryPublisher()
+	default:
+		return nil, fmt.Errorf("%s: unknown publisher %q", enum.EventPublisher, driver)
+	}
+	return events.NewFanout(publisher, webhooks), nil
+}
+
+// outboxOptionsFromEnv parte de DefaultOutboxOptions y aplica OUTBOX_BATCH_SIZE,
+// OUTBOX_MAX_BACKOFF y OUTBOX_RETENTION.
+func outboxOptionsFromEnv() (application.OutboxOptions, error) {
+	opts := application.DefaultOutboxOptions()
+
+	if value := os.Getenv(enum.OutboxBatchSize); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return opts, fmt.Errorf("%s: must be a positive integer", enum.OutboxBatchSize)
+		}
+		opts.BatchSize = n
+	}
+
+	for env, target := range map[string]*time.Duration{
+		enum.OutboxMaxBackoff: &opts.MaxBackoff,
+		enum.OutboxRetention:  &opts.Retention,
+	} {
+		if value := os.Getenv(env); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return opts, fmt.Errorf("%s: %w", env, err)
+			}
+			*target = d
+		}
+	}
+	return opts, nil
+}
+
+// outboxRelayJob publica los eventos pendientes cada OUTBOX_RELAY_INTERVAL (1s por defecto).
+func outboxRelayJob(relay *application.OutboxRelay) (jobs.Job, error) {
+	interval, err := jobInterval(enum.OutboxRelayInterval, defaultOutboxRelayInterval)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	return jobs.Job{Name: "outbox-relay", Interval: interval, Run: relay.Relay}, nil
+}
+
+// outboxPurgeJob elimina cada hora los eventos publicados que superan OUTBOX_RETENTION.
+func outboxPurgeJob(relay *application.OutboxRelay) jobs.Job {
+	return jobs.Job{Name: "outbox-purge", Interval: outboxPurgeInterval, Run: relay.Purge}
+}
+
+// userFeedJob escucha los avisos del outbox para el stream de usuarios. Run no vuelve mientras
+// la escucha siga activa; si falla, se reintenta a los 5s.
+func userFeedJob(feed *application.UserFeed) jobs.Job {
+	return jobs.Job{Name: "user-feed", Interval: userFeedRetryInterval, Run: feed.Run}
+}
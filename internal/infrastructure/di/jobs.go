@@ -0,0 +1,48 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/infrastructure/jobs"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"go.uber.org/dig"
+)
+
+// JobsGroup es el grupo de dig donde se registran las tareas en segundo plano que lanza el servidor.
+const JobsGroup = "jobs"
+
+const defaultInvitationExpiryInterval = time.Hour
+
+// provideJob agrega al grupo JobsGroup la tarea que construye constructor.
+func provideJob(container *dig.Container, constructor interface{}) error {
+	return container.Provide(constructor, dig.Group(JobsGroup))
+}
+
+// invitationExpiryJob vence las invitaciones pendientes cada INVITATION_EXPIRY_INTERVAL (1h por defecto).
+func invitationExpiryJob(svc *application.InvitationService) (jobs.Job, error) {
+	interval, err := jobInterval(enum.InvitationExpiryInterval, defaultInvitationExpiryInterval)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	return jobs.Job{Name: "invitation-expiry", Interval: interval, Run: svc.ExpirePending}, nil
+}
+
+// jobInterval lee la frecuencia de una tarea de la variable env, o def si no está definida.
+// Debe ser positiva: time.NewTicker no admite otra.
+func jobInterval(env string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(env)
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", env, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s: must be a positive duration", env)
+	}
+	return d, nil
+}
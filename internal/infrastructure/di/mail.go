@@ -0,0 +1,90 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/mail"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultMailOutboxDir = "tmp/mail"
+	defaultMailFrom      = "no-reply@localhost"
+	defaultSMTPHost      = "localhost"
+	defaultSMTPPort      = 1025
+)
+
+// newMailerFromEnv arma el Mailer de plantillas con el transporte que indica MAIL_DRIVER:
+// "file" (por defecto) escribe los mensajes en MAIL_OUTBOX_DIR, "smtp" los entrega al servidor
+// SMTP_HOST:SMTP_PORT (por defecto un MailHog local) y "memory" los guarda en memoria.
+// Los transportes de archivo y SMTP reintentan con backoff hasta MAIL_RETRY_ATTEMPTS veces.
+func newMailerFromEnv() (ports.Mailer, error) {
+	var transport mail.Transport
+	switch driver := envOrDefault(enum.MailDriver, "file"); driver {
+	case "file":
+		dir := envOrDefault(enum.MailOutboxDir, defaultMailOutboxDir)
+		log.Debug().Str("dir", dir).Msg("🔌 Registrando transporte de email en archivos")
+		file, err := mail.NewFileTransport(dir)
+		if err != nil {
+			return nil, err
+		}
+		transport = file
+	case "smtp":
+		cfg, err := smtpConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		log.Debug().Str("host", cfg.Host).Int("port", cfg.Port).Msg("🔌 Registrando transporte de email SMTP")
+		transport = mail.NewSMTPTransport(cfg)
+	case "memory":
+		log.Debug().Msg("🔌 Registrando transporte de email en memoria")
+		transport = mail.NewMemoryTransport()
+	default:
+		return nil, fmt.Errorf("%s: unknown driver %q", enum.MailDriver, driver)
+	}
+
+	if _, ok := transport.(*mail.MemoryTransport); !ok {
+		retry := mail.DefaultRetryOptions()
+		if value := os.Getenv(enum.MailRetryAttempts); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", enum.MailRetryAttempts, err)
+			}
+			retry.Attempts = n
+		}
+		transport = mail.NewRetryTransport(transport, retry)
+	}
+
+	return mail.NewTemplateMailer(transport,
+		envOrDefault(enum.MailFrom, defaultMailFrom),
+		envOrDefault(enum.MailDefaultLocale, model.LocaleES))
+}
+
+func smtpConfigFromEnv() (mail.SMTPConfig, error) {
+	cfg := mail.SMTPConfig{
+		Host:     envOrDefault(enum.SMTPHost, defaultSMTPHost),
+		Port:     defaultSMTPPort,
+		Username: os.Getenv(enum.SMTPUsername),
+		Password: os.Getenv(enum.SMTPPassword),
+	}
+	if value := os.Getenv(enum.SMTPPort); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: %w", enum.SMTPPort, err)
+		}
+		cfg.Port = port
+	}
+	return cfg, nil
+}
+
+func envOrDefault(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
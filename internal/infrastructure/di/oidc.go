@@ -0,0 +1,62 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/auth"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/rs/zerolog/log"
+)
+
+// newIdentityProviderFromEnv devuelve el proveedor OIDC configurado con OIDC_*, o nil si no hay
+// ninguno. Se devuelve la interfaz nil explícitamente para que OIDCService.Enabled lo detecte.
+func newIdentityProviderFromEnv() (ports.IdentityProvider, error) {
+	provider, err := auth.NewOIDCProviderFromEnv()
+	if err != nil || provider == nil {
+		return nil, err
+	}
+	log.Info().Str("issuer", os.Getenv(enum.OIDCIssuerURL)).Msg("🔑 Inicio de sesión OIDC habilitado")
+	return provider, nil
+}
+
+// oidcOptionsFromEnv parte de application.DefaultOIDCOptions y aplica OIDC_GROUP_ROLES
+// ("grupo=rol,grupo=rol", repitiendo el grupo para darle varios roles), OIDC_AUTO_PROVISION y
+// OIDC_STATE_TTL si están definidas.
+func oidcOptionsFromEnv() (application.OIDCOptions, error) {
+	opts := application.DefaultOIDCOptions()
+
+	if value := os.Getenv(enum.OIDCGroupRoles); value != "" {
+		opts.GroupRoles = make(map[string][]string)
+		for _, pair := range strings.Split(value, ",") {
+			group, role, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+			if !ok || group == "" || role == "" {
+				return opts, fmt.Errorf("%s: invalid mapping %q, expected group=role", enum.OIDCGroupRoles, pair)
+			}
+			opts.GroupRoles[group] = append(opts.GroupRoles[group], role)
+		}
+	}
+
+	if value := os.Getenv(enum.OIDCAutoProvision); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, fmt.Errorf("%s: %w", enum.OIDCAutoProvision, err)
+		}
+		opts.AutoProvision = enabled
+	}
+
+	if value := os.Getenv(enum.OIDCStateTTL); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return opts, fmt.Errorf("%s: %w", enum.OIDCStateTTL, err)
+		}
+		opts.StateTTL = d
+	}
+	return opts, nil
+}
@@ -0,0 +1,35 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/rs/zerolog/log"
+)
+
+// loadPolicy parte de model.DefaultPolicy y, si se indica un archivo JSON
+// ({"roles": {"rol": ["permiso", ...]}}), reemplaza o agrega los roles que define.
+func loadPolicy(path string) (*model.Policy, error) {
+	policy := model.DefaultPolicy()
+	if path == "" {
+		return policy, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy file: %w", err)
+	}
+
+	var custom model.Policy
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("parsing policy file: %w", err)
+	}
+
+	for role, perms := range custom.Roles {
+		policy.Roles[role] = perms
+	}
+	log.Debug().Str("file", path).Int("roles", len(custom.Roles)).Msg("🔐 Política de permisos cargada")
+	return policy, nil
+}
@@ -0,0 +1,60 @@
+package di
+
+import (
+	"database/sql"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/db"
+	"github.com/jnates/crud_golang/internal/infrastructure/http/handler"
+	"github.com/rs/zerolog/log"
+	"go.uber.org/dig"
+)
+
+// Resource describe una entidad CRUD sin reglas de negocio propias.
+type Resource[T any, ID comparable] struct {
+	SQL  db.SQLOptions
+	HTTP handler.CRUDOptions[T, ID]
+}
+
+// RegisterResource registra en el contenedor el repositorio, el servicio y el handler genéricos
+// de T y agrega sus rutas al servidor y al modo atómico de POST /batch. Los permisos del recurso
+// son "<tabla>:read" y "<tabla>:write". Una entidad nueva sólo necesita su struct con etiquetas
+// `db` y una llamada a esta función desde BuildContainer:
+//
+//	di.RegisterResource(container, conn, di.Resource[model.Product, int64]{
+//		SQL:  db.SQLOptions{Table: "products", NotFound: model.ErrProductNotFound},
+//		HTTP: handler.CRUDOptions[model.Product, int64]{Resource: "product", Path: "/products", Filters: []string{"name"}},
+//	})
+func RegisterResource[T any, ID comparable](container *dig.Container, conn *sql.DB, res Resource[T, ID]) error {
+	log.Debug().Str("resource", res.HTTP.Resource).Msg("🔌 Registrando recurso genérico")
+
+	if err := container.Provide(func() ports.Repository[T, ID] {
+		return db.NewSQLRepository[T, ID](db.NewConn(conn), res.SQL)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(repo ports.Repository[T, ID], authz *application.Authorizer) *application.CRUDService[T, ID] {
+		return application.NewCRUDService(repo, authz, res.SQL.Table)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(svc *application.CRUDService[T, ID]) *handler.CRUDHandler[T, ID] {
+		return handler.NewCRUDHandler[T, ID](svc, res.HTTP)
+	}); err != nil {
+		return err
+	}
+
+	if err := provideRoutes[*handler.CRUDHandler[T, ID]](container); err != nil {
+		return err
+	}
+
+	return provideTxRoutes(container, func(authz *application.Authorizer) TxRoutes {
+		return func(tx db.Conn) handler.RouteRegistrar {
+			repo := db.NewSQLRepository[T, ID](tx, res.SQL)
+			return handler.NewCRUDHandler[T, ID](application.NewCRUDService(repo, authz, res.SQL.Table), res.HTTP)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+	"github.com/jnates/crud_golang/internal/infrastructure/jobs"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/webhook"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultWebhookTimeout          = 10 * time.Second
+	defaultWebhookDeliveryInterval = time.Second
+)
+
+// newWebhookSenderFromEnv crea el cliente HTTP de las entregas con WEBHOOK_TIMEOUT (10s por defecto).
+// Sólo con WEBHOOK_ALLOW_PRIVATE_NETWORKS=true se permiten destinos en la red interna.
+func newWebhookSenderFromEnv() (ports.WebhookSender, error) {
+	timeout := defaultWebhookTimeout
+	if value := os.Getenv(enum.WebhookTimeout); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", enum.WebhookTimeout, err)
+		}
+		timeout = d
+	}
+
+	allowPrivate := false
+	if value := os.Getenv(enum.WebhookAllowPrivate); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", enum.WebhookAllowPrivate, err)
+		}
+		allowPrivate = enabled
+	}
+	if allowPrivate {
+		log.Warn().Msg("⚠️ Webhooks habilitados hacia redes internas")
+	}
+	return webhook.NewHTTPSender(timeout, allowPrivate), nil
+}
+
+// webhookOptionsFromEnv parte de DefaultWebhookOptions y aplica WEBHOOK_MAX_ATTEMPTS,
+// WEBHOOK_BASE_BACKOFF y WEBHOOK_MAX_BACKOFF.
+func webhookOptionsFromEnv() (application.WebhookOptions, error) {
+	opts := application.DefaultWebhookOptions()
+
+	if value := os.Getenv(enum.WebhookMaxAttempts); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return opts, fmt.Errorf("%s: must be a positive integer", enum.WebhookMaxAttempts)
+		}
+		opts.MaxAttempts = n
+	}
+
+	for env, target := range map[string]*time.Duration{
+		enum.WebhookBaseBackoff: &opts.BaseBackoff,
+		enum.WebhookMaxBackoff:  &opts.MaxBackoff,
+	} {
+		if value := os.Getenv(env); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return opts, fmt.Errorf("%s: %w", env, err)
+			}
+			*target = d
+		}
+	}
+	return opts, nil
+}
+
+// webhookDeliveryJob envía las entregas pendientes cada WEBHOOK_DELIVERY_INTERVAL (1s por defecto).
+func webhookDeliveryJob(webhooks *application.WebhookService) (jobs.Job, error) {
+	interval, err := jobInterval(enum.WebhookDeliveryInterval, defaultWebhookDeliveryInterval)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	return jobs.Job{Name: "webhook-delivery", Interval: interval, Run: webhooks.DeliverPending}, nil
+}
@@ -0,0 +1,29 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/domain/ports"
+)
+
+// Fanout publica cada evento en todos sus publicadores. Si alguno falla el relay reintenta el
+// evento en todos, así que los publicadores deben tolerar duplicados.
+type Fanout struct {
+	publishers []ports.EventPublisher
+}
+
+func NewFanout(publishers ...ports.EventPublisher) *Fanout {
+	return &Fanout{publishers: publishers}
+}
+
+func (f *Fanout) Publish(ctx context.Context, event *model.Event) error {
+	var errs []error
+	for _, p := range f.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
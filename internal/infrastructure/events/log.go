@@ -0,0 +1,28 @@
+// Package events contiene las implementaciones de ports.EventPublisher.
+package events
+
+import (
+	"context"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/rs/zerolog/log"
+)
+
+// LogPublisher escribe cada evento en el log; es el publicador por defecto mientras no haya
+// un destino externo configurado.
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, event *model.Event) error {
+	log.Info().
+		Int64("eventID", event.ID).
+		Str("type", event.Type).
+		Str("aggregate", event.AggregateType+":"+event.AggregateID).
+		Str("actor", event.Actor).
+		RawJSON("payload", event.Payload).
+		Msg("📣 Evento de dominio publicado")
+	return nil
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// MemoryPublisher guarda los eventos en memoria; pensado para pruebas.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []model.Event
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event *model.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, *event)
+	return nil
+}
+
+// Events devuelve una copia de los eventos publicados en orden.
+func (p *MemoryPublisher) Events() []model.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]model.Event(nil), p.events...)
+}
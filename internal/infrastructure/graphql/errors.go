@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"errors"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// resolverError lleva el error del caso de uso con el código de extensions.code que esperan los
+// clientes GraphQL, como statusCodeFor en HTTP.
+type resolverError struct {
+	err error
+}
+
+func (e *resolverError) Error() string { return e.err.Error() }
+
+func (e *resolverError) Unwrap() error { return e.err }
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": codeFor(e.err)}
+	var forbidden *model.ForbiddenError
+	if errors.As(e.err, &forbidden) {
+		ext["permission"] = string(forbidden.Permission)
+	}
+	return ext
+}
+
+func codeFor(err error) string {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, model.ErrInvalid):
+		return "BAD_USER_INPUT"
+	case errors.Is(err, model.ErrConflict):
+		return "CONFLICT"
+	case errors.Is(err, model.ErrUnauthenticated):
+		return "UNAUTHENTICATED"
+	case errors.Is(err, model.ErrForbidden):
+		return "FORBIDDEN"
+	default:
+		return "INTERNAL_SERVER_ERROR"
+	}
+}
+
+// resolveError envuelve el error para que la respuesta lleve su código en extensions.
+func resolveError(err error) error {
+	return &resolverError{err: err}
+}
@@ -0,0 +1,25 @@
+package graphql
+
+import (
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+type groupResolver struct {
+	group *model.Group
+}
+
+func (g *groupResolver) ID() graphql.ID { return graphql.ID(strconv.FormatInt(g.group.ID, 10)) }
+
+func (g *groupResolver) Name() string { return g.group.Name }
+
+func (g *groupResolver) Description() string { return g.group.Description }
+
+func (g *groupResolver) Roles() []string {
+	if g.group.Roles == nil {
+		return []string{}
+	}
+	return g.group.Roles
+}
@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+const (
+	// loaderWait es cuánto espera un lote a que lleguen más claves antes de consultarse.
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch es el máximo de claves por consulta.
+	loaderMaxBatch = 500
+)
+
+// Loader junta las claves que piden los resolvers de una misma operación y las resuelve con una
+// sola llamada a fetch, para no consultar una vez por cada elemento de una lista (N+1). Cada clave
+// se consulta una sola vez por operación.
+type Loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *loaderBatch[K, V]
+	cache   map[K]*loaderBatch[K, V]
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	done    chan struct{}
+	results map[K]V
+	err     error
+}
+
+func NewLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{ctx: ctx, fetch: fetch, cache: make(map[K]*loaderBatch[K, V])}
+}
+
+// Load devuelve el valor de key, o el valor cero si fetch no lo devolvió.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	batch, ok := l.cache[key]
+	if !ok {
+		if l.pending == nil {
+			pending := &loaderBatch[K, V]{done: make(chan struct{})}
+			l.pending = pending
+			time.AfterFunc(loaderWait, func() { l.dispatch(pending) })
+		}
+		batch = l.pending
+		batch.keys = append(batch.keys, key)
+		l.cache[key] = batch
+		if len(batch.keys) >= loaderMaxBatch {
+			go l.dispatch(batch)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.results[key], batch.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch consulta el lote si nadie lo hizo antes.
+func (l *Loader[K, V]) dispatch(batch *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if l.pending != batch {
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	batch.results, batch.err = l.fetch(l.ctx, batch.keys)
+	close(batch.done)
+}
+
+// loaders son los Loader de una operación.
+type loaders struct {
+	groupsByUser *Loader[int64, []*model.Group]
+}
+
+func newLoaders(ctx context.Context, groups *application.GroupService) *loaders {
+	return &loaders{
+		groupsByUser: NewLoader(ctx, groups.GroupsOfUsers),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
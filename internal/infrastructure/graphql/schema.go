@@ -0,0 +1,100 @@
+// Package graphql expone los casos de uso en /graphql. El esquema se arma con los archivos de
+// schema/, uno por entidad, y cada entidad resuelve sus campos en su propio archivo.
+package graphql
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	validatorPackage "github.com/jnates/crud_golang/internal/infrastructure/http/validetor"
+	"github.com/labstack/echo/v4"
+)
+
+// maxDepth limita el anidamiento de las consultas.
+const maxDepth = 10
+
+//go:embed schema/*.graphql
+var schemaFiles embed.FS
+
+// API ejecuta consultas GraphQL contra los casos de uso.
+type API struct {
+	schema *graphql.Schema
+	sdl    string
+	groups *application.GroupService
+}
+
+func NewAPI(users *application.UserService, groups *application.GroupService) (*API, error) {
+	sdl, err := loadSchema()
+	if err != nil {
+		return nil, err
+	}
+	schema, err := graphql.ParseSchema(sdl, &resolver{
+		users:     users,
+		validator: validatorPackage.NewValidator(),
+	}, graphql.MaxDepth(maxDepth))
+	if err != nil {
+		return nil, err
+	}
+	return &API{schema: schema, sdl: sdl, groups: groups}, nil
+}
+
+// Exec ejecuta una operación. Cada operación tiene sus propios loaders, así que los lotes y la
+// caché nunca se comparten entre peticiones ni usuarios.
+func (a *API) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Response {
+	ctx = withLoaders(ctx, newLoaders(ctx, a.groups))
+	return a.schema.Exec(ctx, query, operationName, variables)
+}
+
+// SDL devuelve el esquema completo.
+func (a *API) SDL() string {
+	return a.sdl
+}
+
+// resolver es la raíz de Query y Mutation; los métodos de cada entidad están en su archivo.
+type resolver struct {
+	users     *application.UserService
+	validator echo.Validator
+}
+
+// loadSchema concatena los archivos del esquema; las extensiones de tipos no dependen del orden.
+func loadSchema() (string, error) {
+	entries, err := schemaFiles.ReadDir("schema")
+	if err != nil {
+		return "", err
+	}
+
+	var sdl strings.Builder
+	for _, entry := range entries {
+		content, err := schemaFiles.ReadFile("schema/" + entry.Name())
+		if err != nil {
+			return "", err
+		}
+		sdl.Write(content)
+		sdl.WriteString("\n")
+	}
+	return sdl.String(), nil
+}
+
+// JSON es el escalar JSON del esquema.
+type JSON map[string]interface{}
+
+func (JSON) ImplementsGraphQLType(name string) bool { return name == "JSON" }
+
+func (j *JSON) UnmarshalGraphQL(input interface{}) error {
+	object, ok := input.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w: JSON must be an object", model.ErrInvalidInput)
+	}
+	*j = object
+	return nil
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}(j))
+}
@@ -0,0 +1,221 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+)
+
+const defaultPageLimit = 10
+
+type userFilterInput struct {
+	Name       *string
+	Email      *string
+	Status     *[]string
+	Attributes *[]attributeFilterInput
+}
+
+type attributeFilterInput struct {
+	Name  string
+	Value string
+}
+
+// userInput es el cuerpo de createUser y updateUser; se valida con el mismo validador que la API REST.
+type userInput struct {
+	Name       string `validate:"required,max=100"`
+	Email      string `validate:"required,email,max=255"`
+	Attributes *JSON
+}
+
+func (in *userInput) toModel(id int64) *model.User {
+	user := &model.User{ID: id, Name: in.Name, Email: in.Email}
+	if in.Attributes != nil {
+		user.Attributes = *in.Attributes
+	}
+	return user
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	id, err := r.userID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.users.Get(ctx, id)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return &userResolver{user: user}, nil
+}
+
+func (r *resolver) Users(ctx context.Context, args struct {
+	Filter *userFilterInput
+	Page   int32
+	Limit  int32
+}) (*userPageResolver, error) {
+	page, limit := int(args.Page), int(args.Limit)
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+
+	users, err := r.users.List(ctx, (page-1)*limit, limit, userFilter(args.Filter))
+	if err != nil {
+		return nil, resolveError(err)
+	}
+
+	items := make([]*userResolver, 0, len(users))
+	for _, user := range users {
+		items = append(items, &userResolver{user: user})
+	}
+	return &userPageResolver{users: r.users, filter: args.Filter, items: items, page: page, limit: limit}, nil
+}
+
+func (r *resolver) CreateUser(ctx context.Context, args struct{ Input userInput }) (*userResolver, error) {
+	if err := r.validator.Validate(&args.Input); err != nil {
+		return nil, resolveError(fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	}
+	user := args.Input.toModel(0)
+	id, err := r.users.Create(ctx, user)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	user.ID = id
+	return &userResolver{user: user}, nil
+}
+
+func (r *resolver) UpdateUser(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input userInput
+}) (*userResolver, error) {
+	id, err := r.userID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validator.Validate(&args.Input); err != nil {
+		return nil, resolveError(fmt.Errorf("%w: %v", model.ErrInvalidInput, err))
+	}
+	if err := r.users.Update(ctx, args.Input.toModel(id)); err != nil {
+		return nil, resolveError(err)
+	}
+	return r.User(ctx, struct{ ID graphql.ID }{ID: args.ID})
+}
+
+func (r *resolver) DeleteUser(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := r.userID(ctx, args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.users.Delete(ctx, id); err != nil {
+		return false, resolveError(err)
+	}
+	return true, nil
+}
+
+type userResolver struct {
+	user *model.User
+}
+
+func (u *userResolver) ID() graphql.ID { return graphql.ID(strconv.FormatInt(u.user.ID, 10)) }
+
+func (u *userResolver) PublicID() graphql.ID { return graphql.ID(u.user.PublicID) }
+
+func (u *userResolver) Name() string { return u.user.Name }
+
+func (u *userResolver) Email() string { return u.user.Email }
+
+func (u *userResolver) EmailVerified() bool { return u.user.EmailVerified }
+
+func (u *userResolver) Status() string { return strings.ToUpper(string(u.user.Status)) }
+
+func (u *userResolver) Attributes() *JSON {
+	if len(u.user.Attributes) == 0 {
+		return nil
+	}
+	attrs := JSON(u.user.Attributes)
+	return &attrs
+}
+
+// Groups usa el loader de la operación: los grupos de todos los usuarios de una página se
+// consultan juntos.
+func (u *userResolver) Groups(ctx context.Context) ([]*groupResolver, error) {
+	groups, err := loadersFrom(ctx).groupsByUser.Load(ctx, u.user.ID)
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	resolvers := make([]*groupResolver, 0, len(groups))
+	for _, group := range groups {
+		resolvers = append(resolvers, &groupResolver{group: group})
+	}
+	return resolvers, nil
+}
+
+type userPageResolver struct {
+	users  *application.UserService
+	filter *userFilterInput
+	items  []*userResolver
+	page   int
+	limit  int
+}
+
+func (p *userPageResolver) Items() []*userResolver { return p.items }
+
+// Total sólo cuenta si la consulta lo pide.
+func (p *userPageResolver) Total(ctx context.Context) (int32, error) {
+	// List ya convirtió los filtros de atributos; Count los recibe sin convertir.
+	total, err := p.users.Count(ctx, userFilter(p.filter))
+	if err != nil {
+		return 0, resolveError(err)
+	}
+	return int32(total), nil
+}
+
+func (p *userPageResolver) Page() int32 { return int32(p.page) }
+
+func (p *userPageResolver) Limit() int32 { return int32(p.limit) }
+
+// userFilter arma los filtros de UserService.List con las mismas claves que GET /users. El
+// esquema ya validó los estados.
+func userFilter(in *userFilterInput) map[string]interface{} {
+	filter := make(map[string]interface{})
+	if in == nil {
+		return filter
+	}
+	if in.Name != nil && *in.Name != enum.EmptyString {
+		filter[enum.Name] = *in.Name
+	}
+	if in.Email != nil && *in.Email != enum.EmptyString {
+		filter[enum.Email] = *in.Email
+	}
+	if in.Status != nil && len(*in.Status) > 0 {
+		statuses := make([]string, 0, len(*in.Status))
+		for _, status := range *in.Status {
+			statuses = append(statuses, strings.ToLower(status))
+		}
+		filter[enum.Status] = statuses
+	}
+	if in.Attributes != nil && len(*in.Attributes) > 0 {
+		attrs := make(map[string]string, len(*in.Attributes))
+		for _, attr := range *in.Attributes {
+			attrs[attr.Name] = attr.Value
+		}
+		filter[enum.Attributes] = attrs
+	}
+	return filter
+}
+
+// userID acepta el ID numérico del usuario o su ID público.
+func (r *resolver) userID(ctx context.Context, id graphql.ID) (int64, error) {
+	parsed, err := r.users.ResolveUserID(ctx, string(id))
+	if err != nil {
+		return 0, resolveError(err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// ForgotPasswordRequest es el cuerpo de POST /auth/password/forgot.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest es el cuerpo de POST /auth/password/reset.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// VerifyEmailRequest es el cuerpo de POST /auth/email/verify.
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type AccountHandler struct {
+	Service *application.AccountService
+}
+
+func NewAccountHandler(svc *application.AccountService) *AccountHandler {
+	return &AccountHandler{Service: svc}
+}
+
+// Register registra los flujos de contraseña olvidada y verificación de email.
+func (h *AccountHandler) Register(e *echo.Echo) {
+	e.POST("/auth/password/forgot", h.ForgotPassword)
+	e.POST("/auth/password/reset", h.ResetPassword)
+	e.POST("/auth/email/verify", h.VerifyEmail)
+	e.POST("/users/:id/verify-email", h.RequestEmailVerification)
+}
+
+// ForgotPassword godoc
+// @Summary      Request password reset
+// @Description  Email a single-use password reset link; always answers 202 so it does not reveal which accounts exist
+// @Tags         auth
+// @Accept       json
+// @Param        body  body  handler.ForgotPasswordRequest  true  "Email"
+// @Success      202   "Accepted"
+// @Failure      400   {object}  map[string]string
+// @Router       /auth/password/forgot [post]
+func (h *AccountHandler) ForgotPassword(c echo.Context) error {
+	var req ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	h.Service.RequestPasswordReset(c.Request().Context(), req.Email)
+
+	log.Info().Int(enum.Status, http.StatusAccepted).Msg("✅ Solicitud de restablecimiento aceptada")
+	return c.NoContent(http.StatusAccepted)
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Redeem a password reset token and set a new password; every session of the user is revoked
+// @Tags         auth
+// @Accept       json
+// @Param        body  body  handler.ResetPasswordRequest  true  "Token and new password"
+// @Success      204   "No Content"
+// @Failure      400   {object}  map[string]string
+// @Router       /auth/password/reset [post]
+func (h *AccountHandler) ResetPassword(c echo.Context) error {
+	var req ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.Service.ResetPassword(c.Request().Context(), req.Token, req.Password); err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int(enum.Status, status).Msg("⚠️ Restablecimiento de contraseña rechazado")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusNoContent).Msg("✅ Contraseña restablecida")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// VerifyEmail godoc
+// @Summary      Verify email
+// @Description  Redeem an email verification token
+// @Tags         auth
+// @Accept       json
+// @Param        body  body  handler.VerifyEmailRequest  true  "Token"
+// @Success      204   "No Content"
+// @Failure      400   {object}  map[string]string
+// @Router       /auth/email/verify [post]
+func (h *AccountHandler) VerifyEmail(c echo.Context) error {
+	var req VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.Service.VerifyEmail(c.Request().Context(), req.Token); err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int(enum.Status, status).Msg("⚠️ Verificación de email rechazada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusNoContent).Msg("✅ Email verificado")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RequestEmailVerification godoc
+// @Summary      Send email verification
+// @Description  Email the user a single-use link to verify their current address
+// @Tags         users
+// @Param        id   path  string  true  "User ID or public ID"
+// @Success      202  "Accepted"
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /users/{id}/verify-email [post]
+func (h *AccountHandler) RequestEmailVerification(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	if err := h.Service.RequestEmailVerification(c.Request().Context(), id); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al enviar verificación de email")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusAccepted).Msg("✅ Verificación de email enviada")
+	return c.NoContent(http.StatusAccepted)
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type APIKeyHandler struct {
+	Service *application.APIKeyService
+}
+
+func NewAPIKeyHandler(svc *application.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{Service: svc}
+}
+
+// Register registra la administración de API keys.
+func (h *APIKeyHandler) Register(e *echo.Echo) {
+	keys := e.Group("/api-keys")
+	keys.GET("", h.List)
+	keys.GET("/:id", h.Get)
+	keys.POST("", h.Create)
+	keys.POST("/:id/rotate", h.Rotate)
+	keys.DELETE("/:id", h.Revoke)
+}
+
+// Create godoc
+// @Summary      Create API key
+// @Description  Issue an API key scoped to permissions, with optional IP allowlist and expiry; the caller must hold every permission it grants. The key is only returned once
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Param        key  body      model.APIKeyRequest  true  "API key"
+// @Success      201  {object}  model.IssuedAPIKey
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) Create(c echo.Context) error {
+	var req model.APIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	issued, err := h.Service.Create(c.Request().Context(), &req)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al crear API key")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, issued.ID).Int(enum.Status, http.StatusCreated).Msg("✅ API key creada")
+	return c.JSON(http.StatusCreated, issued)
+}
+
+// Get godoc
+// @Summary      Get API key
+// @Description  Retrieve an API key's metadata (never the key itself)
+// @Tags         api-keys
+// @Produce      json
+// @Param        id   path      int  true  "API key ID"
+// @Success      200  {object}  model.APIKey
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /api-keys/{id} [get]
+func (h *APIKeyHandler) Get(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid API key ID"})
+	}
+
+	key, err := h.Service.Get(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("⚠️ API key no encontrada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ API key encontrada")
+	return c.JSON(http.StatusOK, key)
+}
+
+// List godoc
+// @Summary      List API keys
+// @Description  List API keys, newest first, including revoked ones
+// @Tags         api-keys
+// @Produce      json
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Page size"
+// @Success      200    {array}   model.APIKey
+// @Failure      400    {object}  map[string]string
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) List(c echo.Context) error {
+	page, err := parseIntOrDefault(c.QueryParam(enum.Page), 1)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Página inválida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid page number"})
+	}
+
+	limit, err := parseIntOrDefault(c.QueryParam(enum.Limit), 10)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Límite inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+	}
+
+	keys, err := h.Service.List(c.Request().Context(), (page-1)*limit, limit)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al listar API keys")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(keys)).Msg("✅ API keys listadas")
+	return c.JSON(http.StatusOK, keys)
+}
+
+// Rotate godoc
+// @Summary      Rotate API key
+// @Description  Issue a new secret for the key; with grace_seconds the previous one keeps working for that long
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true   "API key ID"
+// @Param        body  body      model.APIKeyRotation  false  "Grace period"
+// @Success      200   {object}  model.IssuedAPIKey
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) Rotate(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid API key ID"})
+	}
+
+	// El cuerpo es opcional: sin él la clave anterior deja de valer de inmediato.
+	var req model.APIKeyRotation
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+		}
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	issued, err := h.Service.Rotate(c.Request().Context(), id, time.Duration(req.GraceSeconds)*time.Second)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al rotar API key")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ API key rotada")
+	return c.JSON(http.StatusOK, issued)
+}
+
+// Revoke godoc
+// @Summary      Revoke API key
+// @Description  Revoke an API key immediately
+// @Tags         api-keys
+// @Param        id   path  int  true  "API key ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid API key ID"})
+	}
+
+	if err := h.Service.Revoke(c.Request().Context(), id); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al revocar API key")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ API key revocada")
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type AttributeHandler struct {
+	Service *application.AttributeService
+}
+
+func NewAttributeHandler(svc *application.AttributeService) *AttributeHandler {
+	return &AttributeHandler{Service: svc}
+}
+
+// Register registra las rutas de definiciones de atributos.
+func (h *AttributeHandler) Register(e *echo.Echo) {
+	attributes := e.Group("/attributes")
+	attributes.GET("", h.List)
+	attributes.GET("/:name", h.Get)
+	attributes.POST("", h.Create)
+	attributes.DELETE("/:name", h.Delete)
+}
+
+// Get godoc
+// @Summary      Get attribute definition
+// @Description  Retrieve a custom attribute definition by name
+// @Tags         attributes
+// @Produce      json
+// @Param        name  path      string  true  "Attribute name"
+// @Success      200   {object}  model.AttributeDefinition
+// @Failure      404   {object}  map[string]string
+// @Router       /attributes/{name} [get]
+func (h *AttributeHandler) Get(c echo.Context) error {
+	def, err := h.Service.Get(c.Request().Context(), c.Param(enum.Name))
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int(enum.Status, status).Msg("⚠️ Definición de atributo no encontrada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Str(enum.Name, def.Name).Msg("✅ Definición de atributo encontrada")
+	return c.JSON(http.StatusOK, def)
+}
+
+// Create godoc
+// @Summary      Register attribute definition
+// @Description  Register a custom attribute (name, type, required, enum values, regex pattern). A required attribute can only be added while there are no users
+// @Tags         attributes
+// @Accept       json
+// @Produce      json
+// @Param        attribute  body      model.AttributeDefinition  true  "Attribute definition"
+// @Success      201        {object}  model.AttributeDefinition
+// @Failure      400        {object}  map[string]string
+// @Failure      409        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /attributes [post]
+func (h *AttributeHandler) Create(c echo.Context) error {
+	var def model.AttributeDefinition
+	if err := c.Bind(&def); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&def); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if _, err := h.Service.Create(c.Request().Context(), &def); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al registrar definición de atributo")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, def.ID).Int(enum.Status, http.StatusCreated).Msg("✅ Definición de atributo registrada")
+	return c.JSON(http.StatusCreated, def)
+}
+
+// Delete godoc
+// @Summary      Delete attribute definition
+// @Description  Delete a custom attribute definition and remove the attribute from every user
+// @Tags         attributes
+// @Produce      json
+// @Param        name  path  string  true  "Attribute name"
+// @Success      204   "No Content"
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /attributes/{name} [delete]
+func (h *AttributeHandler) Delete(c echo.Context) error {
+	name := c.Param(enum.Name)
+	if err := h.Service.Delete(c.Request().Context(), name); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al eliminar definición de atributo")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Str(enum.Name, name).Int(enum.Status, http.StatusNoContent).Msg("✅ Definición de atributo eliminada")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// List godoc
+// @Summary      List attribute definitions
+// @Description  Retrieve every registered custom attribute definition
+// @Tags         attributes
+// @Produce      json
+// @Success      200  {array}   model.AttributeDefinition
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes [get]
+func (h *AttributeHandler) List(c echo.Context) error {
+	defs, err := h.Service.List(c.Request().Context())
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al listar definiciones de atributos")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(defs)).Msg("✅ Definiciones de atributos listadas")
+	return c.JSON(http.StatusOK, defs)
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// LoginRequest es el cuerpo de POST /auth/login.
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// PasswordChangeRequest es el cuerpo de PUT /users/{id}/password.
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" validate:"required"`
+}
+
+type AuthHandler struct {
+	Service *application.AuthService
+}
+
+func NewAuthHandler(svc *application.AuthService) *AuthHandler {
+	return &AuthHandler{Service: svc}
+}
+
+// Register registra el inicio y cierre de sesión, la renovación de tokens, el cambio de
+// contraseña y la gestión de sesiones de cada usuario.
+func (h *AuthHandler) Register(e *echo.Echo) {
+	e.POST("/auth/login", h.Login)
+	e.POST("/auth/refresh", h.Refresh)
+	e.POST("/auth/logout", h.Logout)
+	e.PUT("/users/:id/password", h.SetPassword)
+	e.GET("/users/:id/sessions", h.Sessions)
+	e.DELETE("/users/:id/sessions", h.RevokeSessions)
+	e.DELETE("/users/:id/sessions/:sessionID", h.RevokeSession)
+}
+
+// Login godoc
+// @Summary      Log in
+// @Description  Authenticate with email and password and receive access and refresh tokens
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      handler.LoginRequest  true  "Credentials"
+// @Success      200          {object}  model.TokenPair
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req LoginRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	tokens, err := h.Service.Login(c.Request().Context(), req.Email, req.Password, clientInfo(c))
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Str(enum.Email, req.Email).Str("ip", c.RealIP()).Int(enum.Status, status).Msg("🔒 Inicio de sesión rechazado")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Str(enum.Email, req.Email).Int(enum.Status, http.StatusOK).Msg("✅ Inicio de sesión correcto")
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// SetPassword godoc
+// @Summary      Set user password
+// @Description  Change a user's password; users changing their own must send the current one. Every other session of the user is revoked
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string                         true  "User ID or public ID"
+// @Param        body  body  handler.PasswordChangeRequest  true  "Passwords"
+// @Success      204   "No Content"
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Router       /users/{id}/password [put]
+func (h *AuthHandler) SetPassword(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	var req PasswordChangeRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.Service.SetPassword(c.Request().Context(), id, req.CurrentPassword, req.Password); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al cambiar contraseña")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ Contraseña actualizada")
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,394 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/infrastructure/http/middleware"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxBatchOperations es el máximo de operaciones de una petición a POST /batch.
+const MaxBatchOperations = 50
+
+// batchExcluded son las rutas que no se pueden llamar desde POST /batch: el propio /batch y el
+// stream de usuarios, que no termina nunca.
+var batchExcluded = []string{"/batch", "/users/stream"}
+
+var (
+	operationID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// reference es {{<id>.<campo>[.<campo>...]}}; los campos recorren el JSON de la respuesta
+	// de la operación <id> y pueden ser índices de arrays.
+	reference       = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)((?:\.[A-Za-z0-9_-]+)+)\s*\}\}`)
+	quotedReference = regexp.MustCompile(`"\{\{\s*([A-Za-z0-9_-]+)((?:\.[A-Za-z0-9_-]+)+)\s*\}\}"`)
+
+	errUnknownOperation = errors.New("unknown operation")
+	errFailedOperation  = errors.New("referenced operation failed")
+	errUnknownField     = errors.New("field not found in response")
+)
+
+// BatchRequest es el cuerpo de POST /batch.
+type BatchRequest struct {
+	// Atomic ejecuta las operaciones en una transacción: si una falla, no se guarda ninguna.
+	Atomic     bool             `json:"atomic"`
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,max=50,dive"`
+}
+
+// BatchOperation es una petición a la API. Path, Headers y Body pueden usar {{<id>.<campo>}}
+// para tomar un valor de la respuesta de una operación anterior, p. ej. "/users/{{nuevo.id}}".
+type BatchOperation struct {
+	ID      string            `json:"id,omitempty" validate:"max=64"`
+	Method  string            `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"`
+	Path    string            `json:"path" validate:"required,startswith=/"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty" swaggertype:"object"`
+}
+
+// BatchResult es la respuesta de una operación.
+type BatchResult struct {
+	ID      string            `json:"id,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty" swaggertype:"object"`
+}
+
+// BatchResponse es la respuesta de POST /batch.
+type BatchResponse struct {
+	// Committed sólo se informa en modo atómico: false significa que no se guardó ninguna operación.
+	Committed *bool         `json:"committed,omitempty"`
+	Results   []BatchResult `json:"results"`
+}
+
+// BatchTx es la transacción de una petición atómica; las rutas de Registrars escriben en ella.
+type BatchTx struct {
+	Registrars []RouteRegistrar
+	Commit     func() error
+	Rollback   func() error
+}
+
+// BatchTxFactory abre la transacción de una petición atómica.
+type BatchTxFactory func() (*BatchTx, error)
+
+type BatchHandler struct {
+	begin  BatchTxFactory
+	router *echo.Echo
+}
+
+func NewBatchHandler(begin BatchTxFactory) *BatchHandler {
+	return &BatchHandler{begin: begin}
+}
+
+// Register registra POST /batch; las operaciones no atómicas pasan por el mismo router.
+func (h *BatchHandler) Register(e *echo.Echo) {
+	h.router = e
+	e.POST("/batch", h.Execute)
+}
+
+// Execute godoc
+// @Summary      Run several operations in one request
+// @Description  Dispatch up to 50 API requests in order with the caller's credentials and return the response of each one. Path, headers and body may reference a field of an earlier response with {{<operation id>.<field>}}, e.g. "/users/{{newUser.id}}". With atomic=true every operation runs in a single transaction and the first failure rolls all of them back; atomic mode only supports /users, /groups and the generic resources. Operations after a failed one, or referencing a failed one, get status 424.
+// @Tags         batch
+// @Accept       json
+// @Produce      json
+// @Param        request  body      BatchRequest  true  "Operations"
+// @Success      200      {object}  BatchResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /batch [post]
+func (h *BatchHandler) Execute(c echo.Context) error {
+	var req BatchRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+	for i := range req.Operations {
+		req.Operations[i].Method = strings.ToUpper(req.Operations[i].Method)
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+	if err := checkOperations(req.Operations); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	router := h.router
+	var tx *BatchTx
+	if req.Atomic {
+		var err error
+		if tx, err = h.begin(); err != nil {
+			log.Error().Err(err).Int(enum.Status, http.StatusInternalServerError).Msg("❌ Error al abrir transacción del lote")
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "internal server error"})
+		}
+		defer tx.Rollback()
+		router = h.txRouter(tx)
+	}
+
+	parent := c.Request()
+	if principal, ok := middleware.PrincipalFrom(c); ok {
+		parent = parent.WithContext(middleware.WithBatchPrincipal(parent.Context(), principal))
+	}
+	results, failed := h.run(parent, router, req)
+
+	res := BatchResponse{Results: results}
+	if req.Atomic {
+		committed := failed < 0
+		if committed {
+			if err := tx.Commit(); err != nil {
+				log.Error().Err(err).Int(enum.Status, http.StatusInternalServerError).Msg("❌ Error al confirmar lote")
+				return c.JSON(http.StatusInternalServerError, echo.Map{"error": "internal server error"})
+			}
+		} else {
+			log.Warn().Int("failed", failed).Msg("⚠️ Lote atómico revertido")
+		}
+		res.Committed = &committed
+	}
+
+	log.Info().Int(enum.Operations, len(results)).Bool(enum.Atomic, req.Atomic).Int(enum.Status, http.StatusOK).Msg("✅ Lote ejecutado")
+	return c.JSON(http.StatusOK, res)
+}
+
+// run ejecuta las operaciones en orden y devuelve sus resultados y el índice de la primera que
+// falló, o -1. En modo atómico no ejecuta ninguna después de un fallo.
+func (h *BatchHandler) run(parent *http.Request, router http.Handler, req BatchRequest) ([]BatchResult, int) {
+	results := make([]BatchResult, len(req.Operations))
+	responses := make(map[string]*BatchResult, len(req.Operations))
+	failed := -1
+
+	for i, op := range req.Operations {
+		if req.Atomic && failed >= 0 {
+			results[i] = errorResult(op.ID, http.StatusFailedDependency, fmt.Sprintf("not executed: operation %d failed", failed))
+			continue
+		}
+
+		results[i] = dispatch(parent, router, op, responses)
+		if op.ID != "" {
+			responses[op.ID] = &results[i]
+		}
+		if !succeeded(results[i].Status) && failed < 0 {
+			failed = i
+		}
+	}
+	return results, failed
+}
+
+// txRouter arma un router con las rutas que escriben en la transacción del lote y los mismos
+// middlewares que el principal, salvo Auth: el principal llega en el contexto de cada operación.
+func (h *BatchHandler) txRouter(tx *BatchTx) *echo.Echo {
+	e := echo.New()
+	e.Validator = h.router.Validator
+	e.IPExtractor = h.router.IPExtractor
+	e.Use(middleware.Base()...)
+	e.Use(middleware.BatchAuth())
+	for _, registrar := range tx.Registrars {
+		registrar.Register(e)
+	}
+	return e
+}
+
+// dispatch resuelve las referencias de op y la envía al router con el contexto de la petición
+// original, que lleva el principal y el idioma.
+func dispatch(parent *http.Request, router http.Handler, op BatchOperation, responses map[string]*BatchResult) BatchResult {
+	path, err := resolve(op.Path, responses, url.PathEscape)
+	if err != nil {
+		return referenceError(op.ID, err)
+	}
+	body, err := resolveBody(op.Body, responses)
+	if err != nil {
+		return referenceError(op.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(parent.Context(), op.Method, path, bytes.NewReader(body))
+	if err != nil {
+		return errorResult(op.ID, http.StatusBadRequest, "invalid path")
+	}
+	req.RemoteAddr = parent.RemoteAddr
+	for name, value := range op.Headers {
+		if value, err = resolve(value, responses, nil); err != nil {
+			return referenceError(op.ID, err)
+		}
+		req.Header.Set(name, value)
+	}
+	if len(body) > 0 && req.Header.Get(echo.HeaderContentType) == enum.EmptyString {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	result := BatchResult{ID: op.ID, Status: rec.Code, Body: responseBody(rec.Body.Bytes())}
+	for name := range rec.Header() {
+		if result.Headers == nil {
+			result.Headers = make(map[string]string)
+		}
+		result.Headers[name] = rec.Header().Get(name)
+	}
+	log.Debug().Str("method", op.Method).Str("path", path).Int(enum.Status, rec.Code).Msg("🔁 Operación del lote ejecutada")
+	return result
+}
+
+// checkOperations valida lo que el validador no puede: ids únicos y rutas permitidas.
+func checkOperations(ops []BatchOperation) error {
+	ids := make(map[string]bool, len(ops))
+	for i, op := range ops {
+		if op.ID != "" {
+			if !operationID.MatchString(op.ID) {
+				return fmt.Errorf("operation %d: id may only contain letters, digits, '_' and '-'", i)
+			}
+			if ids[op.ID] {
+				return fmt.Errorf("operation %d: duplicate id %q", i, op.ID)
+			}
+			ids[op.ID] = true
+		}
+		if strings.HasPrefix(op.Path, "//") {
+			return fmt.Errorf("operation %d: path must be relative to the API", i)
+		}
+		path, _, _ := strings.Cut(op.Path, "?")
+		for _, excluded := range batchExcluded {
+			if path == excluded || strings.HasPrefix(path, excluded+"/") {
+				return fmt.Errorf("operation %d: %s is not allowed in a batch", i, excluded)
+			}
+		}
+	}
+	return nil
+}
+
+// resolve reemplaza las referencias de s; escape, si se indica, se aplica a cada valor.
+func resolve(s string, responses map[string]*BatchResult, escape func(string) string) (string, error) {
+	var err error
+	resolved := reference.ReplaceAllStringFunc(s, func(match string) string {
+		value, lookupErr := lookup(reference.FindStringSubmatch(match), responses)
+		if lookupErr != nil {
+			err = lookupErr
+			return match
+		}
+		text := valueString(value)
+		if escape != nil {
+			text = escape(text)
+		}
+		return text
+	})
+	return resolved, err
+}
+
+// resolveBody reemplaza las referencias del JSON. Una referencia que ocupa todo un string toma
+// el tipo del valor referenciado ("{{nuevo.id}}" pasa a ser un número); dentro de un string se
+// inserta como texto.
+func resolveBody(body json.RawMessage, responses map[string]*BatchResult) ([]byte, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	var err error
+	resolved := quotedReference.ReplaceAllFunc(body, func(match []byte) []byte {
+		value, lookupErr := lookup(quotedReference.FindSubmatch(match), responses)
+		if lookupErr != nil {
+			err = lookupErr
+			return match
+		}
+		encoded, _ := json.Marshal(value)
+		return encoded
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved = reference.ReplaceAllFunc(resolved, func(match []byte) []byte {
+		value, lookupErr := lookup(reference.FindSubmatch(match), responses)
+		if lookupErr != nil {
+			err = lookupErr
+			return match
+		}
+		encoded, _ := json.Marshal(valueString(value))
+		return encoded[1 : len(encoded)-1]
+	})
+	return resolved, err
+}
+
+// lookup busca el valor de una referencia ya separada en {match, id, .campo.campo}.
+func lookup[S ~string | ~[]byte](groups []S, responses map[string]*BatchResult) (interface{}, error) {
+	id, path := string(groups[1]), strings.Split(strings.TrimPrefix(string(groups[2]), "."), ".")
+
+	res, ok := responses[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownOperation, id)
+	}
+	if !succeeded(res.Status) {
+		return nil, fmt.Errorf("%w: %q", errFailedOperation, id)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(res.Body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: %s.%s", errUnknownField, id, strings.Join(path, "."))
+	}
+
+	for _, field := range path {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			value, ok = node[field]
+		case []interface{}:
+			index, err := strconv.Atoi(field)
+			ok = err == nil && index >= 0 && index < len(node)
+			if ok {
+				value = node[index]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.%s", errUnknownField, id, strings.Join(path, "."))
+		}
+	}
+	return value, nil
+}
+
+func valueString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// responseBody devuelve el cuerpo tal cual si es JSON; si no, como un string JSON.
+func responseBody(body []byte) json.RawMessage {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return body
+	}
+	encoded, _ := json.Marshal(string(body))
+	return encoded
+}
+
+func referenceError(id string, err error) BatchResult {
+	status := http.StatusBadRequest
+	if errors.Is(err, errFailedOperation) {
+		status = http.StatusFailedDependency
+	}
+	return errorResult(id, status, err.Error())
+}
+
+func errorResult(id string, status int, msg string) BatchResult {
+	body, _ := json.Marshal(echo.Map{"error": msg})
+	return BatchResult{ID: id, Status: status, Body: body}
+}
+
+func succeeded(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// RouteRegistrar es implementado por los handlers que registran sus propias rutas.
+// El servidor recibe todos los registrars del grupo "routes" del contenedor.
+type RouteRegistrar interface {
+	Register(e *echo.Echo)
+}
+
+// CRUDUseCase es el contrato que el handler genérico espera del caso de uso.
+// Lo cumplen application.CRUDService y los servicios específicos como UserService.
+type CRUDUseCase[T any, ID comparable] interface {
+	Get(ctx context.Context, id ID) (*T, error)
+	Create(ctx context.Context, entity *T) (ID, error)
+	Update(ctx context.Context, entity *T) error
+	Delete(ctx context.Context, id ID) error
+	List(ctx context.Context, offset, limit int, filter map[string]interface{}) ([]*T, error)
+}
+
+// FieldSelectingUseCase lo implementan los casos de uso que además admiten ?fields= en Get y
+// List (p. ej. UserService). Con otros casos de uso el parámetro se rechaza.
+type FieldSelectingUseCase[T any, ID comparable] interface {
+	GetFields(ctx context.Context, id ID, fields []string) (*T, error)
+	ListFields(ctx context.Context, offset, limit int, filter map[string]interface{}, fields []string) ([]*T, error)
+}
+
+// CRUDOptions configura un CRUDHandler.
+type CRUDOptions[T any, ID comparable] struct {
+	// Resource es el nombre singular del recurso, usado en mensajes y logs (p. ej. "user").
+	Resource string
+	// Path es la ruta base del recurso (p. ej. "/users").
+	Path string
+	// Filters son los query params que se pasan tal cual como filtros de List.
+	Filters []string
+	// ListFilters agrega filtros propios del recurso; se invoca después de Filters.
+	ListFilters func(c echo.Context, filters map[string]interface{}) error
+	// ParseID convierte el parámetro de ruta en ID; por defecto se admiten IDs int64 y string.
+	ParseID func(string) (ID, error)
+	// ResolveID, si se indica, reemplaza a ParseID cuando convertir el parámetro necesita el caso
+	// de uso (p. ej. el ID público de un usuario); si no encuentra el registro, responde 404.
+	ResolveID func(ctx context.Context, raw string) (ID, error)
+	// SetID asigna el ID a la entidad; por defecto se usa el campo ID.
+	SetID func(*T, ID)
+}
+
+// CRUDHandler expone Get, Create, Update, Delete y List para cualquier entidad.
+type CRUDHandler[T any, ID comparable] struct {
+	Service CRUDUseCase[T, ID]
+	opts    CRUDOptions[T, ID]
+}
+
+func NewCRUDHandler[T any, ID comparable](svc CRUDUseCase[T, ID], opts CRUDOptions[T, ID]) *CRUDHandler[T, ID] {
+	if opts.ParseID == nil {
+		opts.ParseID = defaultParseID[ID]
+	}
+	if opts.SetID == nil {
+		opts.SetID = defaultSetID[T, ID]
+	}
+	return &CRUDHandler[T, ID]{Service: svc, opts: opts}
+}
+
+// Register registra las rutas CRUD bajo opts.Path.
+func (h *CRUDHandler[T, ID]) Register(e *echo.Echo) {
+	h.RegisterOn(e.Group(h.opts.Path))
+}
+
+// RegisterOn registra las rutas CRUD en un grupo existente.
+func (h *CRUDHandler[T, ID]) RegisterOn(g *echo.Group) {
+	g.GET("", h.List)
+	g.GET("/:id", h.Get)
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+}
+
+func (h *CRUDHandler[T, ID]) Get(c echo.Context) error {
+	id, err := h.id(c)
+	if err != nil {
+		return h.invalidID(c, err)
+	}
+
+	fields, err := h.fields(c)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Campos inválidos")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	var entity *T
+	if fields != nil {
+		entity, err = h.Service.(FieldSelectingUseCase[T, ID]).GetFields(c.Request().Context(), id, fields)
+	} else {
+		entity, err = h.Service.Get(c.Request().Context(), id)
+	}
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Str(enum.Resource, h.opts.Resource).Int(enum.Status, status).Msg("⚠️ Registro no encontrado")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Str(enum.Resource, h.opts.Resource).Int(enum.Status, http.StatusOK).Interface(enum.ID, id).Msg("✅ Registro encontrado")
+	if fields == nil {
+		return c.JSON(http.StatusOK, entity)
+	}
+	return c.JSON(http.StatusOK, selectFields(entity, fields))
+}
+
+func (h *CRUDHandler[T, ID]) Create(c echo.Context) error {
+	entity := new(T)
+	if err := c.Bind(entity); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(entity); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	id, err := h.Service.Create(c.Request().Context(), entity)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Str(enum.Resource, h.opts.Resource).Int(enum.Status, status).Msg("❌ Error al crear registro")
+		return respondError(c, status, err)
+	}
+	h.opts.SetID(entity, id)
+
+	log.Info().Str(enum.Resource, h.opts.Resource).Interface(enum.ID, id).Int(enum.Status, http.StatusCreated).Msg("✅ Registro creado")
+	return c.JSON(http.StatusCreated, entity)
+}
+
+func (h *CRUDHandler[T, ID]) Update(c echo.Context) error {
+	id, err := h.id(c)
+	if err != nil {
+		return h.invalidID(c, err)
+	}
+
+	entity := new(T)
+	if err := c.Bind(entity); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(entity); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	h.opts.SetID(entity, id)
+	if err := h.Service.Update(c.Request().Context(), entity); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Str(enum.Resource, h.opts.Resource).Int(enum.Status, status).Msg("❌ Error al actualizar registro")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Str(enum.Resource, h.opts.Resource).Interface(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ Registro actualizado")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *CRUDHandler[T, ID]) Delete(c echo.Context) error {
+	id, err := h.id(c)
+	if err != nil {
+		return h.invalidID(c, err)
+	}
+
+	if err := h.Service.Delete(c.Request().Context(), id); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Str(enum.Resource, h.opts.Resource).Int(enum.Status, status).Msg("❌ Error al eliminar registro")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Str(enum.Resource, h.opts.Resource).Interface(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ Registro eliminado")
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CRUDHandler[T, ID]) List(c echo.Context) error {
+	filters := make(map[string]interface{})
+	for _, key := range h.opts.Filters {
+		if value := c.QueryParam(key); value != enum.EmptyString {
+			filters[key] = value
+		}
+	}
+	if h.opts.ListFilters != nil {
+		if err := h.opts.ListFilters(c, filters); err != nil {
+			log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Filtro inválido")
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+	}
+
+	page, err := parseIntOrDefault(c.QueryParam(enum.Page), 1)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Página inválida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid page number"})
+	}
+
+	limit, err := parseIntOrDefault(c.QueryParam(enum.Limit), 10)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Límite inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+	}
+
+	fields, err := h.fields(c)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Campos inválidos")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	offset := (page - 1) * limit
+	var items []*T
+	if fields != nil {
+		items, err = h.Service.(FieldSelectingUseCase[T, ID]).ListFields(c.Request().Context(), offset, limit, filters, fields)
+	} else {
+		items, err = h.Service.List(c.Request().Context(), offset, limit, filters)
+	}
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Str(enum.Resource, h.opts.Resource).Int(enum.Status, status).Msg("❌ Error al listar registros")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Str(enum.Resource, h.opts.Resource).Int(enum.Status, http.StatusOK).Int(enum.Total, len(items)).Msg("✅ Registros listados")
+	if fields == nil {
+		return c.JSON(http.StatusOK, items)
+	}
+	projected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		projected = append(projected, selectFields(item, fields))
+	}
+	return c.JSON(http.StatusOK, projected)
+}
+
+// fields lee ?fields=; devuelve nil si no se indicó y un error si el caso de uso no admite elegir campos.
+func (h *CRUDHandler[T, ID]) fields(c echo.Context) ([]string, error) {
+	fields := parseFields(c.QueryParam(enum.Fields))
+	if fields == nil {
+		return nil, nil
+	}
+	if _, ok := h.Service.(FieldSelectingUseCase[T, ID]); !ok {
+		return nil, fmt.Errorf("field selection is not supported for %s", h.opts.Resource)
+	}
+	return fields, nil
+}
+
+// id lee el parámetro :id con ResolveID o ParseID.
+func (h *CRUDHandler[T, ID]) id(c echo.Context) (ID, error) {
+	if h.opts.ResolveID != nil {
+		return h.opts.ResolveID(c.Request().Context(), c.Param(enum.ID))
+	}
+	return h.opts.ParseID(c.Param(enum.ID))
+}
+
+func (h *CRUDHandler[T, ID]) invalidID(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrNotFound) {
+		log.Warn().Err(err).Str(enum.Resource, h.opts.Resource).Int(enum.Status, http.StatusNotFound).Msg("⚠️ Registro no encontrado")
+		return respondError(c, http.StatusNotFound, err)
+	}
+	log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+	return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid " + h.opts.Resource + " ID"})
+}
+
+// defaultParseID admite IDs numéricos (int64) y de texto.
+func defaultParseID[ID comparable](raw string) (ID, error) {
+	var id ID
+	switch p := any(&id).(type) {
+	case *int64:
+		n, err := parseID(raw)
+		if err != nil {
+			return id, err
+		}
+		*p = n
+	case *string:
+		if raw == enum.EmptyString {
+			return id, fmt.Errorf("missing ID")
+		}
+		*p = raw
+	default:
+		return id, fmt.Errorf("unsupported ID type %T", id)
+	}
+	return id, nil
+}
+
+// defaultSetID asigna el ID al campo exportado "ID" de la entidad.
+func defaultSetID[T any, ID comparable](entity *T, id ID) {
+	field := reflect.ValueOf(entity).Elem().FieldByName("ID")
+	if field.IsValid() && field.CanSet() && field.Type() == reflect.TypeOf(id) {
+		field.Set(reflect.ValueOf(id))
+	}
+}
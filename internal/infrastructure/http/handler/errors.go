@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/labstack/echo/v4"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem es el cuerpo de error RFC 7807 que se devuelve cuando falta un permiso.
+type Problem struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Status     int    `json:"status"`
+	Detail     string `json:"detail"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// statusCodeFor traduce errores de dominio a códigos HTTP según su categoría.
+func statusCodeFor(err error) int {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, model.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondError escribe el error del caso de uso: los permisos faltantes se devuelven como
+// problem+json con el permiso requerido y el resto con el cuerpo {"error": ...} habitual.
+func respondError(c echo.Context, status int, err error) error {
+	var forbidden *model.ForbiddenError
+	if errors.As(err, &forbidden) {
+		c.Response().Header().Set(echo.HeaderContentType, problemContentType)
+		c.Response().WriteHeader(http.StatusForbidden)
+		return json.NewEncoder(c.Response()).Encode(Problem{
+			Type:       "about:blank",
+			Title:      http.StatusText(http.StatusForbidden),
+			Status:     http.StatusForbidden,
+			Detail:     forbidden.Error(),
+			Permission: string(forbidden.Permission),
+		})
+	}
+	return c.JSON(status, echo.Map{"error": err.Error()})
+}
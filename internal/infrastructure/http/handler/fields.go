@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// parseFields lee la lista de campos de ?fields=id,name sin vacíos ni repetidos; nil si no hay ninguno.
+func parseFields(value string) []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, raw := range strings.Split(value, ",") {
+		field := strings.TrimSpace(raw)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// selectFields devuelve el JSON de v con sólo los campos indicados; los que v omite salen como null.
+// El caso de uso ya validó los nombres contra el modelo.
+func selectFields(v interface{}, fields []string) map[string]json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if raw, ok := all[field]; ok {
+			selected[field] = raw
+		} else {
+			selected[field] = json.RawMessage("null")
+		}
+	}
+	return selected
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/infrastructure/graphql"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// GraphQLRequest es el cuerpo de POST /graphql.
+type GraphQLRequest struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type GraphQLHandler struct {
+	API *graphql.API
+}
+
+func NewGraphQLHandler(api *graphql.API) *GraphQLHandler {
+	return &GraphQLHandler{API: api}
+}
+
+// Register registra el endpoint GraphQL y su esquema.
+func (h *GraphQLHandler) Register(e *echo.Echo) {
+	e.POST("/graphql", h.Query)
+	e.GET("/graphql/schema", h.Schema)
+}
+
+// Query godoc
+// @Summary      Execute a GraphQL operation
+// @Description  Run a query or mutation against the users schema. Errors from the use cases come back in the errors array with extensions.code (NOT_FOUND, BAD_USER_INPUT, CONFLICT, UNAUTHENTICATED, FORBIDDEN, INTERNAL_SERVER_ERROR).
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        request  body      GraphQLRequest  true  "GraphQL request"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]string
+// @Router       /graphql [post]
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	var req GraphQLRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	res := h.API.Exec(c.Request().Context(), req.Query, req.OperationName, req.Variables)
+	if len(res.Errors) > 0 {
+		log.Warn().Str("operation", req.OperationName).Int("errors", len(res.Errors)).Str("error", res.Errors[0].Message).
+			Msg("⚠️ Operación GraphQL con errores")
+	} else {
+		log.Info().Str("operation", req.OperationName).Int(enum.Status, http.StatusOK).Msg("✅ Operación GraphQL ejecutada")
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Schema godoc
+// @Summary      GraphQL schema
+// @Description  The schema in SDL, for client code generators
+// @Tags         graphql
+// @Produce      plain
+// @Success      200  {string}  string
+// @Router       /graphql/schema [get]
+func (h *GraphQLHandler) Schema(c echo.Context) error {
+	return c.String(http.StatusOK, h.API.SDL())
+}
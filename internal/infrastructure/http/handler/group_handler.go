@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type GroupHandler struct {
+	Service *application.GroupService
+	crud    *CRUDHandler[model.Group, int64]
+}
+
+func NewGroupHandler(svc *application.GroupService) *GroupHandler {
+	return &GroupHandler{
+		Service: svc,
+		crud: NewCRUDHandler[model.Group, int64](svc, CRUDOptions[model.Group, int64]{
+			Resource: "group",
+			Path:     "/groups",
+			Filters:  []string{enum.Name},
+		}),
+	}
+}
+
+// Register registra las rutas CRUD de grupos, las de membresía y /users/:id/groups.
+func (h *GroupHandler) Register(e *echo.Echo) {
+	groups := e.Group("/groups")
+	groups.GET("", h.List)
+	groups.GET("/:id", h.Get)
+	groups.POST("", h.Create)
+	groups.PUT("/:id", h.Update)
+	groups.DELETE("/:id", h.Delete)
+	groups.GET("/:id/members", h.Members)
+	groups.POST("/:id/members", h.AddMembers)
+	groups.DELETE("/:id/members", h.RemoveMembers)
+
+	e.GET("/users/:id/groups", h.UserGroups)
+}
+
+// Get godoc
+// @Summary      Get group by ID
+// @Description  Retrieve a group using its ID
+// @Tags         groups
+// @Produce      json
+// @Param        id   path      int  true  "Group ID"
+// @Success      200  {object}  model.Group
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /groups/{id} [get]
+func (h *GroupHandler) Get(c echo.Context) error {
+	return h.crud.Get(c)
+}
+
+// Create godoc
+// @Summary      Create new group
+// @Description  Create a new group with name, description and roles
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        group  body      model.Group  true  "Group data"
+// @Success      201    {object}  model.Group
+// @Failure      400    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /groups [post]
+func (h *GroupHandler) Create(c echo.Context) error {
+	return h.crud.Create(c)
+}
+
+// Update godoc
+// @Summary      Update group
+// @Description  Update group by ID
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int          true  "Group ID"
+// @Param        group  body      model.Group  true  "Updated group"
+// @Success      200    "No Content"
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /groups/{id} [put]
+func (h *GroupHandler) Update(c echo.Context) error {
+	return h.crud.Update(c)
+}
+
+// Delete godoc
+// @Summary      Delete group
+// @Description  Delete a group by ID; memberships are removed too
+// @Tags         groups
+// @Produce      json
+// @Param        id   path      int  true  "Group ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /groups/{id} [delete]
+func (h *GroupHandler) Delete(c echo.Context) error {
+	return h.crud.Delete(c)
+}
+
+// List godoc
+// @Summary      List groups
+// @Description  Retrieve paginated and filtered list of groups
+// @Tags         groups
+// @Produce      json
+// @Param        name   query     string  false  "Filter by name"
+// @Param        page   query     int     false  "Page number"
+// @Param        limit  query     int     false  "Items per page"
+// @Success      200    {array}   model.Group
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /groups [get]
+func (h *GroupHandler) List(c echo.Context) error {
+	return h.crud.List(c)
+}
+
+// Members godoc
+// @Summary      List group members
+// @Description  Retrieve the users that belong to a group
+// @Tags         groups
+// @Produce      json
+// @Param        id   path      int  true  "Group ID"
+// @Success      200  {array}   model.User
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /groups/{id}/members [get]
+func (h *GroupHandler) Members(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid group ID"})
+	}
+
+	users, err := h.Service.Members(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al listar miembros del grupo")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(users)).Msg("✅ Miembros del grupo listados")
+	return c.JSON(http.StatusOK, users)
+}
+
+// AddMembers godoc
+// @Summary      Add group members
+// @Description  Add one or more users to a group
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        id       path  int                        true  "Group ID"
+// @Param        members  body  model.GroupMembersRequest  true  "User IDs or public IDs"
+// @Success      204      "No Content"
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /groups/{id}/members [post]
+func (h *GroupHandler) AddMembers(c echo.Context) error {
+	return h.changeMembers(c, h.Service.AddMembers, "✅ Miembros agregados al grupo")
+}
+
+// RemoveMembers godoc
+// @Summary      Remove group members
+// @Description  Remove one or more users from a group
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        id       path  int                        true  "Group ID"
+// @Param        members  body  model.GroupMembersRequest  true  "User IDs or public IDs"
+// @Success      204      "No Content"
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /groups/{id}/members [delete]
+func (h *GroupHandler) RemoveMembers(c echo.Context) error {
+	return h.changeMembers(c, h.Service.RemoveMembers, "✅ Miembros quitados del grupo")
+}
+
+// UserGroups godoc
+// @Summary      List groups of a user
+// @Description  Retrieve the groups a user belongs to
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID or public ID"
+// @Success      200  {array}   model.Group
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/{id}/groups [get]
+func (h *GroupHandler) UserGroups(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	groups, err := h.Service.GroupsOfUser(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al listar grupos del usuario")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(groups)).Msg("✅ Grupos del usuario listados")
+	return c.JSON(http.StatusOK, groups)
+}
+
+func (h *GroupHandler) changeMembers(c echo.Context, apply func(context.Context, int64, []model.UserRef) error, okMsg string) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid group ID"})
+	}
+
+	var req model.GroupMembersRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := apply(c.Request().Context(), id, req.UserIDs); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al modificar miembros del grupo")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Total, len(req.UserIDs)).Int(enum.Status, http.StatusNoContent).Msg(okMsg)
+	return c.NoContent(http.StatusNoContent)
+}
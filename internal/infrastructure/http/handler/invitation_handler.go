@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type InvitationHandler struct {
+	Service *application.InvitationService
+}
+
+func NewInvitationHandler(svc *application.InvitationService) *InvitationHandler {
+	return &InvitationHandler{Service: svc}
+}
+
+// Register registra la administración de invitaciones y el endpoint público para aceptarlas.
+func (h *InvitationHandler) Register(e *echo.Echo) {
+	invitations := e.Group("/invitations")
+	invitations.GET("", h.List)
+	invitations.GET("/:id", h.Get)
+	invitations.POST("", h.Create)
+	invitations.POST("/:id/resend", h.Resend)
+	invitations.DELETE("/:id", h.Revoke)
+
+	e.POST("/auth/invitations/accept", h.Accept)
+}
+
+// Create godoc
+// @Summary      Invite user
+// @Description  Create a user in invited state and email them a link to accept the invitation
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        invitation  body      model.InvitationRequest  true  "Invitee"
+// @Success      201         {object}  model.Invitation
+// @Failure      400         {object}  map[string]string
+// @Failure      403         {object}  map[string]string
+// @Failure      409         {object}  map[string]string
+// @Router       /invitations [post]
+func (h *InvitationHandler) Create(c echo.Context) error {
+	var req model.InvitationRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	invitation, err := h.Service.Invite(c.Request().Context(), &req)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al crear invitación")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, invitation.ID).Int(enum.Status, http.StatusCreated).Msg("✅ Invitación enviada")
+	return c.JSON(http.StatusCreated, invitation)
+}
+
+// Get godoc
+// @Summary      Get invitation
+// @Description  Retrieve an invitation by ID
+// @Tags         invitations
+// @Produce      json
+// @Param        id   path      int  true  "Invitation ID"
+// @Success      200  {object}  model.Invitation
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /invitations/{id} [get]
+func (h *InvitationHandler) Get(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid invitation ID"})
+	}
+
+	invitation, err := h.Service.Get(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("⚠️ Invitación no encontrada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ Invitación encontrada")
+	return c.JSON(http.StatusOK, invitation)
+}
+
+// List godoc
+// @Summary      List invitations
+// @Description  List invitations, newest first, optionally filtered by status
+// @Tags         invitations
+// @Produce      json
+// @Param        status  query     string  false  "pending, accepted, revoked or expired"
+// @Param        page    query     int     false  "Page number"
+// @Param        limit   query     int     false  "Page size"
+// @Success      200     {array}   model.Invitation
+// @Failure      400     {object}  map[string]string
+// @Router       /invitations [get]
+func (h *InvitationHandler) List(c echo.Context) error {
+	page, err := parseIntOrDefault(c.QueryParam(enum.Page), 1)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Página inválida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid page number"})
+	}
+
+	limit, err := parseIntOrDefault(c.QueryParam(enum.Limit), 10)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Límite inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+	}
+
+	status := model.InvitationStatus(strings.ToLower(strings.TrimSpace(c.QueryParam(enum.Status))))
+	invitations, err := h.Service.List(c.Request().Context(), (page-1)*limit, limit, status)
+	if err != nil {
+		code := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, code).Msg("❌ Error al listar invitaciones")
+		return respondError(c, code, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(invitations)).Msg("✅ Invitaciones listadas")
+	return c.JSON(http.StatusOK, invitations)
+}
+
+// Resend godoc
+// @Summary      Resend invitation
+// @Description  Email a pending invitation again with a new link and a renewed expiry; the previous link stops working
+// @Tags         invitations
+// @Produce      json
+// @Param        id   path      int  true  "Invitation ID"
+// @Success      200  {object}  model.Invitation
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /invitations/{id}/resend [post]
+func (h *InvitationHandler) Resend(c echo.Context) error {
+	return h.change(c, h.Service.Resend, "✅ Invitación reenviada")
+}
+
+// Revoke godoc
+// @Summary      Revoke invitation
+// @Description  Revoke a pending invitation and delete the invited user
+// @Tags         invitations
+// @Produce      json
+// @Param        id   path      int  true  "Invitation ID"
+// @Success      200  {object}  model.Invitation
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /invitations/{id} [delete]
+func (h *InvitationHandler) Revoke(c echo.Context) error {
+	return h.change(c, h.Service.Revoke, "✅ Invitación revocada")
+}
+
+// Accept godoc
+// @Summary      Accept invitation
+// @Description  Redeem an invitation token, choosing name and password; the account becomes active with a verified email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.InvitationAcceptance  true  "Token, name and password"
+// @Success      200   {object}  model.User
+// @Failure      400   {object}  map[string]string
+// @Router       /auth/invitations/accept [post]
+func (h *InvitationHandler) Accept(c echo.Context) error {
+	var req model.InvitationAcceptance
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	user, err := h.Service.Accept(c.Request().Context(), &req)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int(enum.Status, status).Msg("⚠️ Aceptación de invitación rechazada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, user.ID).Int(enum.Status, http.StatusOK).Msg("✅ Invitación aceptada")
+	return c.JSON(http.StatusOK, user)
+}
+
+func (h *InvitationHandler) change(c echo.Context, apply func(context.Context, int64) (*model.Invitation, error), okMsg string) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid invitation ID"})
+	}
+
+	invitation, err := apply(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al modificar invitación")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg(okMsg)
+	return c.JSON(http.StatusOK, invitation)
+}
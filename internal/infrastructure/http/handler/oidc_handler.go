@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// oidcStateCookie ata el state al navegador que inició el flujo, para que nadie pueda
+	// completar un inicio de sesión ajeno con un enlace de callback.
+	oidcStateCookie = "oidc_state"
+	oidcCookiePath  = "/auth/oidc"
+)
+
+type OIDCHandler struct {
+	Service *application.OIDCService
+}
+
+func NewOIDCHandler(svc *application.OIDCService) *OIDCHandler {
+	return &OIDCHandler{Service: svc}
+}
+
+// Register registra el inicio de sesión con el proveedor OpenID Connect, sólo si hay uno configurado.
+func (h *OIDCHandler) Register(e *echo.Echo) {
+	if !h.Service.Enabled() {
+		log.Debug().Msg("🔍 Inicio de sesión OIDC deshabilitado (OIDC_ISSUER_URL vacío)")
+		return
+	}
+	e.GET("/auth/oidc/login", h.Login)
+	e.GET("/auth/oidc/callback", h.Callback)
+}
+
+// Login godoc
+// @Summary      Start OIDC login
+// @Description  Redirect to the identity provider (authorization code + PKCE); the state is bound to the browser with a cookie
+// @Tags         auth
+// @Success      302  "Redirect to the identity provider"
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/oidc/login [get]
+func (h *OIDCHandler) Login(c echo.Context) error {
+	authURL, state, err := h.Service.Begin(c.Request().Context())
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al iniciar sesión con el proveedor de identidad")
+		return respondError(c, status, err)
+	}
+
+	c.SetCookie(h.stateCookie(c, state, 0))
+	log.Debug().Int(enum.Status, http.StatusFound).Msg("🔀 Redirigiendo al proveedor de identidad")
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback godoc
+// @Summary      Complete OIDC login
+// @Description  Redeem the authorization code, link or provision the local user by verified email and receive access and refresh tokens
+// @Tags         auth
+// @Produce      json
+// @Param        code   query     string  true  "Authorization code"
+// @Param        state  query     string  true  "State returned by the identity provider"
+// @Success      200    {object}  model.TokenPair
+// @Failure      401    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c echo.Context) error {
+	// El state se consume en cualquier caso: el navegador no debe reutilizarlo.
+	c.SetCookie(h.stateCookie(c, "", -1))
+
+	if idpError := c.QueryParam("error"); idpError != "" {
+		log.Warn().Str("error", idpError).Str("description", c.QueryParam("error_description")).
+			Int(enum.Status, http.StatusUnauthorized).Msg("🔒 El proveedor de identidad devolvió un error")
+		return respondError(c, http.StatusUnauthorized, model.ErrExternalLoginFailed)
+	}
+
+	state, code := c.QueryParam("state"), c.QueryParam("code")
+	cookie, err := c.Cookie(oidcStateCookie)
+	if state == "" || code == "" || err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		log.Warn().Str("ip", c.RealIP()).Int(enum.Status, http.StatusUnauthorized).Msg("🔒 State de OIDC ausente o distinto al de la cookie")
+		return respondError(c, http.StatusUnauthorized, model.ErrInvalidLoginState)
+	}
+
+	tokens, err := h.Service.Complete(c.Request().Context(), state, code, clientInfo(c))
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Str("ip", c.RealIP()).Int(enum.Status, status).Msg("🔒 Inicio de sesión OIDC rechazado")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Msg("✅ Inicio de sesión OIDC correcto")
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (h *OIDCHandler) stateCookie(c echo.Context, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     oidcCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		// Lax permite que la cookie viaje en la redirección de vuelta desde el proveedor.
+		SameSite: http.SameSiteLaxMode,
+	}
+}
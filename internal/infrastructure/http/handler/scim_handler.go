@@ -0,0 +1,585 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/http/scim"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	scimPath         = "/scim/v2"
+	scimDefaultCount = 100
+	// scimDeprovisionReason es el motivo que queda en el historial al desactivar vía SCIM.
+	scimDeprovisionReason = "deprovisioned via SCIM"
+)
+
+// SCIMHandler expone /scim/v2 para que un IdP aprovisione usuarios y grupos. Las peticiones
+// pasan por los mismos servicios (y permisos) que la API propia.
+type SCIMHandler struct {
+	Users  *application.UserService
+	Groups *application.GroupService
+}
+
+func NewSCIMHandler(users *application.UserService, groups *application.GroupService) *SCIMHandler {
+	return &SCIMHandler{Users: users, Groups: groups}
+}
+
+// Register registra el descubrimiento y los recursos Users y Groups.
+func (h *SCIMHandler) Register(e *echo.Echo) {
+	g := e.Group(scimPath)
+	g.GET("/ServiceProviderConfig", h.ServiceProviderConfig)
+	g.GET("/ResourceTypes", h.ResourceTypes)
+
+	g.GET("/Users", h.ListUsers)
+	g.GET("/Users/:id", h.GetUser)
+	g.POST("/Users", h.CreateUser)
+	g.PUT("/Users/:id", h.ReplaceUser)
+	g.PATCH("/Users/:id", h.PatchUser)
+	g.DELETE("/Users/:id", h.DeleteUser)
+
+	g.GET("/Groups", h.ListGroups)
+	g.GET("/Groups/:id", h.GetGroup)
+	g.POST("/Groups", h.CreateGroup)
+	g.PUT("/Groups/:id", h.ReplaceGroup)
+	g.PATCH("/Groups/:id", h.PatchGroup)
+	g.DELETE("/Groups/:id", h.DeleteGroup)
+}
+
+// ServiceProviderConfig godoc
+// @Summary      SCIM service provider configuration
+// @Description  Describe the SCIM features supported by this server
+// @Tags         scim
+// @Produce      json
+// @Success      200  {object}  scim.ServiceProviderConfig
+// @Router       /scim/v2/ServiceProviderConfig [get]
+func (h *SCIMHandler) ServiceProviderConfig(c echo.Context) error {
+	return scimJSON(c, http.StatusOK, scim.NewServiceProviderConfig(scimBase(c)))
+}
+
+// ResourceTypes godoc
+// @Summary      SCIM resource types
+// @Description  List the SCIM resource types exposed (User and Group)
+// @Tags         scim
+// @Produce      json
+// @Success      200  {object}  scim.ListResponse
+// @Router       /scim/v2/ResourceTypes [get]
+func (h *SCIMHandler) ResourceTypes(c echo.Context) error {
+	types := scim.ResourceTypes(scimBase(c))
+	return scimJSON(c, http.StatusOK, scim.NewListResponse(types, len(types), 1))
+}
+
+// ListUsers godoc
+// @Summary      List SCIM users
+// @Description  Query users with a SCIM filter (e.g. userName eq "jane@example.com") and startIndex/count pagination
+// @Tags         scim
+// @Produce      json
+// @Param        filter      query     string  false  "SCIM filter"
+// @Param        startIndex  query     int     false  "1-based index of the first result"
+// @Param        count       query     int     false  "Results per page (max 200)"
+// @Success      200         {object}  scim.ListResponse
+// @Failure      400         {object}  scim.ErrorResponse
+// @Failure      403         {object}  scim.ErrorResponse
+// @Router       /scim/v2/Users [get]
+func (h *SCIMHandler) ListUsers(c echo.Context) error {
+	startIndex, count, err := scimPage(c)
+	if err != nil {
+		return scimError(c, err, "❌ Paginación SCIM inválida")
+	}
+	filter, err := scim.UserFilter(c.QueryParam("filter"))
+	if err != nil {
+		return scimError(c, err, "❌ Filtro SCIM inválido")
+	}
+
+	ctx := c.Request().Context()
+	total, err := h.Users.Count(ctx, filter)
+	if err != nil {
+		return scimError(c, err, "❌ Error al contar usuarios SCIM")
+	}
+
+	var resources []interface{}
+	if count > 0 {
+		users, err := h.Users.List(ctx, startIndex-1, count, filter)
+		if err != nil {
+			return scimError(c, err, "❌ Error al listar usuarios SCIM")
+		}
+		base := scimBase(c)
+		for _, u := range users {
+			resources = append(resources, scim.FromUser(u, base))
+		}
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, total).Msg("✅ Usuarios SCIM listados")
+	return scimJSON(c, http.StatusOK, scim.NewListResponse(resources, total, startIndex))
+}
+
+// GetUser godoc
+// @Summary      Get SCIM user
+// @Description  Retrieve a user as a SCIM resource
+// @Tags         scim
+// @Produce      json
+// @Param        id   path      string  true  "User ID or public ID"
+// @Success      200  {object}  scim.User
+// @Failure      404  {object}  scim.ErrorResponse
+// @Router       /scim/v2/Users/{id} [get]
+func (h *SCIMHandler) GetUser(c echo.Context) error {
+	user, err := h.loadUser(c)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener usuario SCIM")
+	}
+	return scimJSON(c, http.StatusOK, scim.FromUser(user, scimBase(c)))
+}
+
+// CreateUser godoc
+// @Summary      Create SCIM user
+// @Description  Provision a user; userName must be the email and must be unique
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        user  body      scim.User  true  "SCIM user"
+// @Success      201   {object}  scim.User
+// @Failure      400   {object}  scim.ErrorResponse
+// @Failure      409   {object}  scim.ErrorResponse
+// @Router       /scim/v2/Users [post]
+func (h *SCIMHandler) CreateUser(c echo.Context) error {
+	var req scim.User
+	if err := bindSCIM(c, &req); err != nil {
+		return scimError(c, err, "❌ Error al parsear body SCIM")
+	}
+
+	user := &model.User{}
+	active, err := req.ToUser(user)
+	if err != nil {
+		return scimError(c, err, "❌ Usuario SCIM inválido")
+	}
+
+	ctx := c.Request().Context()
+	status := model.UserStatusActive
+	if active != nil && !*active {
+		status = model.UserStatusDeactivated
+	}
+	if user.ID, err = h.Users.CreateWithStatus(ctx, user, status, scimDeprovisionReason); err != nil {
+		return scimError(c, err, "❌ Error al crear usuario SCIM")
+	}
+
+	created, err := h.Users.Get(ctx, user.ID)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener usuario SCIM")
+	}
+	resource := scim.FromUser(created, scimBase(c))
+	c.Response().Header().Set(echo.HeaderLocation, resource.Meta.Location)
+	log.Info().Int64(enum.ID, user.ID).Int(enum.Status, http.StatusCreated).Msg("✅ Usuario aprovisionado vía SCIM")
+	return scimJSON(c, http.StatusCreated, resource)
+}
+
+// ReplaceUser godoc
+// @Summary      Replace SCIM user
+// @Description  Replace name, userName and active of a user
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string     true  "User ID or public ID"
+// @Param        user  body      scim.User  true  "SCIM user"
+// @Success      200   {object}  scim.User
+// @Failure      400   {object}  scim.ErrorResponse
+// @Failure      404   {object}  scim.ErrorResponse
+// @Failure      409   {object}  scim.ErrorResponse
+// @Router       /scim/v2/Users/{id} [put]
+func (h *SCIMHandler) ReplaceUser(c echo.Context) error {
+	current, err := h.loadUser(c)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener usuario SCIM")
+	}
+
+	var req scim.User
+	if err := bindSCIM(c, &req); err != nil {
+		return scimError(c, err, "❌ Error al parsear body SCIM")
+	}
+	return h.saveUser(c, current, &req)
+}
+
+// PatchUser godoc
+// @Summary      Patch SCIM user
+// @Description  Apply add/replace/remove operations (e.g. replace active to deprovision)
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string             true  "User ID or public ID"
+// @Param        patch  body      scim.PatchRequest  true  "PatchOp message"
+// @Success      200    {object}  scim.User
+// @Failure      400    {object}  scim.ErrorResponse
+// @Failure      404    {object}  scim.ErrorResponse
+// @Router       /scim/v2/Users/{id} [patch]
+func (h *SCIMHandler) PatchUser(c echo.Context) error {
+	current, err := h.loadUser(c)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener usuario SCIM")
+	}
+
+	var req scim.PatchRequest
+	if err := bindSCIM(c, &req); err != nil {
+		return scimError(c, err, "❌ Error al parsear body SCIM")
+	}
+	if err := req.Validate(); err != nil {
+		return scimError(c, err, "❌ PatchOp inválido")
+	}
+
+	resource := scim.FromUser(current, scimBase(c))
+	if err := req.ApplyTo(resource); err != nil {
+		return scimError(c, err, "❌ Error al aplicar PatchOp")
+	}
+	return h.saveUser(c, current, resource)
+}
+
+// DeleteUser godoc
+// @Summary      Delete SCIM user
+// @Description  Delete a user
+// @Tags         scim
+// @Param        id   path  string  true  "User ID or public ID"
+// @Success      204  "No Content"
+// @Failure      404  {object}  scim.ErrorResponse
+// @Router       /scim/v2/Users/{id} [delete]
+func (h *SCIMHandler) DeleteUser(c echo.Context) error {
+	id, err := parseUserID(c, h.Users)
+	if err != nil {
+		return scimError(c, err, "❌ ID inválido")
+	}
+	if err := h.Users.Delete(c.Request().Context(), id); err != nil {
+		return scimError(c, err, "❌ Error al eliminar usuario SCIM")
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ Usuario eliminado vía SCIM")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListGroups godoc
+// @Summary      List SCIM groups
+// @Description  Query groups with a SCIM filter (e.g. displayName eq "Engineering"); excludedAttributes=members omits members
+// @Tags         scim
+// @Produce      json
+// @Param        filter              query     string  false  "SCIM filter"
+// @Param        startIndex          query     int     false  "1-based index of the first result"
+// @Param        count               query     int     false  "Results per page (max 200)"
+// @Param        excludedAttributes  query     string  false  "Use members to omit members"
+// @Success      200                 {object}  scim.ListResponse
+// @Failure      400                 {object}  scim.ErrorResponse
+// @Router       /scim/v2/Groups [get]
+func (h *SCIMHandler) ListGroups(c echo.Context) error {
+	startIndex, count, err := scimPage(c)
+	if err != nil {
+		return scimError(c, err, "❌ Paginación SCIM inválida")
+	}
+	filter, err := scim.GroupFilter(c.QueryParam("filter"))
+	if err != nil {
+		return scimError(c, err, "❌ Filtro SCIM inválido")
+	}
+
+	ctx := c.Request().Context()
+	total, err := h.Groups.Count(ctx, filter)
+	if err != nil {
+		return scimError(c, err, "❌ Error al contar grupos SCIM")
+	}
+
+	var resources []interface{}
+	if count > 0 {
+		groups, err := h.Groups.List(ctx, startIndex-1, count, filter)
+		if err != nil {
+			return scimError(c, err, "❌ Error al listar grupos SCIM")
+		}
+		for _, g := range groups {
+			resource, err := h.groupResource(c, g)
+			if err != nil {
+				return scimError(c, err, "❌ Error al listar miembros del grupo")
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, total).Msg("✅ Grupos SCIM listados")
+	return scimJSON(c, http.StatusOK, scim.NewListResponse(resources, total, startIndex))
+}
+
+// GetGroup godoc
+// @Summary      Get SCIM group
+// @Description  Retrieve a group with its members as a SCIM resource
+// @Tags         scim
+// @Produce      json
+// @Param        id   path      int  true  "Group ID"
+// @Success      200  {object}  scim.Group
+// @Failure      404  {object}  scim.ErrorResponse
+// @Router       /scim/v2/Groups/{id} [get]
+func (h *SCIMHandler) GetGroup(c echo.Context) error {
+	group, err := h.loadGroup(c)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener grupo SCIM")
+	}
+	resource, err := h.groupResource(c, group)
+	if err != nil {
+		return scimError(c, err, "❌ Error al listar miembros del grupo")
+	}
+	return scimJSON(c, http.StatusOK, resource)
+}
+
+// CreateGroup godoc
+// @Summary      Create SCIM group
+// @Description  Create a group and add its members
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        group  body      scim.Group  true  "SCIM group"
+// @Success      201    {object}  scim.Group
+// @Failure      400    {object}  scim.ErrorResponse
+// @Failure      409    {object}  scim.ErrorResponse
+// @Router       /scim/v2/Groups [post]
+func (h *SCIMHandler) CreateGroup(c echo.Context) error {
+	var req scim.Group
+	if err := bindSCIM(c, &req); err != nil {
+		return scimError(c, err, "❌ Error al parsear body SCIM")
+	}
+	memberRefs, err := req.MemberRefs()
+	if err != nil {
+		return scimError(c, err, "❌ Miembros SCIM inválidos")
+	}
+	group := &model.Group{Name: strings.TrimSpace(req.DisplayName), Roles: []string{}}
+	if err := c.Validate(group); err != nil {
+		return scimError(c, scim.Errorf(scim.ScimTypeInvalidValue, "%s", err.Error()), "❌ Validación fallida")
+	}
+
+	ctx := c.Request().Context()
+	if group.ID, err = h.Groups.CreateWithMembers(ctx, group, memberRefs); err != nil {
+		return scimError(c, err, "❌ Error al crear grupo SCIM")
+	}
+
+	resource, err := h.groupResource(c, group)
+	if err != nil {
+		return scimError(c, err, "❌ Error al listar miembros del grupo")
+	}
+	c.Response().Header().Set(echo.HeaderLocation, resource.Meta.Location)
+	log.Info().Int64(enum.ID, group.ID).Int(enum.Status, http.StatusCreated).Msg("✅ Grupo aprovisionado vía SCIM")
+	return scimJSON(c, http.StatusCreated, resource)
+}
+
+// ReplaceGroup godoc
+// @Summary      Replace SCIM group
+// @Description  Replace the name and the full member list of a group
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int         true  "Group ID"
+// @Param        group  body      scim.Group  true  "SCIM group"
+// @Success      200    {object}  scim.Group
+// @Failure      400    {object}  scim.ErrorResponse
+// @Failure      404    {object}  scim.ErrorResponse
+// @Router       /scim/v2/Groups/{id} [put]
+func (h *SCIMHandler) ReplaceGroup(c echo.Context) error {
+	current, err := h.loadGroup(c)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener grupo SCIM")
+	}
+
+	var req scim.Group
+	if err := bindSCIM(c, &req); err != nil {
+		return scimError(c, err, "❌ Error al parsear body SCIM")
+	}
+	return h.saveGroup(c, current, &req)
+}
+
+// PatchGroup godoc
+// @Summary      Patch SCIM group
+// @Description  Apply add/replace/remove operations on displayName and members
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                true  "Group ID"
+// @Param        patch  body      scim.PatchRequest  true  "PatchOp message"
+// @Success      200    {object}  scim.Group
+// @Failure      400    {object}  scim.ErrorResponse
+// @Failure      404    {object}  scim.ErrorResponse
+// @Router       /scim/v2/Groups/{id} [patch]
+func (h *SCIMHandler) PatchGroup(c echo.Context) error {
+	current, err := h.loadGroup(c)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener grupo SCIM")
+	}
+
+	var req scim.PatchRequest
+	if err := bindSCIM(c, &req); err != nil {
+		return scimError(c, err, "❌ Error al parsear body SCIM")
+	}
+	if err := req.Validate(); err != nil {
+		return scimError(c, err, "❌ PatchOp inválido")
+	}
+
+	members, err := h.Groups.Members(c.Request().Context(), current.ID)
+	if err != nil {
+		return scimError(c, err, "❌ Error al listar miembros del grupo")
+	}
+	resource := scim.FromGroup(current, members, scimBase(c))
+	if err := req.ApplyToGroup(resource); err != nil {
+		return scimError(c, err, "❌ Error al aplicar PatchOp")
+	}
+	return h.saveGroup(c, current, resource)
+}
+
+// DeleteGroup godoc
+// @Summary      Delete SCIM group
+// @Description  Delete a group; memberships are removed too
+// @Tags         scim
+// @Param        id   path  int  true  "Group ID"
+// @Success      204  "No Content"
+// @Failure      404  {object}  scim.ErrorResponse
+// @Router       /scim/v2/Groups/{id} [delete]
+func (h *SCIMHandler) DeleteGroup(c echo.Context) error {
+	id, err := scim.ParseID(c.Param(enum.ID))
+	if err != nil {
+		return scimError(c, err, "❌ ID inválido")
+	}
+	if err := h.Groups.Delete(c.Request().Context(), id); err != nil {
+		return scimError(c, err, "❌ Error al eliminar grupo SCIM")
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ Grupo eliminado vía SCIM")
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *SCIMHandler) loadUser(c echo.Context) (*model.User, error) {
+	id, err := parseUserID(c, h.Users)
+	if err != nil {
+		return nil, err
+	}
+	return h.Users.Get(c.Request().Context(), id)
+}
+
+// saveUser aplica el recurso sobre el usuario actual conservando sus atributos personalizados;
+// active se traduce a activar o desactivar, que quedan en el historial de estados.
+func (h *SCIMHandler) saveUser(c echo.Context, current *model.User, resource *scim.User) error {
+	updated := *current
+	active, err := resource.ToUser(&updated)
+	if err != nil {
+		return scimError(c, err, "❌ Usuario SCIM inválido")
+	}
+
+	ctx := c.Request().Context()
+	if err := h.Users.Update(ctx, &updated); err != nil {
+		return scimError(c, err, "❌ Error al actualizar usuario SCIM")
+	}
+
+	isActive := current.Status == model.UserStatusActive
+	switch {
+	case active == nil || *active == isActive:
+	case *active:
+		_, err = h.Users.Activate(ctx, current.ID, "reactivated via SCIM")
+	case isActive:
+		_, err = h.Users.Deactivate(ctx, current.ID, scimDeprovisionReason)
+	}
+	if err != nil {
+		return scimError(c, err, "❌ Error al cambiar estado del usuario SCIM")
+	}
+
+	saved, err := h.Users.Get(ctx, current.ID)
+	if err != nil {
+		return scimError(c, err, "❌ Error al obtener usuario SCIM")
+	}
+	log.Info().Int64(enum.ID, current.ID).Int(enum.Status, http.StatusOK).Msg("✅ Usuario actualizado vía SCIM")
+	return scimJSON(c, http.StatusOK, scim.FromUser(saved, scimBase(c)))
+}
+
+func (h *SCIMHandler) loadGroup(c echo.Context) (*model.Group, error) {
+	id, err := scim.ParseID(c.Param(enum.ID))
+	if err != nil {
+		return nil, err
+	}
+	return h.Groups.Get(c.Request().Context(), id)
+}
+
+// groupResource traduce el grupo con sus miembros, salvo excludedAttributes=members.
+func (h *SCIMHandler) groupResource(c echo.Context, group *model.Group) (*scim.Group, error) {
+	var members []*model.User
+	if !scimExcludes(c, "members") {
+		var err error
+		if members, err = h.Groups.Members(c.Request().Context(), group.ID); err != nil {
+			return nil, err
+		}
+	}
+	return scim.FromGroup(group, members, scimBase(c)), nil
+}
+
+// saveGroup aplica el nombre del recurso y reemplaza los miembros del grupo por los suyos en una
+// misma transacción, conservando la descripción y los roles del grupo.
+func (h *SCIMHandler) saveGroup(c echo.Context, current *model.Group, resource *scim.Group) error {
+	desired, err := resource.MemberRefs()
+	if err != nil {
+		return scimError(c, err, "❌ Miembros SCIM inválidos")
+	}
+
+	updated := *current
+	updated.Name = strings.TrimSpace(resource.DisplayName)
+	if err := c.Validate(&updated); err != nil {
+		return scimError(c, scim.Errorf(scim.ScimTypeInvalidValue, "%s", err.Error()), "❌ Validación fallida")
+	}
+	if err := h.Groups.Replace(c.Request().Context(), &updated, desired); err != nil {
+		return scimError(c, err, "❌ Error al actualizar grupo SCIM")
+	}
+
+	saved, err := h.groupResource(c, &updated)
+	if err != nil {
+		return scimError(c, err, "❌ Error al listar miembros del grupo")
+	}
+	log.Info().Int64(enum.ID, updated.ID).Int(enum.Total, len(desired)).
+		Int(enum.Status, http.StatusOK).Msg("✅ Grupo actualizado vía SCIM")
+	return scimJSON(c, http.StatusOK, saved)
+}
+
+// scimPage lee startIndex (base 1) y count; count se limita a scim.MaxResults.
+func scimPage(c echo.Context) (startIndex, count int, err error) {
+	if startIndex, err = parseIntOrDefault(c.QueryParam("startIndex"), 1); err != nil {
+		return 0, 0, scim.Errorf(scim.ScimTypeInvalidValue, "startIndex must be an integer")
+	}
+	if count, err = parseIntOrDefault(c.QueryParam("count"), scimDefaultCount); err != nil {
+		return 0, 0, scim.Errorf(scim.ScimTypeInvalidValue, "count must be an integer")
+	}
+	startIndex = max(startIndex, 1)
+	count = min(max(count, 0), scim.MaxResults)
+	return startIndex, count, nil
+}
+
+func scimExcludes(c echo.Context, attr string) bool {
+	for _, excluded := range strings.Split(c.QueryParam("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(excluded), attr) {
+			return true
+		}
+	}
+	return false
+}
+
+// scimBase es la URL absoluta de /scim/v2 para meta.location.
+func scimBase(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host + scimPath
+}
+
+// bindSCIM decodifica el cuerpo: el binder de Echo no acepta application/scim+json.
+func bindSCIM(c echo.Context, v interface{}) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return scim.Errorf(scim.ScimTypeInvalidSyntax, "invalid request body")
+	}
+	return nil
+}
+
+func scimJSON(c echo.Context, status int, v interface{}) error {
+	c.Response().Header().Set(echo.HeaderContentType, scim.ContentType)
+	c.Response().WriteHeader(status)
+	return json.NewEncoder(c.Response()).Encode(v)
+}
+
+// scimError responde con el cuerpo de error de SCIM en lugar de {"error": ...}.
+func scimError(c echo.Context, err error, msg string) error {
+	status, body := scim.NewErrorResponse(statusCodeFor(err), err)
+	log.Error().Err(err).Int(enum.Status, status).Msg(msg)
+	return scimJSON(c, status, body)
+}
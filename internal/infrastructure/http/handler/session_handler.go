@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// RefreshRequest es el cuerpo de POST /auth/refresh y POST /auth/logout.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// Refresh godoc
+// @Summary      Refresh tokens
+// @Description  Exchange a refresh token for a new access token and a new refresh token; reusing a rotated refresh token revokes the session
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      handler.RefreshRequest  true  "Refresh token"
+// @Success      200   {object}  model.TokenPair
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      403   {object}  map[string]string
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	tokens, err := h.Service.Refresh(c.Request().Context(), req.RefreshToken, clientInfo(c))
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Str("ip", c.RealIP()).Int(enum.Status, status).Msg("🔒 Renovación de token rechazada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Msg("✅ Tokens renovados")
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary      Log out
+// @Description  Revoke the session of a refresh token
+// @Tags         auth
+// @Accept       json
+// @Param        body  body  handler.RefreshRequest  true  "Refresh token"
+// @Success      204   "No Content"
+// @Failure      400   {object}  map[string]string
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c echo.Context) error {
+	var req RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := h.Service.Logout(c.Request().Context(), req.RefreshToken); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al cerrar sesión")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusNoContent).Msg("✅ Sesión cerrada")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Sessions godoc
+// @Summary      List user sessions
+// @Description  List the active sessions of a user with device and IP
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID or public ID"
+// @Success      200  {array}   model.Session
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /users/{id}/sessions [get]
+func (h *AuthHandler) Sessions(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	sessions, err := h.Service.Sessions(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al listar sesiones")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(sessions)).Msg("✅ Sesiones listadas")
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSessions godoc
+// @Summary      Revoke all user sessions
+// @Description  Revoke every active session of a user
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID or public ID"
+// @Success      200  {object}  map[string]int
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /users/{id}/sessions [delete]
+func (h *AuthHandler) RevokeSessions(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	revoked, err := h.Service.RevokeSessions(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al revocar sesiones")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Total, revoked).Int(enum.Status, http.StatusOK).Msg("✅ Sesiones revocadas")
+	return c.JSON(http.StatusOK, echo.Map{"revoked": revoked})
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a user session
+// @Description  Revoke one session of a user
+// @Tags         users
+// @Param        id         path  string  true  "User ID or public ID"
+// @Param        sessionID  path  int  true  "Session ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /users/{id}/sessions/{sessionID} [delete]
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+	sessionID, err := parseID(c.Param(enum.SessionID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid session ID"})
+	}
+
+	if err := h.Service.RevokeSession(c.Request().Context(), id, sessionID); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al revocar sesión")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, sessionID).Int(enum.Status, http.StatusNoContent).Msg("✅ Sesión revocada")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// clientInfo identifica el dispositivo de la petición para registrarlo en la sesión.
+func clientInfo(c echo.Context) model.ClientInfo {
+	return model.ClientInfo{UserAgent: c.Request().UserAgent(), IP: c.RealIP()}
+}
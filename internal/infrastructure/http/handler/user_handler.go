@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,10 +18,36 @@ import (
 
 type UserHandler struct {
 	Service *application.UserService
+	crud    *CRUDHandler[model.User, int64]
 }
 
 func NewUserHandler(svc *application.UserService) *UserHandler {
-	return &UserHandler{Service: svc}
+	return &UserHandler{
+		Service: svc,
+		crud: NewCRUDHandler[model.User, int64](svc, CRUDOptions[model.User, int64]{
+			Resource:    "user",
+			Path:        "/users",
+			Filters:     []string{enum.Name, enum.Email},
+			ListFilters: userListFilters,
+			ResolveID:   svc.ResolveUserID,
+		}),
+	}
+}
+
+// Register registra las rutas CRUD de usuarios y sus acciones de estado.
+func (h *UserHandler) Register(e *echo.Echo) {
+	api := e.Group("/users")
+	api.GET("", h.List)
+	api.POST("/lookup", h.Lookup)
+	api.GET("/:id", h.Get)
+	api.POST("", h.Create)
+	api.PUT("/:id", h.Update)
+	api.DELETE("/:id", h.Delete)
+	api.GET("/:id/status-history", h.StatusHistory)
+	api.POST("/:id/activate", h.Activate)
+	api.POST("/:id/suspend", h.Suspend)
+	api.POST("/:id/lock", h.Lock)
+	api.POST("/:id/deactivate", h.Deactivate)
 }
 
 // Get godoc
@@ -26,26 +55,14 @@ func NewUserHandler(svc *application.UserService) *UserHandler {
 // @Description  Retrieve a user using their ID
 // @Tags         users
 // @Produce      json
-// @Param        id   path      int  true  "User ID"
-// @Success      200  {object}  model.User
-// @Failure      400  {object}  map[string]string
-// @Failure      404  {object}  map[string]string
+// @Param        id      path      string  true   "User ID or public ID"
+// @Param        fields  query     string  false  "Comma separated fields to return (e.g. id,name)"
+// @Success      200     {object}  model.User
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
 // @Router       /users/{id} [get]
 func (h *UserHandler) Get(c echo.Context) error {
-	id, err := parseID(c.Param(enum.ID))
-	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid user ID"})
-	}
-
-	u, err := h.Service.Get(id)
-	if err != nil {
-		log.Warn().Err(err).Int(enum.Status, http.StatusNotFound).Msg("⚠️ Usuario no encontrado")
-		return c.JSON(http.StatusNotFound, echo.Map{"error": "user not found"})
-	}
-
-	log.Info().Int(enum.Status, http.StatusOK).Int64("userID", u.ID).Msg("✅ Usuario encontrado")
-	return c.JSON(http.StatusOK, u)
+	return h.crud.Get(c)
 }
 
 // Create godoc
@@ -57,28 +74,11 @@ func (h *UserHandler) Get(c echo.Context) error {
 // @Param        user  body      model.User  true  "User data"
 // @Success      201   {object}  model.User
 // @Failure      400   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
 // @Failure      500   {object}  map[string]string
 // @Router       /users [post]
 func (h *UserHandler) Create(c echo.Context) error {
-	var u model.User
-	if err := c.Bind(&u); err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
-	}
-
-	if err := c.Validate(&u); err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
-	}
-
-	id, err := h.Service.Create(&u)
-	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusInternalServerError).Msg("❌ Error al crear usuario")
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
-	}
-	u.ID = id
-	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusCreated).Msg("✅ Usuario creado")
-	return c.JSON(http.StatusCreated, u)
+	return h.crud.Create(c)
 }
 
 // Update godoc
@@ -87,38 +87,16 @@ func (h *UserHandler) Create(c echo.Context) error {
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        id    path      int         true  "User ID"
+// @Param        id    path      string      true  "User ID or public ID"
 // @Param        user  body      model.User  true  "Updated user"
 // @Success      200   "No Content"
 // @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
 // @Failure      500   {object}  map[string]string
 // @Router       /users/{id} [put]
 func (h *UserHandler) Update(c echo.Context) error {
-	id, err := parseID(c.Param(enum.ID))
-	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid user ID"})
-	}
-
-	var u model.User
-	if err := c.Bind(&u); err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
-	}
-
-	if err := c.Validate(&u); err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
-	}
-
-	u.ID = id
-	if err := h.Service.Update(&u); err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusInternalServerError).Msg("❌ Error al actualizar usuario")
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
-	}
-
-	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ Usuario actualizado")
-	return c.NoContent(http.StatusOK)
+	return h.crud.Update(c)
 }
 
 // Delete godoc
@@ -126,25 +104,14 @@ func (h *UserHandler) Update(c echo.Context) error {
 // @Description  Delete a user by ID
 // @Tags         users
 // @Produce      json
-// @Param        id   path      int  true  "User ID"
+// @Param        id   path      string  true  "User ID or public ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /users/{id} [delete]
 func (h *UserHandler) Delete(c echo.Context) error {
-	id, err := parseID(c.Param(enum.ID))
-	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid user ID"})
-	}
-
-	if err := h.Service.Delete(id); err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusInternalServerError).Msg("❌ Error al eliminar usuario")
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
-	}
-
-	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ Usuario eliminado")
-	return c.NoContent(http.StatusNoContent)
+	return h.crud.Delete(c)
 }
 
 // List godoc
@@ -154,46 +121,89 @@ func (h *UserHandler) Delete(c echo.Context) error {
 // @Produce      json
 // @Param        name   query     string  false  "Filter by name"
 // @Param        email  query     string  false  "Filter by email"
+// @Param        status query     string  false  "Filter by status (comma separated)"
+// @Param        attr.{name} query string false "Filter by custom attribute value (exact match)"
 // @Param        page   query     int     false  "Page number"
 // @Param        limit  query     int     false  "Items per page"
+// @Param        fields query     string  false  "Comma separated fields to return (e.g. id,name)"
+// @Param        ids    query     string  false  "Comma separated user IDs or public IDs; the response is then a model.UserLookup instead of a page"
 // @Success      200    {array}   model.User
 // @Failure      400    {object}  map[string]string
 // @Failure      500    {object}  map[string]string
 // @Router       /users [get]
 func (h *UserHandler) List(c echo.Context) error {
-	filters := make(map[string]interface{})
-	if name := c.QueryParam(enum.Name); name != enum.EmptyString {
-		filters[enum.Name] = name
-	}
-	if email := c.QueryParam(enum.Email); email != enum.EmptyString {
-		filters[enum.Email] = email
+	if value := c.QueryParam(enum.IDs); value != enum.EmptyString {
+		ids, err := parseIDs(value)
+		if err != nil {
+			log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ IDs inválidos")
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid user ID"})
+		}
+		return h.lookup(c, ids, parseFields(c.QueryParam(enum.Fields)))
 	}
+	return h.crud.List(c)
+}
 
-	page, err := parseIntOrDefault(c.QueryParam(enum.Page), 1)
-	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Página inválida")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid page number"})
+// Lookup godoc
+// @Summary      Get users by IDs
+// @Description  Fetch many users in one call by ID or public ID; same as GET /users?ids= for sets too large for a URL. Missing IDs are listed in the response as given.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.UserLookupRequest  true  "User IDs and optional fields"
+// @Success      200      {object}  model.UserLookup
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /users/lookup [post]
+func (h *UserHandler) Lookup(c echo.Context) error {
+	var req model.UserLookupRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
 	}
-
-	limit, err := parseIntOrDefault(c.QueryParam(enum.Limit), 10)
-	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Límite inválido")
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
 	}
+	return h.lookup(c, req.IDs, parseFields(strings.Join(req.Fields, ",")))
+}
 
-	offset := (page - 1) * limit
-	users, err := h.Service.List(offset, limit, filters)
+func (h *UserHandler) lookup(c echo.Context, ids []model.UserRef, fields []string) error {
+	result, err := h.Service.GetByIDs(c.Request().Context(), ids, fields)
 	if err != nil {
-		log.Error().Err(err).Int(enum.Status, http.StatusInternalServerError).Msg("❌ Error al listar usuarios")
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al buscar usuarios por IDs")
+		return respondError(c, status, err)
 	}
 
-	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(users)).Msg("✅ Usuarios listados")
-	return c.JSON(http.StatusOK, users)
+	log.Info().Int(enum.Total, len(result.Users)).Int("missing", len(result.Missing)).Int(enum.Status, http.StatusOK).
+		Msg("✅ Usuarios encontrados por IDs")
+	if fields == nil {
+		return c.JSON(http.StatusOK, result)
+	}
+	users := make([]map[string]json.RawMessage, 0, len(result.Users))
+	for _, user := range result.Users {
+		users = append(users, selectFields(user, fields))
+	}
+	return c.JSON(http.StatusOK, echo.Map{"users": users, "missing": result.Missing})
 }
 
 // --- helpers ---
 
+// userListFilters agrega a List los filtros por estado y por atributos personalizados.
+func userListFilters(c echo.Context, filters map[string]interface{}) error {
+	if status := c.QueryParam(enum.Status); status != enum.EmptyString {
+		statuses, err := parseStatuses(status)
+		if err != nil {
+			return err
+		}
+		filters[enum.Status] = statuses
+	}
+	if attrs := attributeFilters(c); len(attrs) > 0 {
+		filters[enum.Attributes] = attrs
+	}
+	return nil
+}
+
 func parseID(idStr string) (int64, error) {
 	if strings.EqualFold(idStr, enum.EmptyString) {
 		return 0, errors.New("missing ID")
@@ -201,6 +211,63 @@ func parseID(idStr string) (int64, error) {
 	return strconv.ParseInt(idStr, 10, 64)
 }
 
+// UserIDResolver lo cumplen los casos de uso con rutas /users/:id: aceptan el ID numérico del
+// usuario o su ID público.
+type UserIDResolver interface {
+	ResolveUserID(ctx context.Context, ref string) (int64, error)
+}
+
+// parseUserID lee el :id de una ruta de usuario, numérico o público.
+func parseUserID(c echo.Context, users UserIDResolver) (int64, error) {
+	return users.ResolveUserID(c.Request().Context(), c.Param(enum.ID))
+}
+
+// userIDError responde 404 si el ID público no existe y 400 si el ID no es válido.
+func userIDError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrNotFound) {
+		log.Warn().Err(err).Int(enum.Status, http.StatusNotFound).Msg("⚠️ Usuario no encontrado")
+		return respondError(c, http.StatusNotFound, err)
+	}
+	log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+	return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid user ID"})
+}
+
+// attributeFilters recoge los query params con prefijo "attr." como filtros de atributos personalizados.
+func attributeFilters(c echo.Context) map[string]string {
+	attrs := make(map[string]string)
+	for key, values := range c.QueryParams() {
+		if name, ok := strings.CutPrefix(key, enum.AttributeFilterPrefix); ok && len(values) > 0 {
+			attrs[name] = values[0]
+		}
+	}
+	return attrs
+}
+
+func parseStatuses(value string) ([]string, error) {
+	var statuses []string
+	for _, raw := range strings.Split(value, ",") {
+		status := model.UserStatus(strings.ToLower(strings.TrimSpace(raw)))
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: %q", model.ErrInvalidStatus, raw)
+		}
+		statuses = append(statuses, string(status))
+	}
+	return statuses, nil
+}
+
+// parseIDs lee una lista de IDs de usuario, numéricos o públicos, separados por comas.
+func parseIDs(value string) ([]model.UserRef, error) {
+	var refs []model.UserRef
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == enum.EmptyString {
+			return nil, errors.New("empty ID")
+		}
+		refs = append(refs, model.UserRef(raw))
+	}
+	return refs, nil
+}
+
 func parseIntOrDefault(value string, def int) (int, error) {
 	value = strings.TrimSpace(value)
 	if strings.EqualFold(value, enum.EmptyString) {
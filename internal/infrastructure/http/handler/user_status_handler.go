@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// StatusChangeRequest es el cuerpo de las acciones de cambio de estado.
+type StatusChangeRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// Activate godoc
+// @Summary      Activate user
+// @Description  Move a user to the active status
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                       true   "User ID or public ID"
+// @Param        body  body      handler.StatusChangeRequest  false  "Reason"
+// @Success      200   {object}  model.UserStatusChange
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /users/{id}/activate [post]
+func (h *UserHandler) Activate(c echo.Context) error {
+	return h.changeStatus(c, model.UserStatusActive)
+}
+
+// Suspend godoc
+// @Summary      Suspend user
+// @Description  Suspend a user; a reason is required
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                       true  "User ID or public ID"
+// @Param        body  body      handler.StatusChangeRequest  true  "Reason"
+// @Success      200   {object}  model.UserStatusChange
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /users/{id}/suspend [post]
+func (h *UserHandler) Suspend(c echo.Context) error {
+	return h.changeStatus(c, model.UserStatusSuspended)
+}
+
+// Lock godoc
+// @Summary      Lock user
+// @Description  Lock a user account; a reason is required
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                       true  "User ID or public ID"
+// @Param        body  body      handler.StatusChangeRequest  true  "Reason"
+// @Success      200   {object}  model.UserStatusChange
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /users/{id}/lock [post]
+func (h *UserHandler) Lock(c echo.Context) error {
+	return h.changeStatus(c, model.UserStatusLocked)
+}
+
+// Deactivate godoc
+// @Summary      Deactivate user
+// @Description  Deactivate a user account; a reason is required
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                       true  "User ID or public ID"
+// @Param        body  body      handler.StatusChangeRequest  true  "Reason"
+// @Success      200   {object}  model.UserStatusChange
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Router       /users/{id}/deactivate [post]
+func (h *UserHandler) Deactivate(c echo.Context) error {
+	return h.changeStatus(c, model.UserStatusDeactivated)
+}
+
+// StatusHistory godoc
+// @Summary      User status history
+// @Description  List every status transition of a user with actor and timestamp
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID or public ID"
+// @Success      200  {array}   model.UserStatusChange
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/{id}/status-history [get]
+func (h *UserHandler) StatusHistory(c echo.Context) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	changes, err := h.Service.StatusHistory(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al obtener historial de estados")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(changes)).Msg("✅ Historial de estados obtenido")
+	return c.JSON(http.StatusOK, changes)
+}
+
+func (h *UserHandler) changeStatus(c echo.Context, to model.UserStatus) error {
+	id, err := parseUserID(c, h.Service)
+	if err != nil {
+		return userIDError(c, err)
+	}
+
+	var req StatusChangeRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	change, err := h.Service.ChangeStatus(c.Request().Context(), id, to, req.Reason)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al cambiar estado de usuario")
+		return respondError(c, status, err)
+	}
+
+	log.Info().
+		Int64(enum.ID, id).
+		Str(enum.Actor, change.Actor).
+		Str("to", string(change.To)).
+		Int(enum.Status, http.StatusOK).
+		Msg("✅ Estado de usuario actualizado")
+	return c.JSON(http.StatusOK, change)
+}
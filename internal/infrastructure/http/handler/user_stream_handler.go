@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// streamHeartbeat mantiene viva la conexión a través de proxies que cortan las inactivas.
+	streamHeartbeat = 15 * time.Second
+	// streamRetry es la espera que se sugiere a EventSource antes de reconectar.
+	streamRetry = 3 * time.Second
+)
+
+type UserStreamHandler struct {
+	Feed *application.UserFeed
+}
+
+func NewUserStreamHandler(feed *application.UserFeed) *UserStreamHandler {
+	return &UserStreamHandler{Feed: feed}
+}
+
+// Register registra el stream de cambios de usuarios.
+func (h *UserStreamHandler) Register(e *echo.Echo) {
+	e.GET("/users/stream", h.Stream)
+}
+
+// Stream godoc
+// @Summary      Stream user changes
+// @Description  Server-Sent Events feed of user.created, user.updated and user.deleted events, filtered like GET /users. Resume with the Last-Event-ID header (or last_event_id query param).
+// @Tags         users
+// @Produce      text/event-stream
+// @Param        name           query     string  false  "Filter by name"
+// @Param        email          query     string  false  "Filter by email"
+// @Param        status         query     string  false  "Filter by status (comma separated)"
+// @Param        attr.{name}    query     string  false  "Filter by custom attribute value (exact match)"
+// @Param        last_event_id  query     int     false  "Resume after this event ID"
+// @Param        Last-Event-ID  header    int     false  "Resume after this event ID"
+// @Success      200            {object}  model.Event
+// @Failure      400            {object}  map[string]string
+// @Failure      403            {object}  map[string]string
+// @Router       /users/stream [get]
+func (h *UserStreamHandler) Stream(c echo.Context) error {
+	filters := make(map[string]interface{})
+	for _, key := range []string{enum.Name, enum.Email} {
+		if value := c.QueryParam(key); value != enum.EmptyString {
+			filters[key] = value
+		}
+	}
+	if err := userListFilters(c, filters); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Filtro inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Last-Event-ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid Last-Event-ID"})
+	}
+
+	ctx := c.Request().Context()
+	events, err := h.Feed.Subscribe(ctx, filters, lastEventID)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al abrir stream de usuarios")
+		return respondError(c, status, err)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Evita que nginx acumule la respuesta.
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	fmt.Fprintf(res, "retry: %d\n\n", streamRetry.Milliseconds())
+	res.Flush()
+
+	log.Info().Int64("lastEventID", lastEventID).Int(enum.Status, http.StatusOK).Msg("📡 Stream de usuarios abierto")
+	defer log.Info().Msg("📴 Stream de usuarios cerrado")
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// parseLastEventID lee el header Last-Event-ID que envía EventSource al reconectar o, en la
+// primera conexión, el query param last_event_id.
+func parseLastEventID(c echo.Context) (int64, error) {
+	raw := c.Request().Header.Get("Last-Event-ID")
+	if raw == enum.EmptyString {
+		raw = c.QueryParam("last_event_id")
+	}
+	raw = strings.TrimSpace(raw)
+	if raw == enum.EmptyString {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid event ID %q", raw)
+	}
+	return id, nil
+}
@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type WebhookHandler struct {
+	Service *application.WebhookService
+}
+
+func NewWebhookHandler(svc *application.WebhookService) *WebhookHandler {
+	return &WebhookHandler{Service: svc}
+}
+
+// Register registra la administración de webhooks y su registro de entregas.
+func (h *WebhookHandler) Register(e *echo.Echo) {
+	hooks := e.Group("/webhooks")
+	hooks.GET("", h.List)
+	hooks.GET("/:id", h.Get)
+	hooks.POST("", h.Create)
+	hooks.PUT("/:id", h.Update)
+	hooks.DELETE("/:id", h.Delete)
+	hooks.POST("/:id/rotate-secret", h.RotateSecret)
+	hooks.GET("/:id/deliveries", h.Deliveries)
+	hooks.GET("/:id/deliveries/:deliveryID", h.Delivery)
+	hooks.POST("/:id/deliveries/:deliveryID/redeliver", h.Redeliver)
+}
+
+// Create godoc
+// @Summary      Create webhook
+// @Description  Subscribe a URL to domain event types ("*" for all); the signing secret is only returned once
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      model.WebhookRequest  true  "Webhook"
+// @Success      201      {object}  model.IssuedWebhook
+// @Failure      400      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Router       /webhooks [post]
+func (h *WebhookHandler) Create(c echo.Context) error {
+	var req model.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	issued, err := h.Service.Create(c.Request().Context(), &req)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al crear webhook")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, issued.ID).Int(enum.Status, http.StatusCreated).Msg("✅ Webhook creado")
+	return c.JSON(http.StatusCreated, issued)
+}
+
+// Get godoc
+// @Summary      Get webhook
+// @Description  Retrieve a webhook subscription (never its secret)
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  model.Webhook
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /webhooks/{id} [get]
+func (h *WebhookHandler) Get(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid webhook ID"})
+	}
+
+	hook, err := h.Service.Get(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("⚠️ Webhook no encontrado")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ Webhook encontrado")
+	return c.JSON(http.StatusOK, hook)
+}
+
+// List godoc
+// @Summary      List webhooks
+// @Description  List webhook subscriptions, newest first
+// @Tags         webhooks
+// @Produce      json
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Page size"
+// @Success      200    {array}   model.Webhook
+// @Failure      400    {object}  map[string]string
+// @Router       /webhooks [get]
+func (h *WebhookHandler) List(c echo.Context) error {
+	page, err := parseIntOrDefault(c.QueryParam(enum.Page), 1)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Página inválida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid page number"})
+	}
+
+	limit, err := parseIntOrDefault(c.QueryParam(enum.Limit), 10)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Límite inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+	}
+
+	hooks, err := h.Service.List(c.Request().Context(), (page-1)*limit, limit)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int(enum.Status, status).Msg("❌ Error al listar webhooks")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int(enum.Status, http.StatusOK).Int(enum.Total, len(hooks)).Msg("✅ Webhooks listados")
+	return c.JSON(http.StatusOK, hooks)
+}
+
+// Update godoc
+// @Summary      Update webhook
+// @Description  Replace the URL and event types; omit active to keep the current state
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                   true  "Webhook ID"
+// @Param        webhook  body      model.WebhookRequest  true  "Webhook"
+// @Success      200      {object}  model.Webhook
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Router       /webhooks/{id} [put]
+func (h *WebhookHandler) Update(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid webhook ID"})
+	}
+
+	var req model.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Error al parsear body")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Validación fallida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	hook, err := h.Service.Update(c.Request().Context(), id, &req)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al actualizar webhook")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ Webhook actualizado")
+	return c.JSON(http.StatusOK, hook)
+}
+
+// Delete godoc
+// @Summary      Delete webhook
+// @Description  Delete a webhook subscription and its delivery log
+// @Tags         webhooks
+// @Param        id   path  int  true  "Webhook ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid webhook ID"})
+	}
+
+	if err := h.Service.Delete(c.Request().Context(), id); err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al eliminar webhook")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusNoContent).Msg("✅ Webhook eliminado")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RotateSecret godoc
+// @Summary      Rotate webhook secret
+// @Description  Issue a new signing secret; subsequent deliveries are signed with it
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  model.IssuedWebhook
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid webhook ID"})
+	}
+
+	issued, err := h.Service.RotateSecret(c.Request().Context(), id)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, status).Msg("❌ Error al rotar secreto de webhook")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Msg("✅ Secreto de webhook rotado")
+	return c.JSON(http.StatusOK, issued)
+}
+
+// Deliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Delivery log of a webhook, newest first, optionally filtered by status (pending, succeeded, dead)
+// @Tags         webhooks
+// @Produce      json
+// @Param        id      path      int     true   "Webhook ID"
+// @Param        status  query     string  false  "Delivery status"
+// @Param        page    query     int     false  "Page number"
+// @Param        limit   query     int     false  "Page size"
+// @Success      200     {array}   model.WebhookDelivery
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(c echo.Context) error {
+	id, err := parseID(c.Param(enum.ID))
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid webhook ID"})
+	}
+
+	status := model.DeliveryStatus(c.QueryParam("status"))
+	switch status {
+	case "", model.DeliveryPending, model.DeliverySucceeded, model.DeliveryDead:
+	default:
+		log.Error().Str("status", string(status)).Int(enum.Status, http.StatusBadRequest).Msg("❌ Estado de entrega inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid delivery status"})
+	}
+
+	page, err := parseIntOrDefault(c.QueryParam(enum.Page), 1)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Página inválida")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid page number"})
+	}
+
+	limit, err := parseIntOrDefault(c.QueryParam(enum.Limit), 10)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ Límite inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+	}
+
+	deliveries, err := h.Service.Deliveries(c.Request().Context(), id, status, (page-1)*limit, limit)
+	if err != nil {
+		code := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, id).Int(enum.Status, code).Msg("❌ Error al listar entregas de webhook")
+		return respondError(c, code, err)
+	}
+
+	log.Info().Int64(enum.ID, id).Int(enum.Status, http.StatusOK).Int(enum.Total, len(deliveries)).Msg("✅ Entregas de webhook listadas")
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// Delivery godoc
+// @Summary      Get webhook delivery
+// @Description  Retrieve a delivery with its payload and the result of its last attempt
+// @Tags         webhooks
+// @Produce      json
+// @Param        id          path      int  true  "Webhook ID"
+// @Param        deliveryID  path      int  true  "Delivery ID"
+// @Success      200         {object}  model.WebhookDelivery
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Router       /webhooks/{id}/deliveries/{deliveryID} [get]
+func (h *WebhookHandler) Delivery(c echo.Context) error {
+	id, deliveryID, err := parseDeliveryIDs(c)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	delivery, err := h.Service.Delivery(c.Request().Context(), id, deliveryID)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Warn().Err(err).Int64(enum.ID, deliveryID).Int(enum.Status, status).Msg("⚠️ Entrega de webhook no encontrada")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, deliveryID).Int(enum.Status, http.StatusOK).Msg("✅ Entrega de webhook encontrada")
+	return c.JSON(http.StatusOK, delivery)
+}
+
+// Redeliver godoc
+// @Summary      Redeliver webhook
+// @Description  Send a delivery again right away, whatever its status, and return the recorded result
+// @Tags         webhooks
+// @Produce      json
+// @Param        id          path      int  true  "Webhook ID"
+// @Param        deliveryID  path      int  true  "Delivery ID"
+// @Success      200         {object}  model.WebhookDelivery
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Router       /webhooks/{id}/deliveries/{deliveryID}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	id, deliveryID, err := parseDeliveryIDs(c)
+	if err != nil {
+		log.Error().Err(err).Int(enum.Status, http.StatusBadRequest).Msg("❌ ID inválido")
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	delivery, err := h.Service.Redeliver(c.Request().Context(), id, deliveryID)
+	if err != nil {
+		status := statusCodeFor(err)
+		log.Error().Err(err).Int64(enum.ID, deliveryID).Int(enum.Status, status).Msg("❌ Error al reenviar webhook")
+		return respondError(c, status, err)
+	}
+
+	log.Info().Int64(enum.ID, deliveryID).Str("result", string(delivery.Status)).Int(enum.Status, http.StatusOK).
+		Msg("✅ Webhook reenviado")
+	return c.JSON(http.StatusOK, delivery)
+}
+
+var (
+	errInvalidWebhookID  = errors.New("invalid webhook ID")
+	errInvalidDeliveryID = errors.New("invalid delivery ID")
+)
+
+// parseDeliveryIDs lee los IDs del webhook y de la entrega de la ruta.
+func parseDeliveryIDs(c echo.Context) (webhookID, deliveryID int64, err error) {
+	if webhookID, err = parseID(c.Param(enum.ID)); err != nil {
+		return 0, 0, errInvalidWebhookID
+	}
+	if deliveryID, err = parseID(c.Param("deliveryID")); err != nil {
+		return 0, 0, errInvalidDeliveryID
+	}
+	return webhookID, deliveryID, nil
+}
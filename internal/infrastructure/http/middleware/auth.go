@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/auth"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// TokenVerifier valida un bearer token y devuelve el principal que representa.
+type TokenVerifier interface {
+	Verify(token string) (*model.Principal, error)
+}
+
+// APIKeyHeader es el header alternativo para presentar una API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator valida una API key presentada desde una IP y devuelve su principal.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key, ip string) (*model.Principal, error)
+}
+
+// RequestVerifier valida una petición firmada y devuelve el principal del cliente que la firmó.
+type RequestVerifier interface {
+	VerifyRequest(r *http.Request) (*model.Principal, error)
+}
+
+// AuthConfig configura el middleware de autenticación.
+type AuthConfig struct {
+	Verifier TokenVerifier
+	// Signatures, si se indica, acepta peticiones firmadas con HMAC por clientes internos.
+	Signatures RequestVerifier
+	// APIKeys, si se indica, acepta API keys en el header X-API-Key o como bearer token.
+	APIKeys APIKeyAuthenticator
+	// PublicPaths son prefijos de ruta que no requieren autenticación (p. ej. "/swagger").
+	PublicPaths []string
+}
+
+// Auth exige una firma HMAC, una API key o un bearer token válidos, deja el principal en el contexto de echo (clave enum.Principal)
+// y en el contexto de la petición (model.PrincipalFrom) para que lo usen handlers y servicios.
+func Auth(cfg AuthConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Las operaciones de POST /batch llegan con el principal de la petición original.
+			if principal, ok := batchPrincipal(c); ok {
+				SetPrincipal(c, principal)
+				return next(c)
+			}
+
+			if isPublic(c.Request().URL.Path, cfg.PublicPaths) {
+				return next(c)
+			}
+
+			if cfg.Signatures != nil && auth.IsSigned(c.Request()) {
+				return verifySignature(c, next, cfg.Signatures)
+			}
+
+			if key, ok := apiKey(c.Request()); ok && cfg.APIKeys != nil {
+				return authenticateAPIKey(c, next, cfg.APIKeys, key)
+			}
+
+			token, ok := bearerToken(c.Request())
+			if !ok {
+				return unauthorized(c, "missing bearer token", "invalid_request")
+			}
+
+			principal, err := cfg.Verifier.Verify(token)
+			if err != nil {
+				log.Warn().Err(err).Str("path", c.Request().URL.Path).Int(enum.Status, http.StatusUnauthorized).Msg("🔒 Token rechazado")
+				if errors.Is(err, auth.ErrTokenExpired) {
+					return unauthorized(c, "token expired", "invalid_token")
+				}
+				return unauthorized(c, "invalid token", "invalid_token")
+			}
+
+			SetPrincipal(c, principal)
+			log.Debug().Str("subject", principal.Subject).Strs("roles", principal.Roles).Msg("🔓 Petición autenticada")
+			return next(c)
+		}
+	}
+}
+
+func verifySignature(c echo.Context, next echo.HandlerFunc, signatures RequestVerifier) error {
+	principal, err := signatures.VerifyRequest(c.Request())
+	if err != nil {
+		log.Warn().Err(err).Str("path", c.Request().URL.Path).Int(enum.Status, http.StatusUnauthorized).Msg("🔒 Firma rechazada")
+		switch {
+		case errors.Is(err, auth.ErrSignatureExpired):
+			return unauthorized(c, "signature expired", "invalid_token")
+		case errors.Is(err, auth.ErrReplayedRequest):
+			return unauthorized(c, "replayed request", "invalid_token")
+		default:
+			return unauthorized(c, "invalid signature", "invalid_token")
+		}
+	}
+
+	SetPrincipal(c, principal)
+	log.Debug().Str("subject", principal.Subject).Msg("🔓 Petición firmada autenticada")
+	return next(c)
+}
+
+func authenticateAPIKey(c echo.Context, next echo.HandlerFunc, keys APIKeyAuthenticator, key string) error {
+	principal, err := keys.Authenticate(c.Request().Context(), key, c.RealIP())
+	if err != nil {
+		log.Warn().Err(err).Str("path", c.Request().URL.Path).Msg("🔒 API key rechazada")
+		switch {
+		case errors.Is(err, model.ErrForbidden):
+			return c.JSON(http.StatusForbidden, echo.Map{"error": err.Error()})
+		case errors.Is(err, model.ErrUnauthenticated):
+			return unauthorized(c, "invalid API key", "invalid_token")
+		default:
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "internal server error"})
+		}
+	}
+
+	SetPrincipal(c, principal)
+	log.Debug().Str("subject", principal.Subject).Msg("🔓 Petición autenticada con API key")
+	return next(c)
+}
+
+// batchPrincipalKey es la clave privada del principal de una operación de POST /batch: sólo
+// WithBatchPrincipal la escribe, así que ningún otro valor del contexto evita la autenticación.
+type batchPrincipalKey struct{}
+
+// WithBatchPrincipal devuelve el contexto de una operación de POST /batch, que se ejecuta con el
+// principal ya autenticado de la petición original.
+func WithBatchPrincipal(ctx context.Context, p *model.Principal) context.Context {
+	return context.WithValue(ctx, batchPrincipalKey{}, p)
+}
+
+func batchPrincipal(c echo.Context) (*model.Principal, bool) {
+	p, ok := c.Request().Context().Value(batchPrincipalKey{}).(*model.Principal)
+	return p, ok && p != nil
+}
+
+// BatchAuth autentica las operaciones de un lote atómico, que se despachan a un router propio
+// sin el middleware Auth: sólo deja pasar las que traen el principal de WithBatchPrincipal.
+func BatchAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := batchPrincipal(c)
+			if !ok {
+				return unauthorized(c, "missing bearer token", "invalid_request")
+			}
+			SetPrincipal(c, principal)
+			return next(c)
+		}
+	}
+}
+
+// Anonymous deja un principal administrador en cada petición; se usa sólo con AUTH_DISABLED=true
+// para que las comprobaciones de permisos de los servicios no bloqueen el desarrollo local.
+func Anonymous() echo.MiddlewareFunc {
+	principal := &model.Principal{Subject: enum.Anonymous, Roles: []string{model.RoleAdmin}, AuthMethod: "none"}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			SetPrincipal(c, principal)
+			return next(c)
+		}
+	}
+}
+
+// SetPrincipal guarda el principal en el contexto de echo y en el de la petición.
+func SetPrincipal(c echo.Context, p *model.Principal) {
+	c.Set(enum.Principal, p)
+	c.SetRequest(c.Request().WithContext(model.WithPrincipal(c.Request().Context(), p)))
+}
+
+// PrincipalFrom devuelve el principal autenticado de la petición, si existe.
+func PrincipalFrom(c echo.Context) (*model.Principal, bool) {
+	p, ok := c.Get(enum.Principal).(*model.Principal)
+	return p, ok && p != nil
+}
+
+// apiKey obtiene la API key del header X-API-Key o de un bearer token con el prefijo de las claves.
+func apiKey(r *http.Request) (string, bool) {
+	if key := strings.TrimSpace(r.Header.Get(APIKeyHeader)); key != "" {
+		return key, true
+	}
+	if token, ok := bearerToken(r); ok && strings.HasPrefix(token, model.APIKeyTokenPrefix) {
+		return token, true
+	}
+	return "", false
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get(echo.HeaderAuthorization)
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func isPublic(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func unauthorized(c echo.Context, msg, code string) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="`+code+`"`)
+	return c.JSON(http.StatusUnauthorized, echo.Map{"error": msg})
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/labstack/echo/v4"
+)
+
+// Base devuelve los middlewares que van antes de la autenticación en todos los routers,
+// incluido el de los lotes atómicos.
+func Base() []echo.MiddlewareFunc {
+	return []echo.MiddlewareFunc{Locale()}
+}
+
+// Locale deja en el contexto de la petición el idioma preferido según Accept-Language,
+// para que los emails que dispare la petición salgan en ese idioma.
+func Locale() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if locale := parseAcceptLanguage(c.Request().Header.Get("Accept-Language")); locale != "" {
+				c.SetRequest(c.Request().WithContext(model.WithLocale(c.Request().Context(), locale)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// parseAcceptLanguage elige el primer idioma soportado del header. No tiene en cuenta los
+// pesos "q": los clientes ya envían los idiomas en orden de preferencia.
+func parseAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if lang == model.LocaleES || lang == model.LocaleEN {
+			return lang
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package scim
+
+// MaxResults es el máximo de recursos por página; count mayores se recortan.
+const MaxResults = 200
+
+// Supported indica si una característica opcional está disponible.
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+// FilterSupport describe el soporte de filtros.
+type FilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// BulkSupport describe el soporte de operaciones bulk.
+type BulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// AuthenticationScheme es un mecanismo de autenticación aceptado.
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary,omitempty"`
+}
+
+// ServiceProviderConfig describe las capacidades del servidor (RFC 7643, sección 5).
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkSupport            `json:"bulk"`
+	Filter                FilterSupport          `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta,omitempty"`
+}
+
+// NewServiceProviderConfig devuelve la configuración: PATCH y filtros sí; bulk, sort, etag y
+// cambio de contraseña no. La autenticación es una API key enviada como bearer token.
+func NewServiceProviderConfig(base string) *ServiceProviderConfig {
+	return &ServiceProviderConfig{
+		Schemas: []string{SchemaServiceProviderConfig},
+		Patch:   Supported{Supported: true},
+		Filter:  FilterSupport{Supported: true, MaxResults: MaxResults},
+		AuthenticationSchemes: []AuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "API key",
+			Description: "API key sent as a bearer token in the Authorization header",
+			Primary:     true,
+		}},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig", Location: base + "/ServiceProviderConfig"},
+	}
+}
+
+// ResourceType describe un tipo de recurso expuesto.
+type ResourceType struct {
+	Schemas  []string `json:"schemas"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Schema   string   `json:"schema"`
+	Meta     *Meta    `json:"meta,omitempty"`
+}
+
+// ResourceTypes devuelve los tipos User y Group.
+func ResourceTypes(base string) []interface{} {
+	resourceType := func(name, schema string) interface{} {
+		return &ResourceType{
+			Schemas:  []string{SchemaResourceType},
+			ID:       name,
+			Name:     name,
+			Endpoint: "/" + name + "s",
+			Schema:   schema,
+			Meta:     &Meta{ResourceType: "ResourceType", Location: base + "/ResourceTypes/" + name},
+		}
+	}
+	return []interface{}{resourceType("User", SchemaUser), resourceType("Group", SchemaGroup)}
+}
@@ -0,0 +1,63 @@
+package scim
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// Valores de scimType (RFC 7644, sección 3.12).
+const (
+	ScimTypeInvalidFilter = "invalidFilter"
+	ScimTypeInvalidSyntax = "invalidSyntax"
+	ScimTypeInvalidPath   = "invalidPath"
+	ScimTypeInvalidValue  = "invalidValue"
+	ScimTypeMutability    = "mutability"
+	ScimTypeUniqueness    = "uniqueness"
+)
+
+// Error es un error de protocolo SCIM; siempre se responde con 400 salvo uniqueness (409).
+type Error struct {
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string { return e.Detail }
+
+// Errorf crea un error de protocolo con el scimType indicado.
+func Errorf(scimType, format string, args ...interface{}) error {
+	return &Error{ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+// ErrorResponse es el cuerpo de error de SCIM.
+type ErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewErrorResponse traduce un error de protocolo o de dominio y devuelve el código HTTP final;
+// status es el que corresponde a los errores de dominio según su categoría.
+func NewErrorResponse(status int, err error) (int, *ErrorResponse) {
+	resp := &ErrorResponse{Schemas: []string{SchemaError}, Detail: err.Error()}
+
+	var scimErr *Error
+	switch {
+	case errors.As(err, &scimErr):
+		resp.ScimType = scimErr.ScimType
+		status = http.StatusBadRequest
+		if scimErr.ScimType == ScimTypeUniqueness {
+			status = http.StatusConflict
+		}
+	case errors.Is(err, model.ErrEmailTaken), errors.Is(err, model.ErrGroupExists):
+		resp.ScimType = ScimTypeUniqueness
+	case errors.Is(err, model.ErrInvalid):
+		resp.ScimType = ScimTypeInvalidValue
+	}
+	resp.Status = strconv.Itoa(status)
+	return status, resp
+}
@@ -0,0 +1,239 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// Comparison es una condición de un filtro: "atributo operador valor" o "atributo pr".
+type Comparison struct {
+	// Attr es la ruta del atributo en minúsculas y sin el URN del esquema (p. ej. "username").
+	Attr  string
+	Op    model.FilterOp
+	Value interface{}
+}
+
+// ParseFilter interpreta el subconjunto de la sintaxis de filtros (RFC 7644, sección 3.4.2.2)
+// que se traduce a los filtros del dominio: comparaciones eq, ne, co, sw, ew y pr unidas con
+// "and". Los operadores or, not, gt/ge/lt/le y los paréntesis se rechazan con invalidFilter.
+func ParseFilter(expr string) ([]Comparison, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var comparisons []Comparison
+	for i := 0; i < len(tokens); {
+		if len(comparisons) > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, Errorf(ScimTypeInvalidFilter, "unsupported filter: expected \"and\" before %q", tokens[i])
+			}
+			i++
+		}
+		if i+1 >= len(tokens) {
+			return nil, Errorf(ScimTypeInvalidFilter, "incomplete filter %q", expr)
+		}
+
+		cmp := Comparison{Attr: normalizePath(tokens[i]), Op: model.FilterOp(strings.ToLower(tokens[i+1]))}
+		switch cmp.Op {
+		case model.FilterPresent:
+			i += 2
+		case model.FilterEq, model.FilterNe, model.FilterContains, model.FilterStartsWith, model.FilterEndsWith:
+			if i+2 >= len(tokens) {
+				return nil, Errorf(ScimTypeInvalidFilter, "missing value after %q", tokens[i+1])
+			}
+			if cmp.Value, err = parseValue(tokens[i+2]); err != nil {
+				return nil, err
+			}
+			i += 3
+		default:
+			return nil, Errorf(ScimTypeInvalidFilter, "unsupported filter operator %q", tokens[i+1])
+		}
+		comparisons = append(comparisons, cmp)
+	}
+	if len(comparisons) == 0 {
+		return nil, Errorf(ScimTypeInvalidFilter, "empty filter")
+	}
+	return comparisons, nil
+}
+
+// tokenize separa el filtro por espacios respetando los textos entre comillas.
+func tokenize(expr string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inString, escaped := false, false
+
+	for _, r := range expr {
+		switch {
+		case inString:
+			current.WriteRune(r)
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+		case r == '"':
+			inString = true
+			current.WriteRune(r)
+		case r == '(' || r == ')' || r == '[' || r == ']':
+			return nil, Errorf(ScimTypeInvalidFilter, "unsupported filter: grouping and value filters are not supported")
+		case r == ' ' || r == '\t':
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inString {
+		return nil, Errorf(ScimTypeInvalidFilter, "unterminated string in filter")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseValue interpreta un valor JSON: texto entre comillas, true, false, null o número.
+func parseValue(token string) (interface{}, error) {
+	if strings.HasPrefix(token, `"`) {
+		var s string
+		if err := json.Unmarshal([]byte(token), &s); err != nil {
+			return nil, Errorf(ScimTypeInvalidFilter, "invalid string %s", token)
+		}
+		return s, nil
+	}
+	switch strings.ToLower(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(token, 10, 64); err == nil {
+		return n, nil
+	}
+	return nil, Errorf(ScimTypeInvalidFilter, "invalid value %q", token)
+}
+
+// normalizePath quita el URN del esquema y pasa la ruta a minúsculas: en SCIM los nombres de
+// atributo no distinguen mayúsculas.
+func normalizePath(path string) string {
+	lower := strings.ToLower(path)
+	for _, schema := range []string{SchemaUser, SchemaGroup} {
+		if prefix := strings.ToLower(schema) + ":"; strings.HasPrefix(lower, prefix) {
+			return lower[len(prefix):]
+		}
+	}
+	return lower
+}
+
+// UserFilter traduce un filtro de /Users a los filtros de UserService.List: userName y
+// emails.value filtran por email, displayName y name.formatted por nombre y active por estado.
+func UserFilter(expr string) (map[string]interface{}, error) {
+	return domainFilter(expr, func(cmp Comparison) (string, model.Filter, error) {
+		switch cmp.Attr {
+		case "username", "emails", "emails.value":
+			return textFilter("email", cmp)
+		case "displayname", "name.formatted":
+			return textFilter("name", cmp)
+		case "id":
+			return userIDFilter(cmp)
+		case "active":
+			active, ok := cmp.Value.(bool)
+			if !ok || (cmp.Op != model.FilterEq && cmp.Op != model.FilterNe) {
+				return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "active only supports eq and ne with true or false")
+			}
+			op := model.FilterEq
+			if active != (cmp.Op == model.FilterEq) {
+				op = model.FilterNe
+			}
+			return "status", model.Filter{Op: op, Value: string(model.UserStatusActive)}, nil
+		}
+		return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "filtering by %q is not supported", cmp.Attr)
+	})
+}
+
+// GroupFilter traduce un filtro de /Groups: displayName filtra por nombre.
+func GroupFilter(expr string) (map[string]interface{}, error) {
+	return domainFilter(expr, func(cmp Comparison) (string, model.Filter, error) {
+		switch cmp.Attr {
+		case "displayname":
+			return textFilter("name", cmp)
+		case "id":
+			return idFilter(cmp)
+		}
+		return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "filtering by %q is not supported", cmp.Attr)
+	})
+}
+
+func domainFilter(expr string, translate func(Comparison) (string, model.Filter, error)) (map[string]interface{}, error) {
+	filter := make(map[string]interface{})
+	if strings.TrimSpace(expr) == "" {
+		return filter, nil
+	}
+	comparisons, err := ParseFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmp := range comparisons {
+		key, value, err := translate(cmp)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := filter[key]; dup {
+			return nil, Errorf(ScimTypeInvalidFilter, "only one condition per attribute is supported")
+		}
+		filter[key] = value
+	}
+	return filter, nil
+}
+
+func textFilter(column string, cmp Comparison) (string, model.Filter, error) {
+	if _, ok := cmp.Value.(string); !ok && cmp.Op != model.FilterPresent {
+		return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "%s expects a string value", cmp.Attr)
+	}
+	return column, model.Filter{Op: cmp.Op, Value: cmp.Value}, nil
+}
+
+// userIDFilter filtra usuarios por su id SCIM, que es el ID público; también admite el ID numérico.
+func userIDFilter(cmp Comparison) (string, model.Filter, error) {
+	if cmp.Op != model.FilterEq && cmp.Op != model.FilterNe {
+		return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "id only supports eq and ne")
+	}
+	if publicID, err := uuid.Parse(toString(cmp.Value)); err == nil {
+		return "public_id", model.Filter{Op: cmp.Op, Value: publicID.String()}, nil
+	}
+	return idFilter(cmp)
+}
+
+func idFilter(cmp Comparison) (string, model.Filter, error) {
+	if cmp.Op != model.FilterEq && cmp.Op != model.FilterNe {
+		return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "id only supports eq and ne")
+	}
+	id, err := ParseID(toString(cmp.Value))
+	if err != nil {
+		return "", model.Filter{}, Errorf(ScimTypeInvalidFilter, "invalid id %v", cmp.Value)
+	}
+	return "id", model.Filter{Op: cmp.Op, Value: id}, nil
+}
+
+func toString(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case int64:
+		return strconv.FormatInt(x, 10)
+	default:
+		return ""
+	}
+}
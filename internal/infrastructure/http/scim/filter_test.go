@@ -0,0 +1,225 @@
+package scim
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want []Comparison
+	}{
+		{
+			name: "comparación simple",
+			expr: `userName eq "ana@example.com"`,
+			want: []Comparison{{Attr: "username", Op: model.FilterEq, Value: "ana@example.com"}},
+		},
+		{
+			name: "operador y atributo sin distinguir mayúsculas",
+			expr: `DisplayName CO "Ana"`,
+			want: []Comparison{{Attr: "displayname", Op: model.FilterContains, Value: "Ana"}},
+		},
+		{
+			name: "URN del esquema",
+			expr: `urn:ietf:params:scim:schemas:core:2.0:User:userName sw "ana"`,
+			want: []Comparison{{Attr: "username", Op: model.FilterStartsWith, Value: "ana"}},
+		},
+		{
+			name: "texto con espacios",
+			expr: `displayName eq "Ana  María"`,
+			want: []Comparison{{Attr: "displayname", Op: model.FilterEq, Value: "Ana  María"}},
+		},
+		{
+			name: "comillas escapadas",
+			expr: `displayName eq "Ana \"la jefa\" and co"`,
+			want: []Comparison{{Attr: "displayname", Op: model.FilterEq, Value: `Ana "la jefa" and co`}},
+		},
+		{
+			name: "barra invertida escapada",
+			expr: `displayName ew "a\\"`,
+			want: []Comparison{{Attr: "displayname", Op: model.FilterEndsWith, Value: `a\`}},
+		},
+		{
+			name: "pr sin valor",
+			expr: `emails pr`,
+			want: []Comparison{{Attr: "emails", Op: model.FilterPresent}},
+		},
+		{
+			name: "cadena de and",
+			expr: `userName sw "ana" AND active eq true and displayName pr`,
+			want: []Comparison{
+				{Attr: "username", Op: model.FilterStartsWith, Value: "ana"},
+				{Attr: "active", Op: model.FilterEq, Value: true},
+				{Attr: "displayname", Op: model.FilterPresent},
+			},
+		},
+		{
+			name: "valores false, null y número",
+			expr: "active ne false and title eq null and id eq 12",
+			want: []Comparison{
+				{Attr: "active", Op: model.FilterNe, Value: false},
+				{Attr: "title", Op: model.FilterEq, Value: nil},
+				{Attr: "id", Op: model.FilterEq, Value: int64(12)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseFilter(%q) = %#v, want %#v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "vacío", expr: "   "},
+		{name: "or", expr: `userName eq "a" or userName eq "b"`},
+		{name: "not", expr: `not userName eq "a"`},
+		{name: "gt", expr: `meta.lastModified gt "2024-01-01"`},
+		{name: "ge", expr: "id ge 1"},
+		{name: "lt", expr: "id lt 1"},
+		{name: "le", expr: "id le 1"},
+		{name: "paréntesis", expr: `(userName eq "a")`},
+		{name: "filtro de valor", expr: `emails[type eq "work"]`},
+		{name: "texto sin cerrar", expr: `userName eq "ana`},
+		{name: "falta el valor", expr: "userName eq"},
+		{name: "falta el operador", expr: "userName"},
+		{name: "and colgante", expr: `userName eq "a" and`},
+		{name: "falta el and", expr: `userName eq "a" active eq true`},
+		{name: "valor sin comillas", expr: "userName eq ana"},
+		{name: "escape inválido", expr: `userName eq "a\q"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(tt.expr)
+			assertInvalidFilter(t, tt.expr, err)
+		})
+	}
+}
+
+func TestUserFilter(t *testing.T) {
+	active := model.Filter{Op: model.FilterEq, Value: string(model.UserStatusActive)}
+	inactive := model.Filter{Op: model.FilterNe, Value: string(model.UserStatusActive)}
+
+	tests := []struct {
+		name string
+		expr string
+		want map[string]interface{}
+	}{
+		{name: "sin filtro", expr: "", want: map[string]interface{}{}},
+		{
+			name: "userName filtra por email",
+			expr: `userName eq "ana@example.com"`,
+			want: map[string]interface{}{"email": model.Filter{Op: model.FilterEq, Value: "ana@example.com"}},
+		},
+		{
+			name: "emails.value filtra por email",
+			expr: `emails.value co "example"`,
+			want: map[string]interface{}{"email": model.Filter{Op: model.FilterContains, Value: "example"}},
+		},
+		{
+			name: "name.formatted pr filtra por nombre",
+			expr: "name.formatted pr",
+			want: map[string]interface{}{"name": model.Filter{Op: model.FilterPresent}},
+		},
+		{name: "active eq true", expr: "active eq true", want: map[string]interface{}{"status": active}},
+		{name: "active ne false", expr: "active ne false", want: map[string]interface{}{"status": active}},
+		{name: "active eq false", expr: "active eq false", want: map[string]interface{}{"status": inactive}},
+		{name: "active ne true", expr: "active ne true", want: map[string]interface{}{"status": inactive}},
+		{
+			name: "id público",
+			expr: `id eq "0190F3C2-7A6B-7C3D-9E1F-2A3B4C5D6E7F"`,
+			want: map[string]interface{}{"public_id": model.Filter{Op: model.FilterEq, Value: "0190f3c2-7a6b-7c3d-9e1f-2a3b4c5d6e7f"}},
+		},
+		{
+			name: "id numérico",
+			expr: `id ne "12"`,
+			want: map[string]interface{}{"id": model.Filter{Op: model.FilterNe, Value: int64(12)}},
+		},
+		{
+			name: "cadena de and",
+			expr: `userName ew "@example.com" and displayName sw "Ana" and active ne false`,
+			want: map[string]interface{}{
+				"email":  model.Filter{Op: model.FilterEndsWith, Value: "@example.com"},
+				"name":   model.Filter{Op: model.FilterStartsWith, Value: "Ana"},
+				"status": active,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("UserFilter(%q): %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("UserFilter(%q) = %#v, want %#v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserFilterRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "atributo desconocido", expr: `title eq "jefa"`},
+		{name: "active con texto", expr: `active eq "true"`},
+		{name: "active con co", expr: "active co true"},
+		{name: "active pr", expr: "active pr"},
+		{name: "id con sw", expr: `id sw "12"`},
+		{name: "id inválido", expr: `id eq "zz"`},
+		{name: "email con número", expr: "userName eq 12"},
+		{name: "atributo repetido", expr: `userName sw "a" and emails.value ew "b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UserFilter(tt.expr)
+			assertInvalidFilter(t, tt.expr, err)
+		})
+	}
+}
+
+func TestGroupFilter(t *testing.T) {
+	got, err := GroupFilter(`displayName eq "Admins" and id eq "3"`)
+	if err != nil {
+		t.Fatalf("GroupFilter: %v", err)
+	}
+	want := map[string]interface{}{
+		"name": model.Filter{Op: model.FilterEq, Value: "Admins"},
+		"id":   model.Filter{Op: model.FilterEq, Value: int64(3)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GroupFilter = %#v, want %#v", got, want)
+	}
+
+	_, err = GroupFilter(`userName eq "ana"`)
+	assertInvalidFilter(t, `userName eq "ana"`, err)
+}
+
+func assertInvalidFilter(t *testing.T, expr string, err error) {
+	t.Helper()
+	var scimErr *Error
+	if !errors.As(err, &scimErr) || scimErr.ScimType != ScimTypeInvalidFilter {
+		t.Fatalf("%q: got %v, want scimType %s", expr, err, ScimTypeInvalidFilter)
+	}
+}
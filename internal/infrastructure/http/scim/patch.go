@@ -0,0 +1,262 @@
+package scim
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PatchRequest es el cuerpo de PATCH (RFC 7644, sección 3.5.2).
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation es una operación add, replace o remove. Sin path, value es un objeto con los
+// atributos a modificar.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// memberValuePath reconoce el path members[value eq "id"].
+var memberValuePath = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
+
+// Validate comprueba el esquema del mensaje y que haya al menos una operación.
+func (p *PatchRequest) Validate() error {
+	hasSchema := false
+	for _, schema := range p.Schemas {
+		hasSchema = hasSchema || schema == SchemaPatchOp
+	}
+	if !hasSchema {
+		return Errorf(ScimTypeInvalidSyntax, "schemas must contain %s", SchemaPatchOp)
+	}
+	if len(p.Operations) == 0 {
+		return Errorf(ScimTypeInvalidSyntax, "at least one operation is required")
+	}
+	return nil
+}
+
+// ApplyTo aplica las operaciones sobre el usuario. Los atributos que el dominio no guarda (p. ej.
+// los de la extensión enterprise) se ignoran; emails se deriva de userName.
+func (p *PatchRequest) ApplyTo(u *User) error {
+	return p.apply(u.set, u.remove)
+}
+
+// ApplyToGroup aplica las operaciones sobre el grupo, cuyos miembros deben estar completos.
+func (p *PatchRequest) ApplyToGroup(g *Group) error {
+	return p.apply(g.set, g.remove)
+}
+
+func (p *PatchRequest) apply(
+	set func(op, path string, value json.RawMessage) error,
+	remove func(path string, value json.RawMessage) error,
+) error {
+	for _, operation := range p.Operations {
+		op := strings.ToLower(operation.Op)
+		path := normalizePath(strings.TrimSpace(operation.Path))
+
+		switch {
+		case op == "remove":
+			if path == "" {
+				return Errorf(ScimTypeInvalidPath, "remove requires a path")
+			}
+			if err := remove(path, operation.Value); err != nil {
+				return err
+			}
+		case op != "add" && op != "replace":
+			return Errorf(ScimTypeInvalidSyntax, "unsupported operation %q", operation.Op)
+		case path != "":
+			if err := set(op, path, operation.Value); err != nil {
+				return err
+			}
+		default:
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(operation.Value, &attrs); err != nil {
+				return Errorf(ScimTypeInvalidSyntax, "%s without path requires an object value", op)
+			}
+			for key, value := range attrs {
+				if err := set(op, normalizePath(key), value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (u *User) set(_ string, path string, value json.RawMessage) error {
+	switch path {
+	case "active":
+		active, err := decodeBool(path, value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+	case "username":
+		return decodeString(path, value, &u.UserName)
+	case "externalid":
+		return decodeString(path, value, &u.ExternalID)
+	case "displayname", "name.formatted":
+		var name string
+		if err := decodeString(path, value, &name); err != nil {
+			return err
+		}
+		u.setName(name)
+	case "name.givenname", "name.familyname":
+		var part string
+		if err := decodeString(path, value, &part); err != nil {
+			return err
+		}
+		given, family := u.nameParts()
+		if path == "name.givenname" {
+			given = part
+		} else {
+			family = part
+		}
+		u.setName(strings.TrimSpace(given + " " + family))
+	case "name":
+		var name Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return Errorf(ScimTypeInvalidValue, "name must be an object")
+		}
+		full := strings.TrimSpace(name.Formatted)
+		if full == "" {
+			full = strings.TrimSpace(name.GivenName + " " + name.FamilyName)
+		}
+		u.setName(full)
+	case "id", "meta", "groups":
+		return Errorf(ScimTypeMutability, "%s is read-only", path)
+	}
+	return nil
+}
+
+func (u *User) remove(path string, _ json.RawMessage) error {
+	switch path {
+	case "username":
+		return Errorf(ScimTypeMutability, "userName is required and cannot be removed")
+	case "active":
+		inactive := false
+		u.Active = &inactive
+	case "externalid":
+		u.ExternalID = ""
+	case "displayname", "name", "name.formatted":
+		u.DisplayName, u.Name = "", nil
+	case "name.givenname":
+		_, family := u.nameParts()
+		u.setName(family)
+	case "name.familyname":
+		given, _ := u.nameParts()
+		u.setName(given)
+	case "id", "meta", "groups":
+		return Errorf(ScimTypeMutability, "%s is read-only", path)
+	}
+	return nil
+}
+
+func (u *User) nameParts() (given, family string) {
+	if u.Name == nil {
+		return "", ""
+	}
+	return u.Name.GivenName, u.Name.FamilyName
+}
+
+func (g *Group) set(op, path string, value json.RawMessage) error {
+	switch path {
+	case "displayname":
+		return decodeString(path, value, &g.DisplayName)
+	case "externalid":
+		return decodeString(path, value, &g.ExternalID)
+	case "members":
+		var members []MultiValued
+		if err := json.Unmarshal(value, &members); err != nil {
+			return Errorf(ScimTypeInvalidValue, "members must be an array of {\"value\": id}")
+		}
+		if _, err := memberRefs(members); err != nil {
+			return err
+		}
+		if op == "replace" {
+			g.Members = nil
+		}
+		for _, m := range members {
+			if !g.hasMember(m.Value) {
+				g.Members = append(g.Members, MultiValued{Value: m.Value})
+			}
+		}
+	case "id", "meta":
+		return Errorf(ScimTypeMutability, "%s is read-only", path)
+	}
+	return nil
+}
+
+func (g *Group) remove(path string, value json.RawMessage) error {
+	if match := memberValuePath.FindStringSubmatch(path); match != nil {
+		g.removeMembers(match[1])
+		return nil
+	}
+	switch path {
+	case "members":
+		// Sin value se quitan todos; con un arreglo, sólo esos.
+		if len(value) == 0 || string(value) == "null" {
+			g.Members = nil
+			return nil
+		}
+		var members []MultiValued
+		if err := json.Unmarshal(value, &members); err != nil {
+			return Errorf(ScimTypeInvalidValue, "members must be an array of {\"value\": id}")
+		}
+		for _, m := range members {
+			g.removeMembers(m.Value)
+		}
+	case "externalid":
+		g.ExternalID = ""
+	case "displayname":
+		return Errorf(ScimTypeMutability, "displayName is required and cannot be removed")
+	case "id", "meta":
+		return Errorf(ScimTypeMutability, "%s is read-only", path)
+	}
+	return nil
+}
+
+func (g *Group) hasMember(value string) bool {
+	for _, m := range g.Members {
+		if m.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Group) removeMembers(value string) {
+	kept := g.Members[:0]
+	for _, m := range g.Members {
+		if m.Value != value {
+			kept = append(kept, m)
+		}
+	}
+	g.Members = kept
+}
+
+func decodeString(path string, value json.RawMessage, target *string) error {
+	if err := json.Unmarshal(value, target); err != nil {
+		return Errorf(ScimTypeInvalidValue, "%s must be a string", path)
+	}
+	return nil
+}
+
+// decodeBool acepta también "True"/"False" como texto, que envían algunos proveedores.
+func decodeBool(path string, value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, Errorf(ScimTypeInvalidValue, "%s must be a boolean", path)
+}
@@ -0,0 +1,196 @@
+// Package scim contiene los recursos y mensajes de SCIM 2.0 (RFC 7643 y 7644) y su traducción a
+// los modelos del dominio. Los handlers viven en el paquete handler, como el resto de la API.
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// ContentType es el tipo de contenido de las peticiones y respuestas SCIM.
+const ContentType = "application/scim+json"
+
+// URIs de los esquemas y mensajes.
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// Meta son los metadatos de un recurso.
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+// Name es el nombre de un usuario. El dominio guarda un único nombre completo: givenName es la
+// primera palabra y familyName el resto.
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValued es un elemento de un atributo multivaluado (emails, members, groups).
+type MultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User es el recurso User del esquema core. userName es el email del usuario.
+type User struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	UserName    string        `json:"userName"`
+	Name        *Name         `json:"name,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Emails      []MultiValued `json:"emails,omitempty"`
+	Active      *bool         `json:"active,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// Group es el recurso Group del esquema core; los miembros son usuarios.
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []MultiValued `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// ListResponse es la respuesta paginada de una consulta.
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// NewListResponse arma la respuesta de una página que empieza en startIndex (base 1).
+func NewListResponse(resources []interface{}, total, startIndex int) *ListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// FromUser traduce un usuario del dominio; base es la URL de /scim/v2 para meta.location. El id
+// es el ID público del usuario.
+func FromUser(u *model.User, base string) *User {
+	id := u.PublicID
+	active := u.Status == model.UserStatusActive
+	user := &User{
+		Schemas:  []string{SchemaUser},
+		ID:       id,
+		UserName: u.Email,
+		Emails:   []MultiValued{{Value: u.Email, Type: "work", Primary: true}},
+		Active:   &active,
+		Meta:     &Meta{ResourceType: "User", Location: base + "/Users/" + id},
+	}
+	user.setName(u.Name)
+	return user
+}
+
+// ToUser aplica el recurso sobre un usuario del dominio (nombre y email) y devuelve el valor de
+// active, o nil si no se indicó.
+func (u *User) ToUser(target *model.User) (*bool, error) {
+	email := strings.TrimSpace(u.UserName)
+	if email == "" {
+		return nil, Errorf(ScimTypeInvalidValue, "userName is required")
+	}
+	if !strings.Contains(email, "@") {
+		return nil, Errorf(ScimTypeInvalidValue, "userName must be an email address")
+	}
+	target.Email = email
+	target.Name = u.fullName()
+	return u.Active, nil
+}
+
+// fullName elige el nombre completo: displayName, name.formatted, givenName + familyName o,
+// en último caso, userName.
+func (u *User) fullName() string {
+	if name := strings.TrimSpace(u.DisplayName); name != "" {
+		return name
+	}
+	if u.Name != nil {
+		if name := strings.TrimSpace(u.Name.Formatted); name != "" {
+			return name
+		}
+		if name := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); name != "" {
+			return name
+		}
+	}
+	return strings.TrimSpace(u.UserName)
+}
+
+// setName mantiene sincronizados displayName y name a partir del nombre completo.
+func (u *User) setName(full string) {
+	given, family, _ := strings.Cut(strings.TrimSpace(full), " ")
+	u.DisplayName = full
+	u.Name = &Name{Formatted: full, GivenName: given, FamilyName: strings.TrimSpace(family)}
+}
+
+// FromGroup traduce un grupo del dominio con sus miembros (nil si no se consultaron).
+func FromGroup(g *model.Group, members []*model.User, base string) *Group {
+	id := strconv.FormatInt(g.ID, 10)
+	group := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          id,
+		DisplayName: g.Name,
+		Meta:        &Meta{ResourceType: "Group", Location: base + "/Groups/" + id},
+	}
+	for _, m := range members {
+		group.Members = append(group.Members, MultiValued{
+			Value:   m.PublicID,
+			Display: m.Name,
+			Type:    "User",
+			Ref:     base + "/Users/" + m.PublicID,
+		})
+	}
+	return group
+}
+
+// MemberRefs devuelve las referencias a los usuarios miembros: el id SCIM (ID público) o el
+// ID numérico.
+func (g *Group) MemberRefs() ([]model.UserRef, error) {
+	return memberRefs(g.Members)
+}
+
+func memberRefs(members []MultiValued) ([]model.UserRef, error) {
+	refs := make([]model.UserRef, 0, len(members))
+	for _, m := range members {
+		value := strings.TrimSpace(m.Value)
+		if value == "" {
+			return nil, Errorf(ScimTypeInvalidValue, "member value is required")
+		}
+		refs = append(refs, model.UserRef(value))
+	}
+	return refs, nil
+}
+
+// ParseID convierte el id de un grupo en el ID numérico del dominio.
+func ParseID(value string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, Errorf(ScimTypeInvalidValue, "invalid id %q", value)
+	}
+	return id, nil
+}
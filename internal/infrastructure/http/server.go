@@ -1,18 +1,56 @@
 package infrastructure
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
 	_ "github.com/jnates/crud_golang/docs"
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/infrastructure/auth"
 	"github.com/jnates/crud_golang/internal/infrastructure/db"
 	"github.com/jnates/crud_golang/internal/infrastructure/di"
 	"github.com/jnates/crud_golang/internal/infrastructure/http/handler"
+	"github.com/jnates/crud_golang/internal/infrastructure/http/middleware"
 	validatorPackage "github.com/jnates/crud_golang/internal/infrastructure/http/validetor"
+	"github.com/jnates/crud_golang/internal/infrastructure/jobs"
 	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/jnates/crud_golang/internal/infrastructure/rpc"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"go.uber.org/dig"
 )
 
-func Start(port string) {
+// publicPaths son los prefijos de ruta que no exigen autenticación.
+var publicPaths = []string{
+	"/swagger",
+	"/auth/login",
+	"/auth/refresh",
+	"/auth/logout",
+	"/auth/password",
+	"/auth/email/verify",
+	"/auth/invitations/accept",
+	"/auth/oidc",
+}
+
+// routes agrupa los handlers registrados en el grupo di.RoutesGroup del contenedor y las
+// tareas en segundo plano del grupo di.JobsGroup.
+type routes struct {
+	dig.In
+
+	Registrars []handler.RouteRegistrar `group:"routes"`
+	Jobs       []jobs.Job               `group:"jobs"`
+	Verifier   *auth.JWTVerifier
+	Signatures *auth.HMACVerifier
+	APIKeys    *application.APIKeyService
+	Users      *rpc.UserServer
+}
+
+// Start levanta la API REST en port y, si grpcPort no está vacío, la API gRPC en ese puerto.
+func Start(port, grpcPort string) {
 	conn := db.NewPostgresConnection()
 	container := di.BuildContainer(conn)
 	if container == nil {
@@ -20,23 +58,52 @@ func Start(port string) {
 		return
 	}
 
-	err := container.Invoke(func(userHandler *handler.UserHandler) {
+	err := container.Invoke(func(r routes) {
 		e := echo.New()
 		e.HideBanner = true
 		e.Logger.SetOutput(log.Logger)
 
 		e.Validator = validatorPackage.NewValidator()
 
+		// IP del cliente para las listas de IPs de las API keys y los logs
+		extractor, err := ipExtractor(os.Getenv("TRUSTED_PROXIES"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error al leer TRUSTED_PROXIES")
+		}
+		e.IPExtractor = extractor
+
+		// Idioma de los emails que disparen las peticiones
+		e.Use(middleware.Base()...)
+
 		// Swagger docs
 		e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+		// Autenticación (el verificador es nil con AUTH_DISABLED=true)
+		if r.Verifier != nil {
+			cfg := middleware.AuthConfig{
+				Verifier:    r.Verifier,
+				APIKeys:     r.APIKeys,
+				PublicPaths: publicPaths,
+			}
+			// Un *HMACVerifier nil dentro de la interfaz no sería nil: sólo se asigna si existe.
+			if r.Signatures != nil {
+				cfg.Signatures = r.Signatures
+			}
+			e.Use(middleware.Auth(cfg))
+		} else {
+			e.Use(middleware.Anonymous())
+		}
+
 		// Rutas de API
-		api := e.Group("/users")
-		api.GET("", userHandler.List)
-		api.GET("/:id", userHandler.Get)
-		api.POST("", userHandler.Create)
-		api.PUT("/:id", userHandler.Update)
-		api.DELETE("/:id", userHandler.Delete)
+		for _, registrar := range r.Registrars {
+			registrar.Register(e)
+		}
+
+		jobs.Start(context.Background(), r.Jobs)
+
+		if grpcPort != "" {
+			go startGRPC(grpcPort, r)
+		}
 
 		log.Info().Str(enum.APIPort, port).Msg("🚀 Servidor escuchando")
 		if err := e.Start(":" + port); err != nil {
@@ -48,3 +115,44 @@ func Start(port string) {
 		log.Fatal().Err(err).Msg("Error al inicializar dependencias con dig")
 	}
 }
+
+// ipExtractor toma la IP del cliente de la conexión. Si trustedProxies trae rangos CIDR
+// separados por comas, la toma de X-Forwarded-For saltando sólo los proxies de esos rangos;
+// sin proxies de confianza los headers se ignoran para que no se pueda falsificar la IP.
+func ipExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	if strings.TrimSpace(trustedProxies) == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range strings.Split(trustedProxies, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// startGRPC sirve la API gRPC con las mismas credenciales que la REST, salvo las firmas HMAC.
+func startGRPC(port string, r routes) {
+	cfg := rpc.AuthConfig{APIKeys: r.APIKeys}
+	// Igual que en HTTP: un *JWTVerifier nil dentro de la interfaz no sería nil.
+	if r.Verifier != nil {
+		cfg.Verifier = r.Verifier
+	}
+
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error al abrir puerto gRPC")
+	}
+	log.Info().Str(enum.GRPCPort, port).Msg("🚀 Servidor gRPC escuchando")
+	if err := rpc.NewServer(cfg, r.Users).Serve(lis); err != nil {
+		log.Fatal().Err(err).Msg("Error al iniciar servidor gRPC")
+	}
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/rs/zerolog/log"
+)
+
+// Job es una tarea periódica que corre en segundo plano dentro del proceso de la API.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start lanza cada job en su propia goroutine hasta que se cancele ctx. Los jobs corren con
+// model.SystemPrincipal; un error se registra y se reintenta en la siguiente vuelta.
+func Start(ctx context.Context, jobs []Job) {
+	ctx = model.WithPrincipal(ctx, model.SystemPrincipal)
+	for _, job := range jobs {
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job Job) {
+	log.Info().Str("job", job.Name).Dur("interval", job.Interval).Msg("⏱️ Tarea en segundo plano iniciada")
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		if err := job.Run(ctx); err != nil {
+			log.Error().Err(err).Str("job", job.Name).Msg("🔴 Error en tarea en segundo plano")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,7 @@ package enum
 
 const (
 	APIPort    string = "API_PORT"
+	GRPCPort   string = "GRPC_PORT"
 	DBHost     string = "DB_HOST"
 	DBUser     string = "DB_USER"
 	DBPassword string = "DB_PASSWORD"
@@ -9,3 +10,71 @@ const (
 	DBPort     string = "DB_PORT"
 	SSLMode    string = "SSL_MODE"
 )
+
+const (
+	AuthDisabled    string = "AUTH_DISABLED"
+	JWTSecret       string = "JWT_SECRET"
+	JWKSFile        string = "JWT_JWKS_FILE"
+	JWKSURL         string = "JWT_JWKS_URL"
+	JWTIssuer       string = "JWT_ISSUER"
+	JWTAudience     string = "JWT_AUDIENCE"
+	JWTRolesClaim   string = "JWT_ROLES_CLAIM"
+	JWTLeeway       string = "JWT_LEEWAY"
+	JWTAccessTTL    string = "JWT_ACCESS_TTL"
+	JWTRefreshTTL   string = "JWT_REFRESH_TTL"
+	HMACClientsFile string = "HMAC_CLIENTS_FILE"
+	HMACClockSkew   string = "HMAC_CLOCK_SKEW"
+	PolicyFile      string = "POLICY_FILE"
+)
+
+const (
+	OIDCIssuerURL     string = "OIDC_ISSUER_URL"
+	OIDCClientID      string = "OIDC_CLIENT_ID"
+	OIDCClientSecret  string = "OIDC_CLIENT_SECRET"
+	OIDCRedirectURL   string = "OIDC_REDIRECT_URL"
+	OIDCScopes        string = "OIDC_SCOPES"
+	OIDCGroupsClaim   string = "OIDC_GROUPS_CLAIM"
+	OIDCGroupRoles    string = "OIDC_GROUP_ROLES"
+	OIDCAutoProvision string = "OIDC_AUTO_PROVISION"
+	OIDCStateTTL      string = "OIDC_STATE_TTL"
+)
+
+const (
+	PasswordMinLength        string = "PASSWORD_MIN_LENGTH"
+	LoginMaxAttempts         string = "LOGIN_MAX_ATTEMPTS"
+	LoginLockoutDuration     string = "LOGIN_LOCKOUT_DURATION"
+	ResetTokenTTL            string = "RESET_TOKEN_TTL"
+	VerificationTokenTTL     string = "VERIFICATION_TOKEN_TTL"
+	AppPublicURL             string = "APP_PUBLIC_URL"
+	InvitationTTL            string = "INVITATION_TTL"
+	InvitationExpiryInterval string = "INVITATION_EXPIRY_INTERVAL"
+)
+
+const (
+	EventPublisher      string = "EVENT_PUBLISHER"
+	OutboxRelayInterval string = "OUTBOX_RELAY_INTERVAL"
+	OutboxBatchSize     string = "OUTBOX_BATCH_SIZE"
+	OutboxMaxBackoff    string = "OUTBOX_MAX_BACKOFF"
+	OutboxRetention     string = "OUTBOX_RETENTION"
+)
+
+const (
+	WebhookTimeout          string = "WEBHOOK_TIMEOUT"
+	WebhookDeliveryInterval string = "WEBHOOK_DELIVERY_INTERVAL"
+	WebhookMaxAttempts      string = "WEBHOOK_MAX_ATTEMPTS"
+	WebhookBaseBackoff      string = "WEBHOOK_BASE_BACKOFF"
+	WebhookMaxBackoff       string = "WEBHOOK_MAX_BACKOFF"
+	WebhookAllowPrivate     string = "WEBHOOK_ALLOW_PRIVATE_NETWORKS"
+)
+
+const (
+	MailDriver        string = "MAIL_DRIVER"
+	MailOutboxDir     string = "MAIL_OUTBOX_DIR"
+	MailFrom          string = "MAIL_FROM"
+	MailDefaultLocale string = "MAIL_DEFAULT_LOCALE"
+	MailRetryAttempts string = "MAIL_RETRY_ATTEMPTS"
+	SMTPHost          string = "SMTP_HOST"
+	SMTPPort          string = "SMTP_PORT"
+	SMTPUsername      string = "SMTP_USERNAME"
+	SMTPPassword      string = "SMTP_PASSWORD"
+)
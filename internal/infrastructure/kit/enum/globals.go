@@ -1,17 +1,32 @@
 package enum
 
 const (
+	Actor       string = "actor"
+	Anonymous   string = "anonymous"
 	App         string = "CRUD"
 	Args        string = "args"
+	Atomic      string = "atomic"
+	Attributes  string = "attributes"
 	Email       string = "email"
 	EmptyString string = ""
+	Fields      string = "fields"
 	Filters     string = "filters"
 	ID          string = "id"
+	IDs         string = "ids"
 	Limit       string = "limit"
 	Name        string = "name"
 	Offset      string = "offset"
+	Operations  string = "operations"
 	Page        string = "page"
+	Principal   string = "principal"
 	Query       string = "query"
+	Reason      string = "reason"
+	Resource    string = "resource"
+	SessionID   string = "sessionID"
 	Total       string = "total"
 	Status      string = "status"
 )
+
+const (
+	AttributeFilterPrefix string = "attr."
+)
@@ -0,0 +1,71 @@
+package dbutils
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// JSONMap adapta un mapa genérico a una columna JSONB, tanto para lectura como para escritura.
+type JSONMap map[string]interface{}
+
+// Value serializa el mapa como JSON; un mapa nulo se guarda como objeto vacío.
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan deserializa el contenido JSONB de la columna.
+func (m *JSONMap) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("dbutils: cannot scan %T into JSONMap", src)
+	}
+	return json.Unmarshal(data, m)
+}
+
+// JSONContains representa un filtro de contención JSONB ("columna @> valor").
+type JSONContains map[string]interface{}
+
+// JSON adapta cualquier valor serializable a una columna JSON/JSONB.
+// Para escanear, V debe ser un puntero al destino.
+type JSON struct {
+	V interface{}
+}
+
+// Value serializa V; los mapas y slices nulos se guardan como "{}" y "[]".
+func (j JSON) Value() (driver.Value, error) {
+	rv := reflect.ValueOf(j.V)
+	switch {
+	case rv.Kind() == reflect.Map && rv.IsNil():
+		return []byte("{}"), nil
+	case rv.Kind() == reflect.Slice && rv.IsNil():
+		return []byte("[]"), nil
+	}
+	return json.Marshal(j.V)
+}
+
+// Scan deserializa el contenido de la columna en V.
+func (j *JSON) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, j.V)
+	case string:
+		return json.Unmarshal([]byte(v), j.V)
+	default:
+		return fmt.Errorf("dbutils: cannot scan %T into JSON", src)
+	}
+}
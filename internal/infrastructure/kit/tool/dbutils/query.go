@@ -4,20 +4,51 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
 // BuildDynamicQuery construye un query base con filtros tipo ILIKE y placeholders tipo $1, $2...
 func BuildDynamicQuery(baseQuery string, filters map[string]interface{}, startIndex int) (string, []interface{}) {
+	return BuildFilteredQuery(baseQuery, filters, nil, startIndex)
+}
+
+// BuildFilteredQuery combina filtros ILIKE con filtros de igualdad exacta.
+// Si el valor de un filtro exacto es un slice de strings se usa "= ANY($n)",
+// si es JSONContains se usa la contención JSONB "@> $n" y si es Match, su operador.
+func BuildFilteredQuery(baseQuery string, likeFilters, exactFilters map[string]interface{}, startIndex int) (string, []interface{}) {
 	var args []interface{}
 	var conditions []string
 	argPos := startIndex
 
-	for key, val := range filters {
+	for key, val := range likeFilters {
 		conditions = append(conditions, fmt.Sprintf("%s ILIKE $%d", key, argPos))
 		args = append(args, fmt.Sprintf("%%%v%%", val))
 		argPos++
 	}
 
+	for key, val := range exactFilters {
+		switch v := val.(type) {
+		case Match:
+			cond, arg, ok := v.condition(key, argPos)
+			conditions = append(conditions, cond)
+			if !ok {
+				continue
+			}
+			args = append(args, arg)
+		case []string:
+			conditions = append(conditions, fmt.Sprintf("%s = ANY($%d)", key, argPos))
+			args = append(args, pq.Array(v))
+		case JSONContains:
+			conditions = append(conditions, fmt.Sprintf("%s @> $%d", key, argPos))
+			args = append(args, JSONMap(v))
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", key, argPos))
+			args = append(args, val)
+		}
+		argPos++
+	}
+
 	if len(conditions) > 0 {
 		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -25,6 +56,65 @@ func BuildDynamicQuery(baseQuery string, filters map[string]interface{}, startIn
 	return baseQuery, args
 }
 
+// Match es un filtro con operador: eq, ne, co (contiene), sw (empieza), ew (termina) o pr
+// (presente). Con textos, todos se comparan con ILIKE, sin distinguir mayúsculas.
+type Match struct {
+	Op    string
+	Value interface{}
+}
+
+// condition devuelve la condición SQL del filtro y su argumento; ok es false si no usa ninguno.
+func (m Match) condition(key string, argPos int) (cond string, arg interface{}, ok bool) {
+	if m.Op == "pr" {
+		return fmt.Sprintf("(%s IS NOT NULL AND %s::text <> '')", key, key), nil, false
+	}
+
+	text, isText := m.Value.(string)
+	if !isText && (m.Op == "eq" || m.Op == "ne") {
+		op := "="
+		if m.Op == "ne" {
+			op = "<>"
+		}
+		return fmt.Sprintf("%s %s $%d", key, op, argPos), m.Value, true
+	}
+	if !isText {
+		text = fmt.Sprint(m.Value)
+	}
+
+	pattern := likeEscaper.Replace(text)
+	switch m.Op {
+	case "co":
+		pattern = "%" + pattern + "%"
+	case "sw":
+		pattern += "%"
+	case "ew":
+		pattern = "%" + pattern
+	}
+	if m.Op == "ne" {
+		return fmt.Sprintf("%s::text NOT ILIKE $%d", key, argPos), pattern, true
+	}
+	return fmt.Sprintf("%s::text ILIKE $%d", key, argPos), pattern, true
+}
+
+// likeEscaper escapa los comodines de LIKE para que el valor se compare literalmente.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// SplitFilters separa del mapa de filtros las claves que deben compararse por igualdad exacta.
+func SplitFilters(filters map[string]interface{}, exactKeys ...string) (like, exact map[string]interface{}) {
+	like = make(map[string]interface{}, len(filters))
+	exact = make(map[string]interface{})
+	for key, val := range filters {
+		like[key] = val
+	}
+	for _, key := range exactKeys {
+		if val, ok := like[key]; ok {
+			exact[key] = val
+			delete(like, key)
+		}
+	}
+	return like, exact
+}
+
 // AddPagination agrega LIMIT y OFFSET con placeholders dinámicos
 func AddPagination(query string, args []interface{}, startIndex int, limit, offset int) (string, []interface{}) {
 	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", startIndex, startIndex+1)
@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FileTransport escribe cada mensaje como un archivo .eml en un directorio (outbox); útil en
+// desarrollo para abrir los correos con cualquier cliente de email.
+type FileTransport struct {
+	dir   string
+	count atomic.Int64
+}
+
+func NewFileTransport(dir string) (*FileTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mail: creating outbox %s: %w", dir, err)
+	}
+	return &FileTransport{dir: dir}, nil
+}
+
+func (t *FileTransport) Deliver(_ context.Context, msg *Message) error {
+	now := time.Now()
+	data, err := msg.Bytes(now)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%04d.eml", now.UTC().Format("20060102T150405.000"), t.count.Add(1))
+	path := filepath.Join(t.dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("mail: writing %s: %w", path, err)
+	}
+
+	log.Info().Str("to", msg.To).Str("file", path).Msg("📧 Email escrito en el outbox")
+	return nil
+}
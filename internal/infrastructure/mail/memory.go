@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryTransport guarda los mensajes en memoria; pensado para pruebas.
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (t *MemoryTransport) Deliver(_ context.Context, msg *Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, *msg)
+	return nil
+}
+
+// Messages devuelve una copia de los mensajes entregados en orden.
+func (t *MemoryTransport) Messages() []Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Message(nil), t.messages...)
+}
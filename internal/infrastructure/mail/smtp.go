@@ -0,0 +1,105 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SMTPConfig configura el servidor SMTP. Para desarrollo sirve un servidor local como
+// MailHog (localhost:1025, sin autenticación ni TLS).
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// SMTPTransport entrega los mensajes a un servidor SMTP. Usa STARTTLS cuando el servidor lo
+// ofrece y autenticación PLAIN cuando hay usuario configurado.
+type SMTPTransport struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPTransport(cfg SMTPConfig) *SMTPTransport {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &SMTPTransport{cfg: cfg}
+}
+
+func (t *SMTPTransport) Deliver(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes(time.Now())
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(t.cfg.Host, fmt.Sprint(t.cfg.Port))
+	ctx, cancel := context.WithTimeout(ctx, t.cfg.Timeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("mail: connecting to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, t.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: t.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if t.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.cfg.Username, t.cfg.Password, t.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	from, err := netmail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("mail: invalid sender %q: %w", msg.From, err)
+	}
+	to, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("mail: invalid recipient %q: %w", msg.To, err)
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := client.Quit(); err != nil {
+		return err
+	}
+
+	log.Info().Str("to", msg.To).Str("server", addr).Msg("📧 Email enviado por SMTP")
+	return nil
+}
@@ -0,0 +1,176 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+)
+
+// Cada plantilla tiene, por idioma, un archivo <nombre>.txt.tmpl que define los bloques
+// "subject" y "text" y un archivo <nombre>.html.tmpl con el cuerpo HTML.
+//
+//go:embed templates
+var templatesFS embed.FS
+
+const (
+	textSuffix = ".txt.tmpl"
+	htmlSuffix = ".html.tmpl"
+)
+
+type compiledTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// TemplateMailer implementa ports.Mailer: renderiza la plantilla en el idioma del mensaje y
+// la entrega con el Transport configurado.
+type TemplateMailer struct {
+	transport     Transport
+	from          string
+	defaultLocale string
+	templates     map[string]map[string]*compiledTemplate
+}
+
+// NewTemplateMailer compila las plantillas embebidas; defaultLocale se usa cuando el mensaje
+// no indica idioma o indica uno sin plantillas.
+func NewTemplateMailer(transport Transport, from, defaultLocale string) (*TemplateMailer, error) {
+	templates, err := loadTemplates(templatesFS)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := templates[defaultLocale]; !ok {
+		return nil, fmt.Errorf("mail: no templates for default locale %q", defaultLocale)
+	}
+	return &TemplateMailer{transport: transport, from: from, defaultLocale: defaultLocale, templates: templates}, nil
+}
+
+func (m *TemplateMailer) Send(ctx context.Context, email *model.Email) error {
+	msg, err := m.Render(email)
+	if err != nil {
+		return err
+	}
+	return m.transport.Deliver(ctx, msg)
+}
+
+// Render arma el mensaje de una plantilla sin enviarlo.
+func (m *TemplateMailer) Render(email *model.Email) (*Message, error) {
+	locale := email.Locale
+	if _, ok := m.templates[locale]; !ok {
+		locale = m.defaultLocale
+	}
+	tmpl, ok := m.templates[locale][email.Template]
+	if !ok {
+		return nil, fmt.Errorf("mail: unknown template %q for locale %q", email.Template, locale)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", email.Data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", email.Data); err != nil {
+		return nil, err
+	}
+	if tmpl.html != nil {
+		if err := tmpl.html.Execute(&html, email.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Message{
+		From:    m.from,
+		To:      email.To,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// loadTemplates compila templates/<idioma>/<nombre>.{txt,html}.tmpl.
+func loadTemplates(fsys fs.FS) (map[string]map[string]*compiledTemplate, error) {
+	templates := make(map[string]map[string]*compiledTemplate)
+	err := fs.WalkDir(fsys, "templates", func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		locale := path.Base(path.Dir(file))
+		base := path.Base(file)
+		if templates[locale] == nil {
+			templates[locale] = make(map[string]*compiledTemplate)
+		}
+
+		funcs := map[string]interface{}{"duration": durationText(locale)}
+		switch {
+		case strings.HasSuffix(base, textSuffix):
+			name := strings.TrimSuffix(base, textSuffix)
+			t, err := texttemplate.New(base).Funcs(funcs).ParseFS(fsys, file)
+			if err != nil {
+				return err
+			}
+			entry(templates[locale], name).text = t
+		case strings.HasSuffix(base, htmlSuffix):
+			name := strings.TrimSuffix(base, htmlSuffix)
+			t, err := htmltemplate.New(base).Funcs(funcs).ParseFS(fsys, file)
+			if err != nil {
+				return err
+			}
+			entry(templates[locale], name).html = t
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mail: loading templates: %w", err)
+	}
+
+	for locale, byName := range templates {
+		for name, tmpl := range byName {
+			if tmpl.text == nil {
+				return nil, fmt.Errorf("mail: template %s/%s has no %s file", locale, name, textSuffix)
+			}
+		}
+	}
+	return templates, nil
+}
+
+func entry(byName map[string]*compiledTemplate, name string) *compiledTemplate {
+	if byName[name] == nil {
+		byName[name] = &compiledTemplate{}
+	}
+	return byName[name]
+}
+
+// durationUnits son las unidades en singular y plural de cada idioma.
+var durationUnits = map[string][3][2]string{
+	model.LocaleES: {{"día", "días"}, {"hora", "horas"}, {"minuto", "minutos"}},
+	model.LocaleEN: {{"day", "days"}, {"hour", "hours"}, {"minute", "minutes"}},
+}
+
+// durationText devuelve la función de plantilla "duration", que escribe una duración en la
+// unidad más grande exacta posible: "2 días", "1 hour", "30 minutos".
+func durationText(locale string) func(time.Duration) string {
+	units, ok := durationUnits[locale]
+	if !ok {
+		units = durationUnits[model.LocaleEN]
+	}
+	return func(d time.Duration) string {
+		n, unit := int64(d/time.Minute), units[2]
+		switch {
+		case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+			n, unit = int64(d/(24*time.Hour)), units[0]
+		case d >= time.Hour && d%time.Hour == 0:
+			n, unit = int64(d/time.Hour), units[1]
+		}
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, unit[0])
+		}
+		return fmt.Sprintf("%d %s", n, unit[1])
+	}
+}
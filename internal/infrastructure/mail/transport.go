@@ -0,0 +1,80 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/textproto"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Transport entrega mensajes ya renderizados (SMTP, archivos, memoria).
+type Transport interface {
+	Deliver(ctx context.Context, msg *Message) error
+}
+
+// RetryOptions configura los reintentos con backoff exponencial.
+type RetryOptions struct {
+	Attempts       int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryOptions hace 3 intentos esperando 500ms y luego 1s, con jitter.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{Attempts: 3, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 10 * time.Second}
+}
+
+// RetryTransport reintenta las entregas fallidas con backoff exponencial. Los errores
+// permanentes (respuestas SMTP 5xx, p. ej. un destinatario inexistente) no se reintentan.
+type RetryTransport struct {
+	next  Transport
+	opts  RetryOptions
+	sleep func(context.Context, time.Duration) error
+}
+
+func NewRetryTransport(next Transport, opts RetryOptions) *RetryTransport {
+	if opts.Attempts < 1 {
+		opts.Attempts = 1
+	}
+	return &RetryTransport{next: next, opts: opts, sleep: sleepContext}
+}
+
+func (t *RetryTransport) Deliver(ctx context.Context, msg *Message) error {
+	backoff := t.opts.InitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = t.next.Deliver(ctx, msg); err == nil || isPermanent(err) || attempt >= t.opts.Attempts {
+			return err
+		}
+
+		// Jitter de ±25% para que varias instancias no reintenten a la vez.
+		wait := backoff + time.Duration((rand.Float64()-0.5)*0.5*float64(backoff))
+		log.Warn().Err(err).Int("attempt", attempt).Dur("backoff", wait).Str("to", msg.To).Msg("⚠️ Error al enviar email; reintentando")
+		if err := t.sleep(ctx, wait); err != nil {
+			return err
+		}
+		backoff *= 2
+		if t.opts.MaxBackoff > 0 && backoff > t.opts.MaxBackoff {
+			backoff = t.opts.MaxBackoff
+		}
+	}
+}
+
+func isPermanent(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/auth"
+	"github.com/jnates/crud_golang/internal/infrastructure/http/middleware"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyMetadata es la clave de metadata alternativa para presentar una API key (X-API-Key en HTTP).
+const apiKeyMetadata = "x-api-key"
+
+// AuthConfig configura la autenticación de las llamadas gRPC. Con Verifier nil (AUTH_DISABLED=true)
+// cada llamada se ejecuta como un principal anonymous con rol admin.
+type AuthConfig struct {
+	Verifier middleware.TokenVerifier
+	APIKeys  middleware.APIKeyAuthenticator
+}
+
+// authenticator resuelve el principal de una llamada con las mismas credenciales que el
+// middleware HTTP, salvo las firmas HMAC, que dependen de la petición HTTP.
+type authenticator struct {
+	cfg AuthConfig
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	// El servicio de reflection sólo describe la API.
+	if strings.HasPrefix(method, "/grpc.reflection.") {
+		return ctx, nil
+	}
+	if a.cfg.Verifier == nil {
+		return model.WithPrincipal(ctx, &model.Principal{Subject: enum.Anonymous, Roles: []string{model.RoleAdmin}, AuthMethod: "none"}), nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if key, ok := apiKey(md); ok && a.cfg.APIKeys != nil {
+		principal, err := a.cfg.APIKeys.Authenticate(ctx, key, peerIP(ctx))
+		if err != nil {
+			log.Warn().Err(err).Str("method", method).Msg("🔒 API key rechazada")
+			switch {
+			case errors.Is(err, model.ErrForbidden):
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			case errors.Is(err, model.ErrUnauthenticated):
+				return nil, status.Error(codes.Unauthenticated, "invalid API key")
+			default:
+				return nil, status.Error(codes.Internal, "internal server error")
+			}
+		}
+		return model.WithPrincipal(ctx, principal), nil
+	}
+
+	token, ok := bearerToken(md)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	principal, err := a.cfg.Verifier.Verify(token)
+	if err != nil {
+		log.Warn().Err(err).Str("method", method).Msg("🔒 Token rechazado")
+		if errors.Is(err, auth.ErrTokenExpired) {
+			return nil, status.Error(codes.Unauthenticated, "token expired")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return model.WithPrincipal(ctx, principal), nil
+}
+
+// principalStream reemplaza el contexto del stream por el que lleva el principal.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context { return s.ctx }
+
+func apiKey(md metadata.MD) (string, bool) {
+	if values := md.Get(apiKeyMetadata); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		return strings.TrimSpace(values[0]), true
+	}
+	if token, ok := bearerToken(md); ok && strings.HasPrefix(token, model.APIKeyTokenPrefix) {
+		return token, true
+	}
+	return "", false
+}
+
+func bearerToken(md metadata.MD) (string, bool) {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// peerIP devuelve la IP del cliente para las restricciones de IP de las API keys.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codeFor traduce errores de dominio a códigos gRPC según su categoría, como statusCodeFor en HTTP.
+func codeFor(err error) codes.Code {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, model.ErrInvalid):
+		return codes.InvalidArgument
+	case errors.Is(err, model.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, model.ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, model.ErrForbidden):
+		return codes.PermissionDenied
+	default:
+		return codes.Internal
+	}
+}
+
+// statusError convierte el error del caso de uso en un status gRPC con el mismo mensaje que la API REST.
+func statusError(err error) error {
+	return status.Error(codeFor(err), err.Error())
+}
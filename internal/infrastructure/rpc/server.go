@@ -0,0 +1,49 @@
+// Package rpc expone los casos de uso por gRPC, junto a la API REST y en el mismo proceso.
+package rpc
+
+import (
+	"context"
+	"time"
+
+	userv1 "github.com/jnates/crud_golang/pkg/api/user/v1"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer crea el servidor gRPC con autenticación, log de llamadas y reflection para que
+// herramientas como grpcurl descubran los servicios.
+func NewServer(cfg AuthConfig, users *UserServer) *grpc.Server {
+	authn := &authenticator{cfg: cfg}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logUnary, authn.unary),
+		grpc.ChainStreamInterceptor(logStream, authn.stream),
+	)
+	userv1.RegisterUserServiceServer(server, users)
+	reflection.Register(server)
+	return server
+}
+
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	logCall(info.FullMethod, start, err)
+	return res, err
+}
+
+func logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(info.FullMethod, start, err)
+	return err
+}
+
+func logCall(method string, start time.Time, err error) {
+	code := status.Code(err)
+	event := log.Info()
+	if err != nil {
+		event = log.Warn().Err(err)
+	}
+	event.Str("method", method).Str("code", code.String()).Dur("duration", time.Since(start)).Msg("📞 Llamada gRPC")
+}
@@ -0,0 +1,198 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jnates/crud_golang/internal/application"
+	"github.com/jnates/crud_golang/internal/domain/model"
+	"github.com/jnates/crud_golang/internal/infrastructure/kit/enum"
+	userv1 "github.com/jnates/crud_golang/pkg/api/user/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultPageLimit = 10
+	// streamPageSize es el tamaño de las páginas con que StreamUsers recorre el listado.
+	streamPageSize = 100
+)
+
+// UserServer implementa userv1.UserServiceServer sobre application.UserService.
+type UserServer struct {
+	userv1.UnimplementedUserServiceServer
+	Service *application.UserService
+}
+
+func NewUserServer(svc *application.UserService) *UserServer {
+	return &UserServer{Service: svc}
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.User, error) {
+	id, err := s.userID(ctx, req.GetId(), req.GetPublicId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	user, err := s.Service.Get(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProto(user)
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.User, error) {
+	user := &model.User{Name: req.GetName(), Email: req.GetEmail(), Attributes: req.GetAttributes().AsMap()}
+	id, err := s.Service.Create(ctx, user)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	user.ID = id
+	return toProto(user)
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.User, error) {
+	id, err := s.userID(ctx, req.GetId(), req.GetPublicId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	user := &model.User{ID: id, Name: req.GetName(), Email: req.GetEmail(), Attributes: req.GetAttributes().AsMap()}
+	if err := s.Service.Update(ctx, user); err != nil {
+		return nil, statusError(err)
+	}
+	return s.GetUser(ctx, &userv1.GetUserRequest{Id: user.ID})
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	id, err := s.userID(ctx, req.GetId(), req.GetPublicId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := s.Service.Delete(ctx, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	page, limit := int(req.GetPage()), int(req.GetLimit())
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+
+	filter, err := userFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.Service.List(ctx, (page-1)*limit, limit, filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	// List ya convirtió los filtros de atributos; Count los recibe sin convertir.
+	countFilter, _ := userFilter(req.GetFilter())
+	total, err := s.Service.Count(ctx, countFilter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &userv1.ListUsersResponse{Users: make([]*userv1.User, 0, len(users)), Total: int32(total)}
+	for _, user := range users {
+		pb, err := toProto(user)
+		if err != nil {
+			return nil, err
+		}
+		res.Users = append(res.Users, pb)
+	}
+	return res, nil
+}
+
+func (s *UserServer) StreamUsers(req *userv1.StreamUsersRequest, stream userv1.UserService_StreamUsersServer) error {
+	ctx := stream.Context()
+	for offset := 0; ; offset += streamPageSize {
+		filter, err := userFilter(req.GetFilter())
+		if err != nil {
+			return err
+		}
+		users, err := s.Service.List(ctx, offset, streamPageSize, filter)
+		if err != nil {
+			return statusError(err)
+		}
+		for _, user := range users {
+			pb, err := toProto(user)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(pb); err != nil {
+				return err
+			}
+		}
+		if len(users) < streamPageSize {
+			return nil
+		}
+	}
+}
+
+// userFilter arma los filtros de UserService.List con las mismas claves que GET /users.
+func userFilter(f *userv1.UserFilter) (map[string]interface{}, error) {
+	filter := make(map[string]interface{})
+	if f == nil {
+		return filter, nil
+	}
+	if f.GetName() != "" {
+		filter[enum.Name] = f.GetName()
+	}
+	if f.GetEmail() != "" {
+		filter[enum.Email] = f.GetEmail()
+	}
+	if len(f.GetStatus()) > 0 {
+		statuses := make([]string, 0, len(f.GetStatus()))
+		for _, raw := range f.GetStatus() {
+			st := model.UserStatus(strings.ToLower(strings.TrimSpace(raw)))
+			if !st.IsValid() {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%v: %q", model.ErrInvalidStatus, raw))
+			}
+			statuses = append(statuses, string(st))
+		}
+		filter[enum.Status] = statuses
+	}
+	if len(f.GetAttributes()) > 0 {
+		attrs := make(map[string]string, len(f.GetAttributes()))
+		for name, value := range f.GetAttributes() {
+			attrs[name] = value
+		}
+		filter[enum.Attributes] = attrs
+	}
+	return filter, nil
+}
+
+// userID devuelve el ID interno del usuario pedido: public_id si se indicó y, si no, id.
+func (s *UserServer) userID(ctx context.Context, id int64, publicID string) (int64, error) {
+	if publicID == "" {
+		return id, nil
+	}
+	return s.Service.ResolveUserID(ctx, publicID)
+}
+
+func toProto(user *model.User) (*userv1.User, error) {
+	pb := &userv1.User{
+		Id:            user.ID,
+		PublicId:      user.PublicID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Status:        string(user.Status),
+	}
+	if len(user.Attributes) > 0 {
+		attrs, err := structpb.NewStruct(user.Attributes)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pb.Attributes = attrs
+	}
+	return pb, nil
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress indica que el URL del webhook apunta a la red interna.
+var ErrBlockedAddress = errors.New("webhook: destination address is not allowed")
+
+// blockedRanges son redes internas que no son loopback, privadas ni link-local según netip:
+// la red compartida de los proveedores (donde algunas nubes exponen su metadata), la red
+// "this network" y el rango de metadata de AWS sobre IPv6.
+var blockedRanges = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("fd00:ec2::/32"),
+}
+
+// newDialer devuelve un dialer que rechaza, al momento de conectar, las IPs de loopback,
+// privadas (RFC 1918 y fc00::/7), link-local (incluida la metadata de la nube en
+// 169.254.169.254) y multicast. Como se comprueba la IP ya resuelta, cubre también las
+// redirecciones y el DNS rebinding.
+func newDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !allowedAddr(addr) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+			}
+			return nil
+		},
+	}
+}
+
+func allowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedRanges {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// Package webhook envía las entregas de webhooks por HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jnates/crud_golang/internal/domain/model"
+	signing "github.com/jnates/crud_golang/pkg/webhook"
+)
+
+// maxResponseBody limita lo que se guarda de la respuesta del receptor.
+const maxResponseBody = 1024
+
+// HTTPSender hace un POST firmado con el cuerpo de la entrega al URL del webhook. Cualquier
+// respuesta fuera de 2xx cuenta como fallo; las redirecciones no se siguen.
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender crea el sender. Salvo con allowPrivateNetworks, las conexiones a la red interna
+// (loopback, redes privadas, link-local y metadata de la nube) fallan con ErrBlockedAddress.
+func NewHTTPSender(timeout time.Duration, allowPrivateNetworks bool) *HTTPSender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivateNetworks {
+		// Con un proxy la IP que se comprobaría sería la del proxy, no la del destino.
+		transport.Proxy = nil
+		transport.DialContext = newDialer(timeout).DialContext
+	}
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) *model.DeliveryAttempt {
+	attempt := &model.DeliveryAttempt{At: s.now().UTC()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Err = err
+		return attempt
+	}
+	timestamp := strconv.FormatInt(attempt.At.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "crud-golang-webhooks/1")
+	req.Header.Set(signing.HeaderEvent, delivery.EventType)
+	req.Header.Set(signing.HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(signing.HeaderTimestamp, timestamp)
+	req.Header.Set(signing.HeaderSignature, signing.Sign(hook.Secret, timestamp, delivery.Payload))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	attempt.Duration = time.Since(start)
+	if err != nil {
+		attempt.Err = err
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	attempt.ResponseCode = resp.StatusCode
+	attempt.ResponseBody = string(body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Err = fmt.Errorf("webhook: receiver responded %d", resp.StatusCode)
+	}
+	return attempt
+}
@@ -0,0 +1,271 @@
+// Package mockidp es un proveedor OpenID Connect mínimo para desarrollo y pruebas locales.
+// Aprueba cualquier solicitud de autorización sin pantalla de inicio de sesión, con el usuario
+// que indique login_hint o el primero configurado, y exige PKCE S256 al canjear el código.
+package mockidp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID      = "mock-idp"
+	codeTTL    = time.Minute
+	idTokenTTL = 5 * time.Minute
+)
+
+// User es una identidad que el proveedor puede afirmar.
+type User struct {
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+	Groups        []string `json:"groups"`
+}
+
+// Config configura el proveedor.
+type Config struct {
+	// Issuer es la URL pública del proveedor, p. ej. http://localhost:9000.
+	Issuer   string
+	ClientID string
+	// ClientSecret, si se indica, se exige en el token endpoint (client_secret_basic o _post).
+	ClientSecret string
+	Users        []User
+}
+
+// DefaultUsers es un administrador y un usuario sin grupos, ambos con el email verificado.
+func DefaultUsers() []User {
+	return []User{
+		{Subject: "mock-admin", Email: "admin@example.com", EmailVerified: true, Name: "Mock Admin", Groups: []string{"admins"}},
+		{Subject: "mock-user", Email: "user@example.com", EmailVerified: true, Name: "Mock User"},
+	}
+}
+
+// authorization es un código emitido y pendiente de canje.
+type authorization struct {
+	user          User
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// Server implementa los endpoints de descubrimiento, JWKS, autorización y token.
+type Server struct {
+	cfg Config
+	key *rsa.PrivateKey
+	mux *http.ServeMux
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+// New crea el proveedor con una clave RSA nueva; los tokens dejan de valer al reiniciarlo.
+func New(cfg Config) (*Server, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		return nil, errors.New("mock IdP: issuer and client ID are required")
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	if len(cfg.Users) == 0 {
+		cfg.Users = DefaultUsers()
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{cfg: cfg, key: key, mux: http.NewServeMux(), codes: make(map[string]*authorization)}
+	s.mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	s.mux.HandleFunc("GET /jwks", s.jwks)
+	s.mux.HandleFunc("GET /authorize", s.authorize)
+	s.mux.HandleFunc("POST /token", s.token)
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.cfg.Issuer,
+		"authorization_endpoint":                s.cfg.Issuer + "/authorize",
+		"token_endpoint":                        s.cfg.Issuer + "/token",
+		"jwks_uri":                              s.cfg.Issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize emite un código para el usuario de login_hint (email o sub) y redirige de vuelta.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("client_id") != s.cfg.ClientID || redirectURI == "" {
+		http.Error(w, "unknown client or missing redirect_uri", http.StatusBadRequest)
+		return
+	}
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	reply := target.Query()
+	reply.Set("state", q.Get("state"))
+	switch {
+	case q.Get("response_type") != "code":
+		reply.Set("error", "unsupported_response_type")
+	case q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256":
+		reply.Set("error", "invalid_request")
+		reply.Set("error_description", "PKCE with S256 is required")
+	default:
+		user, ok := s.user(q.Get("login_hint"))
+		if !ok {
+			reply.Set("error", "access_denied")
+			reply.Set("error_description", "unknown login_hint")
+			break
+		}
+		code := randomString()
+		s.mu.Lock()
+		s.codes[code] = &authorization{
+			user:          user,
+			clientID:      s.cfg.ClientID,
+			redirectURI:   redirectURI,
+			nonce:         q.Get("nonce"),
+			codeChallenge: q.Get("code_challenge"),
+			expiresAt:     time.Now().Add(codeTTL),
+		}
+		s.mu.Unlock()
+		reply.Set("code", code)
+	}
+
+	target.RawQuery = reply.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// token canjea un código de un solo uso comprobando cliente, redirect_uri y verificador PKCE.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+	clientID, secret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.cfg.ClientID || (s.cfg.ClientSecret != "" && secret != s.cfg.ClientSecret) {
+		tokenError(w, "invalid_client")
+		return
+	}
+
+	s.mu.Lock()
+	auth, found := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !found || time.Now().After(auth.expiresAt):
+		tokenError(w, "invalid_grant")
+		return
+	case auth.redirectURI != r.PostForm.Get("redirect_uri"):
+		tokenError(w, "invalid_grant")
+		return
+	case base64.RawURLEncoding.EncodeToString(verifier[:]) != auth.codeChallenge:
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.cfg.Issuer,
+		"aud":            auth.clientID,
+		"sub":            auth.user.Subject,
+		"iat":            now.Unix(),
+		"exp":            now.Add(idTokenTTL).Unix(),
+		"email":          auth.user.Email,
+		"email_verified": auth.user.EmailVerified,
+		"name":           auth.user.Name,
+		"groups":         auth.user.Groups,
+	}
+	if auth.nonce != "" {
+		claims["nonce"] = auth.nonce
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenTTL.Seconds()),
+		"id_token":     signed,
+	})
+}
+
+func (s *Server) user(hint string) (User, bool) {
+	if hint == "" {
+		return s.cfg.Users[0], true
+	}
+	for _, u := range s.cfg.Users {
+		if strings.EqualFold(u.Email, hint) || u.Subject == hint {
+			return u, true
+		}
+	}
+	return User{}, false
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	buf := make([]byte, 24)
+	_, _ = rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,142 @@
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Field es un campo del recurso a generar, declarado como "nombre:tipo".
+type Field struct {
+	Name       string // Nombre del campo en Go (Price)
+	Column     string // Columna SQL y clave JSON (price)
+	GoType     string
+	SQLType    string
+	TagOpts    string // Opciones adicionales de la etiqueta db (",json")
+	Validate   string
+	Filterable bool   // Los campos de texto se exponen como filtros ILIKE en List
+	Sample     string // Valor de ejemplo para los tests generados
+	Import     string
+}
+
+type fieldType struct {
+	goType, sqlType, tagOpts, validate, sample, imp string
+	filterable                                      bool
+}
+
+// fieldTypes son los tipos admitidos en --fields.
+var fieldTypes = map[string]fieldType{
+	"string":  {goType: "string", sqlType: "VARCHAR(255) NOT NULL", validate: "required,max=255", sample: `"sample"`, filterable: true},
+	"text":    {goType: "string", sqlType: "TEXT NOT NULL DEFAULT ''", sample: `"sample text"`, filterable: true},
+	"int":     {goType: "int64", sqlType: "BIGINT NOT NULL DEFAULT 0", sample: "1"},
+	"bool":    {goType: "bool", sqlType: "BOOLEAN NOT NULL DEFAULT FALSE", sample: "true"},
+	"float":   {goType: "float64", sqlType: "DOUBLE PRECISION NOT NULL DEFAULT 0", sample: "1.5"},
+	"decimal": {goType: "float64", sqlType: "NUMERIC(12, 2) NOT NULL DEFAULT 0", validate: "gte=0", sample: "9.99"},
+	"time":    {goType: "time.Time", sqlType: "TIMESTAMPTZ NOT NULL DEFAULT NOW()", sample: "time.Now().UTC()", imp: "time"},
+	"date":    {goType: "time.Time", sqlType: "DATE NOT NULL DEFAULT CURRENT_DATE", sample: "time.Now().UTC()", imp: "time"},
+	"json":    {goType: "map[string]interface{}", sqlType: "JSONB NOT NULL DEFAULT '{}'", tagOpts: ",json", sample: `map[string]interface{}{"key": "value"}`},
+}
+
+var (
+	identPattern   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	reservedFields = map[string]bool{"id": true, "created_at": true}
+	camelBoundary  = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	wordSeparators = regexp.MustCompile(`[_\-\s]+`)
+)
+
+// ParseFields interpreta una lista "nombre:tipo,nombre:tipo".
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	seen := make(map[string]bool)
+	for _, raw := range strings.Split(spec, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		name, typ, ok := strings.Cut(raw, ":")
+		if !ok {
+			return nil, fmt.Errorf("field %q must be declared as name:type", raw)
+		}
+		if !identPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid field name %q", name)
+		}
+		ft, ok := fieldTypes[strings.ToLower(typ)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %q for field %q", typ, name)
+		}
+
+		column := snakeCase(name)
+		if reservedFields[column] {
+			return nil, fmt.Errorf("field %q is generated automatically", name)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("duplicated field %q", name)
+		}
+		seen[column] = true
+
+		fields = append(fields, Field{
+			Name:       pascalCase(name),
+			Column:     column,
+			GoType:     ft.goType,
+			SQLType:    ft.sqlType,
+			TagOpts:    ft.tagOpts,
+			Validate:   ft.validate,
+			Filterable: ft.filterable,
+			Sample:     ft.sample,
+			Import:     ft.imp,
+		})
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+	return fields, nil
+}
+
+// snakeCase convierte "OrderItem" u "orderItem" en "order_item".
+func snakeCase(s string) string {
+	s = camelBoundary.ReplaceAllString(s, "${1}_${2}")
+	return strings.ToLower(wordSeparators.ReplaceAllString(s, "_"))
+}
+
+// pascalCase convierte "order_item" en "OrderItem", respetando el sufijo ID.
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(snakeCase(s), "_") {
+		if word == "" {
+			continue
+		}
+		if word == "id" || word == "url" || word == "api" {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// camelCase convierte "order_item" en "orderItem".
+func camelCase(s string) string {
+	p := pascalCase(s)
+	if p == "" {
+		return p
+	}
+	runes := []rune(p)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// plural aplica las reglas de pluralización del inglés más comunes.
+func plural(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y") && !strings.HasSuffix(s, "ay") && !strings.HasSuffix(s, "ey") && !strings.HasSuffix(s, "oy"):
+		return strings.TrimSuffix(s, "y") + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	default:
+		return s + "s"
+	}
+}
@@ -0,0 +1,263 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var templates = template.Must(template.ParseFS(templatesFS, "templates/*.tmpl"))
+
+// containerMarker es la línea de di/container.go antes de la cual se insertan los registros.
+const containerMarker = "\tlog.Debug().Msg(\"✅ Contenedor construido exitosamente\")"
+
+var (
+	modulePattern    = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	migrationPattern = regexp.MustCompile(`^(\d{4})_.*\.sql$`)
+)
+
+// Options configura la generación de un recurso.
+type Options struct {
+	Root   string // Raíz del proyecto (donde está go.mod)
+	Name   string // Nombre del recurso en singular (Product, OrderItem)
+	Fields []Field
+	Force  bool // Sobrescribe archivos existentes
+	DryRun bool // Sólo informa qué archivos se generarían
+}
+
+// Resource es el modelo de datos que reciben las plantillas.
+type Resource struct {
+	Module    string
+	Name      string // OrderItem
+	Var       string // orderItem
+	Snake     string // order_item
+	Table     string // order_items
+	Path      string // /order-items
+	Human     string // order item
+	Tag       string // order-items
+	Migration string // 0004
+	Fields    []Field
+}
+
+// Imports devuelve los paquetes estándar que necesita el modelo.
+func (r Resource) Imports() []string {
+	set := map[string]bool{"time": true}
+	for _, f := range r.Fields {
+		if f.Import != "" {
+			set[f.Import] = true
+		}
+	}
+	imports := make([]string, 0, len(set))
+	for imp := range set {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// NeedsTime indica si algún campo es de tipo fecha (usado por el test generado).
+func (r Resource) NeedsTime() bool {
+	for _, f := range r.Fields {
+		if f.Import == "time" {
+			return true
+		}
+	}
+	return false
+}
+
+// Filters devuelve las columnas filtrables por ILIKE.
+func (r Resource) Filters() []string {
+	var filters []string
+	for _, f := range r.Fields {
+		if f.Filterable {
+			filters = append(filters, f.Column)
+		}
+	}
+	return filters
+}
+
+// File es un archivo generado, con su ruta relativa a la raíz del proyecto.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Generate construye los archivos del recurso, los escribe y registra el recurso en el contenedor.
+// Devuelve la lista de archivos creados o modificados.
+func Generate(opts Options) ([]string, error) {
+	res, err := newResource(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := Render(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, f := range files {
+		target := filepath.Join(opts.Root, f.Path)
+		if _, err := os.Stat(target); err == nil && !opts.Force {
+			return written, fmt.Errorf("%s already exists (use --force to overwrite)", f.Path)
+		}
+		if !opts.DryRun {
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return written, err
+			}
+			if err := os.WriteFile(target, f.Content, 0o644); err != nil {
+				return written, err
+			}
+		}
+		written = append(written, f.Path)
+	}
+
+	container := filepath.Join("internal", "infrastructure", "di", "container.go")
+	changed, err := registerInContainer(filepath.Join(opts.Root, container), res, opts.DryRun)
+	if err != nil {
+		return written, err
+	}
+	if changed {
+		written = append(written, container)
+	}
+	return written, nil
+}
+
+// Render ejecuta las plantillas y devuelve los archivos sin escribirlos.
+func Render(res Resource) ([]File, error) {
+	targets := []struct{ tmpl, path string }{
+		{"model.go.tmpl", filepath.Join("internal", "domain", "model", res.Snake+".go")},
+		{"port.go.tmpl", filepath.Join("internal", "domain", "ports", res.Snake+"_repository.go")},
+		{"repository.go.tmpl", filepath.Join("internal", "infrastructure", "db", res.Snake+"_repository.go")},
+		{"service.go.tmpl", filepath.Join("internal", "application", res.Snake+"_service.go")},
+		{"service_test.go.tmpl", filepath.Join("internal", "application", res.Snake+"_service_test.go")},
+		{"handler.go.tmpl", filepath.Join("internal", "infrastructure", "http", "handler", res.Snake+"_handler.go")},
+		{"migration.sql.tmpl", filepath.Join("migrations", res.Migration+"_create_"+res.Table+".sql")},
+	}
+
+	files := make([]File, 0, len(targets))
+	for _, t := range targets {
+		content, err := execute(t.tmpl, res)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(t.path, ".go") {
+			if content, err = format.Source(content); err != nil {
+				return nil, fmt.Errorf("formatting %s: %w", t.path, err)
+			}
+		}
+		files = append(files, File{Path: t.path, Content: content})
+	}
+	return files, nil
+}
+
+func newResource(opts Options) (Resource, error) {
+	if !identPattern.MatchString(opts.Name) {
+		return Resource{}, fmt.Errorf("invalid resource name %q", opts.Name)
+	}
+	if len(opts.Fields) == 0 {
+		return Resource{}, fmt.Errorf("at least one field is required")
+	}
+
+	module, err := readModule(opts.Root)
+	if err != nil {
+		return Resource{}, err
+	}
+	migration, err := nextMigration(filepath.Join(opts.Root, "migrations"))
+	if err != nil {
+		return Resource{}, err
+	}
+
+	snake := snakeCase(opts.Name)
+	table := plural(snake)
+	return Resource{
+		Module:    module,
+		Name:      pascalCase(opts.Name),
+		Var:       camelCase(opts.Name),
+		Snake:     snake,
+		Table:     table,
+		Path:      "/" + strings.ReplaceAll(table, "_", "-"),
+		Human:     strings.ReplaceAll(snake, "_", " "),
+		Tag:       strings.ReplaceAll(table, "_", "-"),
+		Migration: migration,
+		Fields:    opts.Fields,
+	}, nil
+}
+
+func execute(name string, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func readModule(root string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("reading go.mod: %w", err)
+	}
+	match := modulePattern.FindSubmatch(content)
+	if match == nil {
+		return "", fmt.Errorf("module directive not found in go.mod")
+	}
+	return string(match[1]), nil
+}
+
+// nextMigration devuelve el siguiente número de migración disponible con cuatro dígitos.
+func nextMigration(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	last := 0
+	for _, entry := range entries {
+		match := migrationPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if n, _ := strconv.Atoi(match[1]); n > last {
+			last = n
+		}
+	}
+	return fmt.Sprintf("%04d", last+1), nil
+}
+
+// registerInContainer agrega los registros de dig del recurso a BuildContainer.
+// Si el recurso ya está registrado no modifica el archivo.
+func registerInContainer(path string, res Resource, dryRun bool) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Contains(content, []byte("New"+res.Name+"Repository(")) {
+		return false, nil
+	}
+	if !bytes.Contains(content, []byte(containerMarker)) {
+		return false, fmt.Errorf("%s: marker %q not found", path, strings.TrimSpace(containerMarker))
+	}
+
+	registration, err := execute("container.go.tmpl", res)
+	if err != nil {
+		return false, err
+	}
+	updated := bytes.Replace(content, []byte(containerMarker), append(registration, []byte(containerMarker)...), 1)
+	if updated, err = format.Source(updated); err != nil {
+		return false, fmt.Errorf("formatting %s: %w", path, err)
+	}
+	if dryRun {
+		return true, nil
+	}
+	return true, os.WriteFile(path, updated, 0o644)
+}
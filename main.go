@@ -20,5 +20,6 @@ func main() {
 	infrastructure.InitLogger()
 
 	port := os.Getenv(enum.APIPort)
-	http.Start(port)
+	grpcPort := os.Getenv(enum.GRPCPort)
+	http.Start(port, grpcPort)
 }
@@ -0,0 +1,22 @@
+-- Ciclo de vida de la cuenta de usuario y bitácora de transiciones de estado.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+ALTER TABLE users
+    ADD CONSTRAINT users_status_check
+    CHECK (status IN ('invited', 'active', 'suspended', 'locked', 'deactivated'));
+
+CREATE INDEX IF NOT EXISTS idx_users_status ON users (status);
+
+CREATE TABLE IF NOT EXISTS user_status_changes (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    from_status VARCHAR(20)  NOT NULL,
+    to_status   VARCHAR(20)  NOT NULL,
+    reason      TEXT         NOT NULL DEFAULT '',
+    actor       VARCHAR(255) NOT NULL,
+    changed_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_status_changes_user_id ON user_status_changes (user_id);
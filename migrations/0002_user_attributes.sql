@@ -0,0 +1,16 @@
+-- Atributos personalizados: definiciones administradas por API y valores por usuario en JSONB.
+
+CREATE TABLE IF NOT EXISTS attribute_definitions (
+    id          BIGSERIAL PRIMARY KEY,
+    name        VARCHAR(63)  NOT NULL UNIQUE,
+    type        VARCHAR(20)  NOT NULL,
+    required    BOOLEAN      NOT NULL DEFAULT FALSE,
+    enum_values TEXT[]       NOT NULL DEFAULT '{}',
+    pattern     TEXT         NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_users_attributes ON users USING GIN (attributes jsonb_path_ops);
@@ -0,0 +1,19 @@
+-- Grupos con roles y membresía muchos-a-muchos con usuarios.
+
+CREATE TABLE IF NOT EXISTS groups (
+    id          BIGSERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT         NOT NULL DEFAULT '',
+    roles       TEXT[]       NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- Al eliminar un usuario o un grupo, sus membresías se eliminan en cascada.
+CREATE TABLE IF NOT EXISTS group_members (
+    group_id  BIGINT      NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    user_id   BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_members_user_id ON group_members (user_id);
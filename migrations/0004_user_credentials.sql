@@ -0,0 +1,11 @@
+-- Credenciales por contraseña junto al usuario y control de intentos fallidos.
+-- Las columnas no forman parte de model.User, así que nunca se devuelven en /users.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS password_hash       TEXT,
+    ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS failed_logins       INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until        TIMESTAMPTZ;
+
+-- El inicio de sesión busca por email sin distinguir mayúsculas.
+CREATE INDEX IF NOT EXISTS idx_users_lower_email ON users (LOWER(email));
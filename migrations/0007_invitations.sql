@@ -0,0 +1,27 @@
+-- Invitaciones por email. El usuario se crea en estado 'invited' junto con la invitación y se
+-- elimina si la invitación se revoca o vence; la invitación queda como registro.
+
+CREATE TABLE IF NOT EXISTS invitations (
+    id           BIGSERIAL PRIMARY KEY,
+    email        VARCHAR(255) NOT NULL,
+    user_id      BIGINT       REFERENCES users (id) ON DELETE SET NULL,
+    status       VARCHAR(16)  NOT NULL DEFAULT 'pending'
+                 CHECK (status IN ('pending', 'accepted', 'revoked', 'expired')),
+    -- Sólo se guarda el hash SHA-256 del token; reenviar la invitación lo reemplaza.
+    token_hash   TEXT         NOT NULL UNIQUE,
+    invited_by   VARCHAR(255) NOT NULL,
+    sent_count   INTEGER      NOT NULL DEFAULT 1,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    last_sent_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    accepted_at  TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
+
+-- Una sola invitación pendiente por email.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_pending_email
+    ON invitations (LOWER(email)) WHERE status = 'pending';
+
+-- La tarea de vencimiento recorre sólo las pendientes.
+CREATE INDEX IF NOT EXISTS idx_invitations_pending_expires_at
+    ON invitations (expires_at) WHERE status = 'pending';
@@ -0,0 +1,20 @@
+-- API keys para servicios que llaman a la API sin un usuario humano.
+-- Sólo se guarda el hash SHA-256 de la clave; prefix es la parte visible que la identifica.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id                  BIGSERIAL PRIMARY KEY,
+    name                VARCHAR(100) NOT NULL,
+    prefix              VARCHAR(16)  NOT NULL UNIQUE,
+    key_hash            TEXT         NOT NULL,
+    -- Hash anterior a la última rotación, válido durante el período de gracia.
+    previous_hash       TEXT,
+    previous_expires_at TIMESTAMPTZ,
+    permissions         TEXT[]       NOT NULL DEFAULT '{}',
+    allowed_ips         TEXT[]       NOT NULL DEFAULT '{}',
+    created_by          VARCHAR(255) NOT NULL,
+    created_at          TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at          TIMESTAMPTZ,
+    rotated_at          TIMESTAMPTZ,
+    last_used_at        TIMESTAMPTZ,
+    revoked_at          TIMESTAMPTZ
+);
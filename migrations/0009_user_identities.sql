@@ -0,0 +1,17 @@
+-- Identidades de proveedores OpenID Connect vinculadas a usuarios locales.
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    issuer        TEXT         NOT NULL,
+    subject       TEXT         NOT NULL,
+    user_id       BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email         VARCHAR(255) NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities (user_id);
+
+-- Roles que el proveedor otorgó (por sus grupos) al abrir la sesión; se conservan al renovarla.
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS external_roles TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,24 @@
+-- Outbox transaccional: los eventos de dominio se guardan en la misma transacción que el cambio
+-- y un relay los publica después (entrega al menos una vez, en orden por agregado).
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    event_type      VARCHAR(100) NOT NULL,
+    aggregate_type  VARCHAR(50)  NOT NULL,
+    aggregate_id    VARCHAR(100) NOT NULL,
+    actor           VARCHAR(255) NOT NULL DEFAULT '',
+    payload         JSONB        NOT NULL,
+    occurred_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    published_at    TIMESTAMPTZ,
+    attempts        INT          NOT NULL DEFAULT 0,
+    last_error      TEXT         NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    -- Reserva del relay que lo está publicando; vencida, otro relay puede tomarlo.
+    locked_until    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON outbox_events (aggregate_type, aggregate_id, id) WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published
+    ON outbox_events (published_at) WHERE published_at IS NOT NULL;
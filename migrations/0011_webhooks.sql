@@ -0,0 +1,42 @@
+-- Webhooks salientes: suscripciones de socios a eventos de dominio y el registro de sus entregas.
+-- El secreto se guarda en claro porque se necesita para firmar cada entrega.
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id          BIGSERIAL PRIMARY KEY,
+    url         TEXT         NOT NULL,
+    -- Tipos de evento suscritos; '*' suscribe a todos.
+    event_types TEXT[]       NOT NULL,
+    secret      TEXT         NOT NULL,
+    active      BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_by  VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    webhook_id      BIGINT       NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        BIGINT       NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    payload         JSONB        NOT NULL,
+    -- pending, succeeded o dead (reintentos agotados).
+    status          VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    -- Reserva del worker que la está enviando; vencida, otro worker puede tomarla.
+    locked_until    TIMESTAMPTZ,
+    last_attempt_at TIMESTAMPTZ,
+    response_code   INT          NOT NULL DEFAULT 0,
+    response_body   TEXT         NOT NULL DEFAULT '',
+    last_error      TEXT         NOT NULL DEFAULT '',
+    duration_ms     BIGINT       NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMPTZ,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending
+    ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook
+    ON webhook_deliveries (webhook_id, id DESC);
@@ -0,0 +1,15 @@
+-- Avisa por LISTEN/NOTIFY de cada evento del outbox para que todas las instancias de la API
+-- lo reciban al confirmarse la transacción (stream de cambios de usuarios).
+
+CREATE OR REPLACE FUNCTION notify_outbox_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS outbox_events_notify ON outbox_events;
+
+CREATE TRIGGER outbox_events_notify
+    AFTER INSERT ON outbox_events
+    FOR EACH ROW EXECUTE FUNCTION notify_outbox_event();
@@ -0,0 +1,7 @@
+-- ID público de los usuarios, para no exponer en las URLs el id secuencial. La API genera
+-- UUIDv7 al crear cada usuario; los existentes y los que se inserten a mano reciben un UUIDv4.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS public_id UUID NOT NULL DEFAULT gen_random_uuid();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_public_id ON users (public_id);
@@ -0,0 +1,9 @@
+-- Un email identifica a una sola cuenta: el inicio de sesión, el restablecimiento de
+-- contraseña y la vinculación OIDC buscan al usuario por email sin distinguir mayúsculas.
+-- Reemplaza al índice no único de 0004. Si ya hay duplicados, la migración falla; se
+-- encuentran con:
+--   SELECT LOWER(email), array_agg(id) FROM users GROUP BY 1 HAVING COUNT(*) > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_lower_email_unique ON users (LOWER(email));
+
+DROP INDEX IF EXISTS idx_users_lower_email;
@@ -0,0 +1,80 @@
+// Package webhook firma y verifica las entregas de webhooks de esta API. Los receptores usan
+// Verify o VerifyRequest para comprobar que el cuerpo viene de la API y no fue alterado.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers de una entrega.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// signaturePrefix indica el algoritmo de la firma.
+const signaturePrefix = "sha256="
+
+// DefaultTolerance es la diferencia máxima aceptada entre el timestamp y el reloj del receptor.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature headers")
+	ErrInvalidTimestamp = errors.New("webhook: timestamp outside the tolerance")
+	ErrInvalidSignature = errors.New("webhook: signature does not match")
+)
+
+// Sign devuelve el valor del header X-Webhook-Signature: "sha256=" seguido del HMAC-SHA256 en
+// hexadecimal de "<timestamp>.<cuerpo>".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify comprueba la firma y que el timestamp (segundos Unix) no se aleje de now más que tolerance.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > tolerance || skew < -tolerance {
+		return ErrInvalidTimestamp
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) ||
+		!hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyRequest lee el cuerpo de la petición, verifica su firma con DefaultTolerance y lo devuelve.
+func VerifyRequest(r *http.Request, secret string) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = Verify(secret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, DefaultTolerance, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}